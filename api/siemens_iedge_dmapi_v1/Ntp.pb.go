@@ -31,12 +31,227 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Kind of an ntp server entry, written as the directive of the line in ntp.conf.
+type NtpEntryKind int32
+
+const (
+	NtpEntryKind_SERVER NtpEntryKind = 0 // single server, `server` directive
+	NtpEntryKind_POOL   NtpEntryKind = 1 // pool of servers resolved by DNS, `pool` directive
+	NtpEntryKind_PEER   NtpEntryKind = 2 // sibling device synchronized symmetrically, `peer` directive, only set with SetOrphanMode
+)
+
+// Enum value maps for NtpEntryKind.
+var (
+	NtpEntryKind_name = map[int32]string{
+		0: "SERVER",
+		1: "POOL",
+		2: "PEER",
+	}
+	NtpEntryKind_value = map[string]int32{
+		"SERVER": 0,
+		"POOL":   1,
+		"PEER":   2,
+	}
+)
+
+func (x NtpEntryKind) Enum() *NtpEntryKind {
+	p := new(NtpEntryKind)
+	*p = x
+	return p
+}
+
+func (x NtpEntryKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NtpEntryKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_Ntp_proto_enumTypes[0].Descriptor()
+}
+
+func (NtpEntryKind) Type() protoreflect.EnumType {
+	return &file_Ntp_proto_enumTypes[0]
+}
+
+func (x NtpEntryKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NtpEntryKind.Descriptor instead.
+func (NtpEntryKind) EnumDescriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{0}
+}
+
+// Digest algorithm of a symmetric key.
+type KeyType int32
+
+const (
+	KeyType_MD5        KeyType = 0 // MD5 digest
+	KeyType_SHA1       KeyType = 1 // SHA1 digest
+	KeyType_AES128CMAC KeyType = 2 // AES-128-CMAC, the key must be 32 hex digits
+)
+
+// Enum value maps for KeyType.
+var (
+	KeyType_name = map[int32]string{
+		0: "MD5",
+		1: "SHA1",
+		2: "AES128CMAC",
+	}
+	KeyType_value = map[string]int32{
+		"MD5":        0,
+		"SHA1":       1,
+		"AES128CMAC": 2,
+	}
+)
+
+func (x KeyType) Enum() *KeyType {
+	p := new(KeyType)
+	*p = x
+	return p
+}
+
+func (x KeyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_Ntp_proto_enumTypes[1].Descriptor()
+}
+
+func (KeyType) Type() protoreflect.EnumType {
+	return &file_Ntp_proto_enumTypes[1]
+}
+
+func (x KeyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeyType.Descriptor instead.
+func (KeyType) EnumDescriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{1}
+}
+
+// Access flag of a restrict rule, see ntp_acc(5).
+type RestrictFlag int32
+
+const (
+	RestrictFlag_NOMODIFY RestrictFlag = 0 // deny ntpq requests which modify the state of ntpd
+	RestrictFlag_NOQUERY  RestrictFlag = 1 // deny all ntpq requests
+	RestrictFlag_LIMITED  RestrictFlag = 2 // deny time service if the packet rate exceeds the discard limits
+	RestrictFlag_KOD      RestrictFlag = 3 // send a kiss-o'-death packet when limited denies time service
+	RestrictFlag_NOPEER   RestrictFlag = 4 // deny packets which would mobilize a new association
+)
+
+// Enum value maps for RestrictFlag.
+var (
+	RestrictFlag_name = map[int32]string{
+		0: "NOMODIFY",
+		1: "NOQUERY",
+		2: "LIMITED",
+		3: "KOD",
+		4: "NOPEER",
+	}
+	RestrictFlag_value = map[string]int32{
+		"NOMODIFY": 0,
+		"NOQUERY":  1,
+		"LIMITED":  2,
+		"KOD":      3,
+		"NOPEER":   4,
+	}
+)
+
+func (x RestrictFlag) Enum() *RestrictFlag {
+	p := new(RestrictFlag)
+	*p = x
+	return p
+}
+
+func (x RestrictFlag) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RestrictFlag) Descriptor() protoreflect.EnumDescriptor {
+	return file_Ntp_proto_enumTypes[2].Descriptor()
+}
+
+func (RestrictFlag) Type() protoreflect.EnumType {
+	return &file_Ntp_proto_enumTypes[2]
+}
+
+func (x RestrictFlag) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RestrictFlag.Descriptor instead.
+func (RestrictFlag) EnumDescriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{2}
+}
+
+// Reference clock driver of ntpsec, written as the name of the `refclock` line.
+type RefclockDriver int32
+
+const (
+	RefclockDriver_GPSD    RefclockDriver = 0 // GNSS receiver read through gpsd, `refclock gpsd`
+	RefclockDriver_NMEA    RefclockDriver = 1 // GNSS receiver sending NMEA sentences on a serial line, `refclock nmea`
+	RefclockDriver_PPS     RefclockDriver = 2 // pulse per second line of the kernel PPS API, `refclock pps`
+	RefclockDriver_SHM     RefclockDriver = 3 // shared memory segment written by another program, e.g. gpsd or chrony, `refclock shm`
+	RefclockDriver_GENERIC RefclockDriver = 4 // generic parse driver for serial receivers like DCF77, `refclock generic`, the receiver is set with subtype
+)
+
+// Enum value maps for RefclockDriver.
+var (
+	RefclockDriver_name = map[int32]string{
+		0: "GPSD",
+		1: "NMEA",
+		2: "PPS",
+		3: "SHM",
+		4: "GENERIC",
+	}
+	RefclockDriver_value = map[string]int32{
+		"GPSD":    0,
+		"NMEA":    1,
+		"PPS":     2,
+		"SHM":     3,
+		"GENERIC": 4,
+	}
+)
+
+func (x RefclockDriver) Enum() *RefclockDriver {
+	p := new(RefclockDriver)
+	*p = x
+	return p
+}
+
+func (x RefclockDriver) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RefclockDriver) Descriptor() protoreflect.EnumDescriptor {
+	return file_Ntp_proto_enumTypes[3].Descriptor()
+}
+
+func (RefclockDriver) Type() protoreflect.EnumType {
+	return &file_Ntp_proto_enumTypes[3]
+}
+
+func (x RefclockDriver) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RefclockDriver.Descriptor instead.
+func (RefclockDriver) EnumDescriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{3}
+}
+
 // Type contains an array of ntp server addresses.
 type Ntp struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	NtpServer     []string               `protobuf:"bytes,1,rep,name=ntpServer,proto3" json:"ntpServer,omitempty"` // array of multiple ntp server address.
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	NtpServer         []string               `protobuf:"bytes,1,rep,name=ntpServer,proto3" json:"ntpServer,omitempty"`                 // array of multiple ntp server address.
+	NtpServerEntries  []*NtpServerEntry      `protobuf:"bytes,2,rep,name=ntpServerEntries,proto3" json:"ntpServerEntries,omitempty"`   // typed server and pool entries, written after the plain ntpServer addresses.
+	SyncTimeoutMs     uint32                 `protobuf:"varint,3,opt,name=syncTimeoutMs,proto3" json:"syncTimeoutMs,omitempty"`        // SetNtpServer waits up to this time for ntpd to select a system peer and rolls back otherwise, 0 skips the check, at most 300000
+	FallbackNtpServer []string               `protobuf:"bytes,4,rep,name=fallbackNtpServer,proto3" json:"fallbackNtpServer,omitempty"` // servers used when none of the ntpServer addresses is reachable, only written by the timesyncd backend (FallbackNTP=)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Ntp) Reset() {
@@ -46,14 +261,1866 @@ func (x *Ntp) Reset() {
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Ntp) String() string {
+func (x *Ntp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ntp) ProtoMessage() {}
+
+func (x *Ntp) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ntp.ProtoReflect.Descriptor instead.
+func (*Ntp) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Ntp) GetNtpServer() []string {
+	if x != nil {
+		return x.NtpServer
+	}
+	return nil
+}
+
+func (x *Ntp) GetNtpServerEntries() []*NtpServerEntry {
+	if x != nil {
+		return x.NtpServerEntries
+	}
+	return nil
+}
+
+func (x *Ntp) GetSyncTimeoutMs() uint32 {
+	if x != nil {
+		return x.SyncTimeoutMs
+	}
+	return 0
+}
+
+func (x *Ntp) GetFallbackNtpServer() []string {
+	if x != nil {
+		return x.FallbackNtpServer
+	}
+	return nil
+}
+
+// Single ntp server entry with its ntpsec server options.
+type NtpServerEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`                                          // NTP server address (host name, IPv4 or IPv6 address)
+	Iburst        bool                   `protobuf:"varint,2,opt,name=iburst,proto3" json:"iburst,omitempty"`                                           // send a burst of packets when the server is unreachable (fast start)
+	Burst         bool                   `protobuf:"varint,3,opt,name=burst,proto3" json:"burst,omitempty"`                                             // send a burst of packets when the server is reachable
+	Prefer        bool                   `protobuf:"varint,4,opt,name=prefer,proto3" json:"prefer,omitempty"`                                           // mark the server as preferred for synchronization
+	Noselect      bool                   `protobuf:"varint,5,opt,name=noselect,proto3" json:"noselect,omitempty"`                                       // poll the server but never use it for synchronization
+	Minpoll       int32                  `protobuf:"varint,6,opt,name=minpoll,proto3" json:"minpoll,omitempty"`                                         // minimum poll interval as power of 2 seconds (3-17), 0 means ntpsec default
+	Maxpoll       int32                  `protobuf:"varint,7,opt,name=maxpoll,proto3" json:"maxpoll,omitempty"`                                         // maximum poll interval as power of 2 seconds (3-17), 0 means ntpsec default
+	KeyId         uint32                 `protobuf:"varint,8,opt,name=keyId,proto3" json:"keyId,omitempty"`                                             // id of a symmetric key created with CreateKey used to authenticate the server, 0 means no authentication
+	Version       int32                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`                                         // NTP version used in outgoing packets (1-4), 0 means ntpsec default
+	Kind          NtpEntryKind           `protobuf:"varint,10,opt,name=kind,proto3,enum=siemens.iedge.dmapi.ntp.v1.NtpEntryKind" json:"kind,omitempty"` // directive of the entry, server, pool or peer
+	Preempt       bool                   `protobuf:"varint,11,opt,name=preempt,proto3" json:"preempt,omitempty"`                                        // association may be removed by ntpsec when it is not useful, mostly used with pools, not supported by chrony
+	Nts           bool                   `protobuf:"varint,12,opt,name=nts,proto3" json:"nts,omitempty"`                                                // authenticate the server with Network Time Security, the address may contain the NTS-KE port (default 4460)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NtpServerEntry) Reset() {
+	*x = NtpServerEntry{}
+	mi := &file_Ntp_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NtpServerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NtpServerEntry) ProtoMessage() {}
+
+func (x *NtpServerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NtpServerEntry.ProtoReflect.Descriptor instead.
+func (*NtpServerEntry) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NtpServerEntry) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *NtpServerEntry) GetIburst() bool {
+	if x != nil {
+		return x.Iburst
+	}
+	return false
+}
+
+func (x *NtpServerEntry) GetBurst() bool {
+	if x != nil {
+		return x.Burst
+	}
+	return false
+}
+
+func (x *NtpServerEntry) GetPrefer() bool {
+	if x != nil {
+		return x.Prefer
+	}
+	return false
+}
+
+func (x *NtpServerEntry) GetNoselect() bool {
+	if x != nil {
+		return x.Noselect
+	}
+	return false
+}
+
+func (x *NtpServerEntry) GetMinpoll() int32 {
+	if x != nil {
+		return x.Minpoll
+	}
+	return 0
+}
+
+func (x *NtpServerEntry) GetMaxpoll() int32 {
+	if x != nil {
+		return x.Maxpoll
+	}
+	return 0
+}
+
+func (x *NtpServerEntry) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *NtpServerEntry) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *NtpServerEntry) GetKind() NtpEntryKind {
+	if x != nil {
+		return x.Kind
+	}
+	return NtpEntryKind_SERVER
+}
+
+func (x *NtpServerEntry) GetPreempt() bool {
+	if x != nil {
+		return x.Preempt
+	}
+	return false
+}
+
+func (x *NtpServerEntry) GetNts() bool {
+	if x != nil {
+		return x.Nts
+	}
+	return false
+}
+
+// Pool entry together with the servers ntpsec resolved from it.
+type PoolStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pool          *NtpServerEntry        `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`       // configured pool entry
+	Members       []string               `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"` // addresses of the associations ntpsec spawned from the pool
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolStatus) Reset() {
+	*x = PoolStatus{}
+	mi := &file_Ntp_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolStatus) ProtoMessage() {}
+
+func (x *PoolStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolStatus.ProtoReflect.Descriptor instead.
+func (*PoolStatus) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PoolStatus) GetPool() *NtpServerEntry {
+	if x != nil {
+		return x.Pool
+	}
+	return nil
+}
+
+func (x *PoolStatus) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// PEM encoded CA certificates trusted for the NTS key establishment.
+type NtsCaBundle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pem           string                 `protobuf:"bytes,1,opt,name=pem,proto3" json:"pem,omitempty"` // one or more PEM certificates, empty removes the custom bundle and the system trust store is used
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NtsCaBundle) Reset() {
+	*x = NtsCaBundle{}
+	mi := &file_Ntp_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NtsCaBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NtsCaBundle) ProtoMessage() {}
+
+func (x *NtsCaBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NtsCaBundle.ProtoReflect.Descriptor instead.
+func (*NtsCaBundle) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NtsCaBundle) GetPem() string {
+	if x != nil {
+		return x.Pem
+	}
+	return ""
+}
+
+// NTS state ntpd holds for a server configured with the nts option.
+type NtsStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Server        string                 `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`            // NTS server address as configured
+	KeSucceeded   bool                   `protobuf:"varint,2,opt,name=keSucceeded,proto3" json:"keSucceeded,omitempty"` // ntpd completed the NTS key establishment
+	Cookies       int32                  `protobuf:"varint,3,opt,name=cookies,proto3" json:"cookies,omitempty"`         // number of NTS cookies ntpd holds for the server
+	NtpServer     string                 `protobuf:"bytes,4,opt,name=ntpServer,proto3" json:"ntpServer,omitempty"`      // NTP server and port ntpd uses after the NTS key establishment
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`              // why ntpd holds no NTS state for the server or that it does not report the state
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NtsStatus) Reset() {
+	*x = NtsStatus{}
+	mi := &file_Ntp_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NtsStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NtsStatus) ProtoMessage() {}
+
+func (x *NtsStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NtsStatus.ProtoReflect.Descriptor instead.
+func (*NtsStatus) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NtsStatus) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *NtsStatus) GetKeSucceeded() bool {
+	if x != nil {
+		return x.KeSucceeded
+	}
+	return false
+}
+
+func (x *NtsStatus) GetCookies() int32 {
+	if x != nil {
+		return x.Cookies
+	}
+	return 0
+}
+
+func (x *NtsStatus) GetNtpServer() string {
+	if x != nil {
+		return x.NtpServer
+	}
+	return ""
+}
+
+func (x *NtsStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Symmetric key stored in /etc/ntpsec/ntp.keys, every key is trusted.
+type SymmetricKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         uint32                 `protobuf:"varint,1,opt,name=keyId,proto3" json:"keyId,omitempty"`                                       // key id (1-65535), bound to a server with the keyId of the server entry
+	Type          KeyType                `protobuf:"varint,2,opt,name=type,proto3,enum=siemens.iedge.dmapi.ntp.v1.KeyType" json:"type,omitempty"` // digest algorithm
+	Secret        string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`                                      // printable ASCII of 1-20 characters or hex of more than 20 digits, never returned by the service
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SymmetricKey) Reset() {
+	*x = SymmetricKey{}
+	mi := &file_Ntp_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SymmetricKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SymmetricKey) ProtoMessage() {}
+
+func (x *SymmetricKey) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SymmetricKey.ProtoReflect.Descriptor instead.
+func (*SymmetricKey) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SymmetricKey) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *SymmetricKey) GetType() KeyType {
+	if x != nil {
+		return x.Type
+	}
+	return KeyType_MD5
+}
+
+func (x *SymmetricKey) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+// Id of a symmetric key.
+type SymmetricKeyId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         uint32                 `protobuf:"varint,1,opt,name=keyId,proto3" json:"keyId,omitempty"` // key id (1-65535)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SymmetricKeyId) Reset() {
+	*x = SymmetricKeyId{}
+	mi := &file_Ntp_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SymmetricKeyId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SymmetricKeyId) ProtoMessage() {}
+
+func (x *SymmetricKeyId) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SymmetricKeyId.ProtoReflect.Descriptor instead.
+func (*SymmetricKeyId) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SymmetricKeyId) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+// List of symmetric keys without their secrets.
+type SymmetricKeys struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*SymmetricKey        `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"` // keys stored in /etc/ntpsec/ntp.keys
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SymmetricKeys) Reset() {
+	*x = SymmetricKeys{}
+	mi := &file_Ntp_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SymmetricKeys) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SymmetricKeys) ProtoMessage() {}
+
+func (x *SymmetricKeys) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SymmetricKeys.ProtoReflect.Descriptor instead.
+func (*SymmetricKeys) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SymmetricKeys) GetKeys() []*SymmetricKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// Request of a diagnostic query of an NTP server, the server does not need to be configured.
+type QueryServerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`      // NTP server address with optional port, e.g. time.example.com or [fd00::1]:123
+	Samples       int32                  `protobuf:"varint,2,opt,name=samples,proto3" json:"samples,omitempty"`     // number of packets sent one second apart (1-8), 0 means 1
+	Version       int32                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`     // NTP version of the requests (1-4), 0 means 4
+	TimeoutMs     int32                  `protobuf:"varint,4,opt,name=timeoutMs,proto3" json:"timeoutMs,omitempty"` // time to wait for each response in milliseconds, 0 means 2000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryServerRequest) Reset() {
+	*x = QueryServerRequest{}
+	mi := &file_Ntp_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryServerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryServerRequest) ProtoMessage() {}
+
+func (x *QueryServerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryServerRequest.ProtoReflect.Descriptor instead.
+func (*QueryServerRequest) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *QueryServerRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *QueryServerRequest) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *QueryServerRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *QueryServerRequest) GetTimeoutMs() int32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+// Result of a single NTP request.
+type NtpSample struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Offset         float32                `protobuf:"fixed32,1,opt,name=offset,proto3" json:"offset,omitempty"`                 // difference between local clock and server clock (in milliseconds)
+	Delay          float32                `protobuf:"fixed32,2,opt,name=delay,proto3" json:"delay,omitempty"`                   // network round trip time (in milliseconds)
+	Stratum        int32                  `protobuf:"varint,3,opt,name=stratum,proto3" json:"stratum,omitempty"`                // stratum of the server
+	ReferenceID    string                 `protobuf:"bytes,4,opt,name=referenceID,proto3" json:"referenceID,omitempty"`         // reference id of the server
+	LeapIndicator  int32                  `protobuf:"varint,5,opt,name=leapIndicator,proto3" json:"leapIndicator,omitempty"`    // leap indicator, 0 no warning, 1 last minute has 61 seconds, 2 last minute has 59 seconds, 3 not synchronized
+	RootDelay      float32                `protobuf:"fixed32,6,opt,name=rootDelay,proto3" json:"rootDelay,omitempty"`           // round trip time of the server to its reference clock (in milliseconds)
+	RootDispersion float32                `protobuf:"fixed32,7,opt,name=rootDispersion,proto3" json:"rootDispersion,omitempty"` // dispersion of the server to its reference clock (in milliseconds)
+	KissCode       string                 `protobuf:"bytes,8,opt,name=kissCode,proto3" json:"kissCode,omitempty"`               // Kiss-o'-Death code sent by the server, e.g. RATE or DENY
+	Version        int32                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`                // NTP version of the response
+	Poll           int32                  `protobuf:"varint,10,opt,name=poll,proto3" json:"poll,omitempty"`                     // poll exponent of the response
+	Precision      int32                  `protobuf:"varint,11,opt,name=precision,proto3" json:"precision,omitempty"`           // precision exponent of the server clock
+	Error          string                 `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`                    // reason why the sample is not usable, empty for a valid sample
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *NtpSample) Reset() {
+	*x = NtpSample{}
+	mi := &file_Ntp_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NtpSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NtpSample) ProtoMessage() {}
+
+func (x *NtpSample) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NtpSample.ProtoReflect.Descriptor instead.
+func (*NtpSample) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NtpSample) GetOffset() float32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *NtpSample) GetDelay() float32 {
+	if x != nil {
+		return x.Delay
+	}
+	return 0
+}
+
+func (x *NtpSample) GetStratum() int32 {
+	if x != nil {
+		return x.Stratum
+	}
+	return 0
+}
+
+func (x *NtpSample) GetReferenceID() string {
+	if x != nil {
+		return x.ReferenceID
+	}
+	return ""
+}
+
+func (x *NtpSample) GetLeapIndicator() int32 {
+	if x != nil {
+		return x.LeapIndicator
+	}
+	return 0
+}
+
+func (x *NtpSample) GetRootDelay() float32 {
+	if x != nil {
+		return x.RootDelay
+	}
+	return 0
+}
+
+func (x *NtpSample) GetRootDispersion() float32 {
+	if x != nil {
+		return x.RootDispersion
+	}
+	return 0
+}
+
+func (x *NtpSample) GetKissCode() string {
+	if x != nil {
+		return x.KissCode
+	}
+	return ""
+}
+
+func (x *NtpSample) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *NtpSample) GetPoll() int32 {
+	if x != nil {
+		return x.Poll
+	}
+	return 0
+}
+
+func (x *NtpSample) GetPrecision() int32 {
+	if x != nil {
+		return x.Precision
+	}
+	return 0
+}
+
+func (x *NtpSample) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Result of a diagnostic query, similar to ntpdate -q.
+type QueryServerResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"` // resolved server address
+	Best          *NtpSample             `protobuf:"bytes,2,opt,name=best,proto3" json:"best,omitempty"`       // valid sample with the lowest delay, empty if no sample is valid
+	Samples       []*NtpSample           `protobuf:"bytes,3,rep,name=samples,proto3" json:"samples,omitempty"` // all samples in the order they were sent
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryServerResult) Reset() {
+	*x = QueryServerResult{}
+	mi := &file_Ntp_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryServerResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryServerResult) ProtoMessage() {}
+
+func (x *QueryServerResult) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryServerResult.ProtoReflect.Descriptor instead.
+func (*QueryServerResult) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *QueryServerResult) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *QueryServerResult) GetBest() *NtpSample {
+	if x != nil {
+		return x.Best
+	}
+	return nil
+}
+
+func (x *QueryServerResult) GetSamples() []*NtpSample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+// Restrict rule granting time service to a subnet.
+type RestrictRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cidr          string                 `protobuf:"bytes,1,opt,name=cidr,proto3" json:"cidr,omitempty"`                                                        // subnet in CIDR notation, e.g. 192.168.1.0/24 or fd00::/64
+	Flags         []RestrictFlag         `protobuf:"varint,2,rep,packed,name=flags,proto3,enum=siemens.iedge.dmapi.ntp.v1.RestrictFlag" json:"flags,omitempty"` // access flags of the subnet
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestrictRule) Reset() {
+	*x = RestrictRule{}
+	mi := &file_Ntp_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestrictRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestrictRule) ProtoMessage() {}
+
+func (x *RestrictRule) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestrictRule.ProtoReflect.Descriptor instead.
+func (*RestrictRule) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RestrictRule) GetCidr() string {
+	if x != nil {
+		return x.Cidr
+	}
+	return ""
+}
+
+func (x *RestrictRule) GetFlags() []RestrictFlag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+// Time service offered to other devices.
+type ServingConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"` // serve time, without rules to everybody, otherwise to the subnets of the rules only
+	Rules         []*RestrictRule        `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`      // subnets which are served, must be empty if serving is disabled
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServingConfig) Reset() {
+	*x = ServingConfig{}
+	mi := &file_Ntp_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServingConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServingConfig) ProtoMessage() {}
+
+func (x *ServingConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServingConfig.ProtoReflect.Descriptor instead.
+func (*ServingConfig) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ServingConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *ServingConfig) GetRules() []*RestrictRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// Orphan mode keeps the devices of a cell synchronized to each other when no upstream server is reachable.
+// ntpsec has no local clock driver, an orphan device without reachable sources serves its own clock instead.
+// chrony has no orphan mode, the local clock fallback serves its own clock at the stratum instead.
+type OrphanConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stratum       uint32                 `protobuf:"varint,1,opt,name=stratum,proto3" json:"stratum,omitempty"`       // stratum of the orphan parent (1-15), `tos orphan`, 0 disables orphan mode
+	OrphanWait    uint32                 `protobuf:"varint,2,opt,name=orphanWait,proto3" json:"orphanWait,omitempty"` // seconds without a reachable source before orphan mode starts, `tos orphanwait`, 0 means ntpsec default (300)
+	Peers         []*NtpServerEntry      `protobuf:"bytes,3,rep,name=peers,proto3" json:"peers,omitempty"`            // sibling devices of the cell written as `peer` lines, the kind is ignored
+	LocalClock    bool                   `protobuf:"varint,4,opt,name=localClock,proto3" json:"localClock,omitempty"` // serve the own clock at the stratum when no source is reachable, chrony `local stratum`, not supported by ntpsec
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrphanConfig) Reset() {
+	*x = OrphanConfig{}
+	mi := &file_Ntp_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrphanConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrphanConfig) ProtoMessage() {}
+
+func (x *OrphanConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrphanConfig.ProtoReflect.Descriptor instead.
+func (*OrphanConfig) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *OrphanConfig) GetStratum() uint32 {
+	if x != nil {
+		return x.Stratum
+	}
+	return 0
+}
+
+func (x *OrphanConfig) GetOrphanWait() uint32 {
+	if x != nil {
+		return x.OrphanWait
+	}
+	return 0
+}
+
+func (x *OrphanConfig) GetPeers() []*NtpServerEntry {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+func (x *OrphanConfig) GetLocalClock() bool {
+	if x != nil {
+		return x.LocalClock
+	}
+	return false
+}
+
+// Reference clock entry with its ntpsec refclock options.
+type RefclockEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Driver        RefclockDriver         `protobuf:"varint,1,opt,name=driver,proto3,enum=siemens.iedge.dmapi.ntp.v1.RefclockDriver" json:"driver,omitempty"` // driver of the reference clock
+	Unit          uint32                 `protobuf:"varint,2,opt,name=unit,proto3" json:"unit,omitempty"`                                                    // unit number of the driver (0-255), selects the device, e.g. /dev/gps0
+	Refid         string                 `protobuf:"bytes,3,opt,name=refid,proto3" json:"refid,omitempty"`                                                   // reference id shown to clients (1-4 characters), empty means driver default
+	Time1         float32                `protobuf:"fixed32,4,opt,name=time1,proto3" json:"time1,omitempty"`                                                 // time offset added to the clock by the driver (in seconds), fudge time1
+	Time2         float32                `protobuf:"fixed32,5,opt,name=time2,proto3" json:"time2,omitempty"`                                                 // driver specific time offset (in seconds), e.g. the serial end of line offset of nmea, fudge time2
+	Prefer        bool                   `protobuf:"varint,6,opt,name=prefer,proto3" json:"prefer,omitempty"`                                                // mark the reference clock as preferred for synchronization, required for a pps clock to be used
+	Noselect      bool                   `protobuf:"varint,7,opt,name=noselect,proto3" json:"noselect,omitempty"`                                            // poll the reference clock but never use it for synchronization
+	Stratum       uint32                 `protobuf:"varint,8,opt,name=stratum,proto3" json:"stratum,omitempty"`                                              // stratum of the reference clock (0-15), 0 means driver default
+	Mode          uint32                 `protobuf:"varint,9,opt,name=mode,proto3" json:"mode,omitempty"`                                                    // driver specific mode, 0 means driver default: gpsd 0-2, nmea bit mask of the sentences and baud rate (0-1023), shm 0-1, not used by pps
+	Subtype       uint32                 `protobuf:"varint,10,opt,name=subtype,proto3" json:"subtype,omitempty"`                                             // receiver type of the generic driver, only used with GENERIC
+	Path          string                 `protobuf:"bytes,11,opt,name=path,proto3" json:"path,omitempty"`                                                    // device path overriding the default device of the unit
+	Ppspath       string                 `protobuf:"bytes,12,opt,name=ppspath,proto3" json:"ppspath,omitempty"`                                              // PPS device path of the nmea and generic drivers
+	Baud          uint32                 `protobuf:"varint,13,opt,name=baud,proto3" json:"baud,omitempty"`                                                   // serial speed of the nmea and generic drivers (4800-115200), 0 means driver default
+	Flag1         bool                   `protobuf:"varint,14,opt,name=flag1,proto3" json:"flag1,omitempty"`                                                 // driver specific flag1
+	Flag2         bool                   `protobuf:"varint,15,opt,name=flag2,proto3" json:"flag2,omitempty"`                                                 // driver specific flag2, e.g. capture the clear edge of pps
+	Flag3         bool                   `protobuf:"varint,16,opt,name=flag3,proto3" json:"flag3,omitempty"`                                                 // driver specific flag3, e.g. enable the kernel PPS discipline
+	Flag4         bool                   `protobuf:"varint,17,opt,name=flag4,proto3" json:"flag4,omitempty"`                                                 // driver specific flag4, e.g. record timestamps in the clockstats file
+	Minpoll       int32                  `protobuf:"varint,18,opt,name=minpoll,proto3" json:"minpoll,omitempty"`                                             // minimum poll interval as power of 2 seconds (3-17), 0 means ntpsec default
+	Maxpoll       int32                  `protobuf:"varint,19,opt,name=maxpoll,proto3" json:"maxpoll,omitempty"`                                             // maximum poll interval as power of 2 seconds (3-17), 0 means ntpsec default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefclockEntry) Reset() {
+	*x = RefclockEntry{}
+	mi := &file_Ntp_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefclockEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefclockEntry) ProtoMessage() {}
+
+func (x *RefclockEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefclockEntry.ProtoReflect.Descriptor instead.
+func (*RefclockEntry) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RefclockEntry) GetDriver() RefclockDriver {
+	if x != nil {
+		return x.Driver
+	}
+	return RefclockDriver_GPSD
+}
+
+func (x *RefclockEntry) GetUnit() uint32 {
+	if x != nil {
+		return x.Unit
+	}
+	return 0
+}
+
+func (x *RefclockEntry) GetRefid() string {
+	if x != nil {
+		return x.Refid
+	}
+	return ""
+}
+
+func (x *RefclockEntry) GetTime1() float32 {
+	if x != nil {
+		return x.Time1
+	}
+	return 0
+}
+
+func (x *RefclockEntry) GetTime2() float32 {
+	if x != nil {
+		return x.Time2
+	}
+	return 0
+}
+
+func (x *RefclockEntry) GetPrefer() bool {
+	if x != nil {
+		return x.Prefer
+	}
+	return false
+}
+
+func (x *RefclockEntry) GetNoselect() bool {
+	if x != nil {
+		return x.Noselect
+	}
+	return false
+}
+
+func (x *RefclockEntry) GetStratum() uint32 {
+	if x != nil {
+		return x.Stratum
+	}
+	return 0
+}
+
+func (x *RefclockEntry) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *RefclockEntry) GetSubtype() uint32 {
+	if x != nil {
+		return x.Subtype
+	}
+	return 0
+}
+
+func (x *RefclockEntry) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RefclockEntry) GetPpspath() string {
+	if x != nil {
+		return x.Ppspath
+	}
+	return ""
+}
+
+func (x *RefclockEntry) GetBaud() uint32 {
+	if x != nil {
+		return x.Baud
+	}
+	return 0
+}
+
+func (x *RefclockEntry) GetFlag1() bool {
+	if x != nil {
+		return x.Flag1
+	}
+	return false
+}
+
+func (x *RefclockEntry) GetFlag2() bool {
+	if x != nil {
+		return x.Flag2
+	}
+	return false
+}
+
+func (x *RefclockEntry) GetFlag3() bool {
+	if x != nil {
+		return x.Flag3
+	}
+	return false
+}
+
+func (x *RefclockEntry) GetFlag4() bool {
+	if x != nil {
+		return x.Flag4
+	}
+	return false
+}
+
+func (x *RefclockEntry) GetMinpoll() int32 {
+	if x != nil {
+		return x.Minpoll
+	}
+	return 0
+}
+
+func (x *RefclockEntry) GetMaxpoll() int32 {
+	if x != nil {
+		return x.Maxpoll
+	}
+	return 0
+}
+
+// Reference clocks written as `refclock` lines to ntp.conf.
+type Refclocks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Refclocks     []*RefclockEntry       `protobuf:"bytes,1,rep,name=refclocks,proto3" json:"refclocks,omitempty"` // configured reference clocks, empty removes all refclock lines except the ones of other drivers or options, which are kept
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Refclocks) Reset() {
+	*x = Refclocks{}
+	mi := &file_Ntp_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Refclocks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Refclocks) ProtoMessage() {}
+
+func (x *Refclocks) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Refclocks.ProtoReflect.Descriptor instead.
+func (*Refclocks) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Refclocks) GetRefclocks() []*RefclockEntry {
+	if x != nil {
+		return x.Refclocks
+	}
+	return nil
+}
+
+// Applied ntp.conf revision of the configuration history.
+type ConfigRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                     // revision number, increasing with every change of ntp.conf, revision 1 is the ntp.conf before the first change
+	Time          string                 `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`                  // time of the change (RFC 3339)
+	Servers       []string               `protobuf:"bytes,3,rep,name=servers,proto3" json:"servers,omitempty"`            // server and pool lines of the revision
+	Applied       bool                   `protobuf:"varint,4,opt,name=applied,proto3" json:"applied,omitempty"`           // the revision was applied, otherwise the previous ntp.conf was restored
+	Result        string                 `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`              // error of a failed apply, empty if it was applied
+	FailedStep    string                 `protobuf:"bytes,6,opt,name=failedStep,proto3" json:"failedStep,omitempty"`      // step of a failed apply, e.g. start or verify-sync
+	RestoredFrom  uint64                 `protobuf:"varint,7,opt,name=restoredFrom,proto3" json:"restoredFrom,omitempty"` // revision restored by RestoreConfig, 0 for the other changes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigRevision) Reset() {
+	*x = ConfigRevision{}
+	mi := &file_Ntp_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigRevision) ProtoMessage() {}
+
+func (x *ConfigRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigRevision.ProtoReflect.Descriptor instead.
+func (*ConfigRevision) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ConfigRevision) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConfigRevision) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *ConfigRevision) GetServers() []string {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+func (x *ConfigRevision) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *ConfigRevision) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *ConfigRevision) GetFailedStep() string {
+	if x != nil {
+		return x.FailedStep
+	}
+	return ""
+}
+
+func (x *ConfigRevision) GetRestoredFrom() uint64 {
+	if x != nil {
+		return x.RestoredFrom
+	}
+	return 0
+}
+
+// Configuration history, newest revision first.
+type ConfigHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*ConfigRevision      `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"` // the kept revisions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigHistory) Reset() {
+	*x = ConfigHistory{}
+	mi := &file_Ntp_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigHistory) ProtoMessage() {}
+
+func (x *ConfigHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigHistory.ProtoReflect.Descriptor instead.
+func (*ConfigHistory) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ConfigHistory) GetRevisions() []*ConfigRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+// Revisions to compare.
+type ConfigDiffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          uint64                 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"` // old revision
+	To            uint64                 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`     // new revision, 0 means the current ntp.conf
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigDiffRequest) Reset() {
+	*x = ConfigDiffRequest{}
+	mi := &file_Ntp_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigDiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigDiffRequest) ProtoMessage() {}
+
+func (x *ConfigDiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigDiffRequest.ProtoReflect.Descriptor instead.
+func (*ConfigDiffRequest) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ConfigDiffRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ConfigDiffRequest) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+// Difference between two revisions.
+type ConfigDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Diff          string                 `protobuf:"bytes,1,opt,name=diff,proto3" json:"diff,omitempty"` // unified diff of the ntp.conf files, empty if they are equal
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigDiff) Reset() {
+	*x = ConfigDiff{}
+	mi := &file_Ntp_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigDiff) ProtoMessage() {}
+
+func (x *ConfigDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigDiff.ProtoReflect.Descriptor instead.
+func (*ConfigDiff) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ConfigDiff) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+// Revision to restore.
+type RestoreConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                       // revision number
+	SyncTimeoutMs uint32                 `protobuf:"varint,2,opt,name=syncTimeoutMs,proto3" json:"syncTimeoutMs,omitempty"` // wait up to this time for ntpd to select a system peer and roll back otherwise, 0 skips the check, at most 300000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreConfigRequest) Reset() {
+	*x = RestoreConfigRequest{}
+	mi := &file_Ntp_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreConfigRequest) ProtoMessage() {}
+
+func (x *RestoreConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreConfigRequest.ProtoReflect.Descriptor instead.
+func (*RestoreConfigRequest) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RestoreConfigRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RestoreConfigRequest) GetSyncTimeoutMs() uint32 {
+	if x != nil {
+		return x.SyncTimeoutMs
+	}
+	return 0
+}
+
+// Peer Details read from ntpd with the NTP control protocol, the first fields match the columns of ntpq -pn.
+type PeerDetails struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RemoteServer   string                 `protobuf:"bytes,1,opt,name=remoteServer,proto3" json:"remoteServer,omitempty"`        // NTP server address
+	ReferenceID    string                 `protobuf:"bytes,2,opt,name=referenceID,proto3" json:"referenceID,omitempty"`          // Reference id for the NTP server
+	Stratum        string                 `protobuf:"bytes,3,opt,name=stratum,proto3" json:"stratum,omitempty"`                  // Stratum for the NTP Server
+	Type           string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`                        // Type of server (local, unicast, multicast, or broadcast)
+	Poll           int32                  `protobuf:"varint,5,opt,name=poll,proto3" json:"poll,omitempty"`                       // How frequently to query server (in seconds)
+	When           int32                  `protobuf:"varint,6,opt,name=when,proto3" json:"when,omitempty"`                       // How many seconds passed after the last poll.
+	Reach          string                 `protobuf:"bytes,7,opt,name=reach,proto3" json:"reach,omitempty"`                      // octal bitmask of success or failure of last 8 queries (left-shifted). eg:375
+	Delay          float32                `protobuf:"fixed32,8,opt,name=delay,proto3" json:"delay,omitempty"`                    // network round trip time (in milliseconds)
+	Offset         float32                `protobuf:"fixed32,9,opt,name=offset,proto3" json:"offset,omitempty"`                  // difference between local clock and remote clock (in milliseconds)
+	Jitter         float32                `protobuf:"fixed32,10,opt,name=jitter,proto3" json:"jitter,omitempty"`                 // Difference of successive time values from server (in milliseconds)
+	AssociationId  uint32                 `protobuf:"varint,11,opt,name=associationId,proto3" json:"associationId,omitempty"`    // association id of the peer in ntpd
+	Address        string                 `protobuf:"bytes,12,opt,name=address,proto3" json:"address,omitempty"`                 // peer address without the selection character of remoteServer, host name for pools and reference clocks
+	Port           int32                  `protobuf:"varint,13,opt,name=port,proto3" json:"port,omitempty"`                      // peer port
+	LocalAddress   string                 `protobuf:"bytes,14,opt,name=localAddress,proto3" json:"localAddress,omitempty"`       // local address used to reach the peer
+	Selection      string                 `protobuf:"bytes,15,opt,name=selection,proto3" json:"selection,omitempty"`             // selection state of the peer, e.g. sys.peer, candidate, outlier or reject
+	Configured     bool                   `protobuf:"varint,16,opt,name=configured,proto3" json:"configured,omitempty"`          // peer is configured in ntp.conf, false for servers spawned from a pool
+	Authenticated  bool                   `protobuf:"varint,17,opt,name=authenticated,proto3" json:"authenticated,omitempty"`    // last packets of the peer were authenticated
+	RootDelay      float32                `protobuf:"fixed32,18,opt,name=rootDelay,proto3" json:"rootDelay,omitempty"`           // round trip time of the peer to its reference clock (in milliseconds)
+	RootDispersion float32                `protobuf:"fixed32,19,opt,name=rootDispersion,proto3" json:"rootDispersion,omitempty"` // dispersion of the peer to its reference clock (in milliseconds)
+	Dispersion     float32                `protobuf:"fixed32,20,opt,name=dispersion,proto3" json:"dispersion,omitempty"`         // dispersion of the peer (in milliseconds)
+	LeapIndicator  int32                  `protobuf:"varint,21,opt,name=leapIndicator,proto3" json:"leapIndicator,omitempty"`    // leap indicator of the peer, 3 means not synchronized
+	Precision      int32                  `protobuf:"varint,22,opt,name=precision,proto3" json:"precision,omitempty"`            // precision of the peer clock as power of 2 seconds
+	HostPoll       int32                  `protobuf:"varint,23,opt,name=hostPoll,proto3" json:"hostPoll,omitempty"`              // poll interval of the local host as power of 2 seconds
+	PeerPoll       int32                  `protobuf:"varint,24,opt,name=peerPoll,proto3" json:"peerPoll,omitempty"`              // poll interval of the peer as power of 2 seconds
+	Flash          uint32                 `protobuf:"varint,25,opt,name=flash,proto3" json:"flash,omitempty"`                    // flash status bits of the last packet of the peer, 0 means no error
+	Refclock       *RefclockStatus        `protobuf:"bytes,26,opt,name=refclock,proto3" json:"refclock,omitempty"`               // status of the driver, only set for reference clocks
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PeerDetails) Reset() {
+	*x = PeerDetails{}
+	mi := &file_Ntp_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerDetails) ProtoMessage() {}
+
+func (x *PeerDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerDetails.ProtoReflect.Descriptor instead.
+func (*PeerDetails) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PeerDetails) GetRemoteServer() string {
+	if x != nil {
+		return x.RemoteServer
+	}
+	return ""
+}
+
+func (x *PeerDetails) GetReferenceID() string {
+	if x != nil {
+		return x.ReferenceID
+	}
+	return ""
+}
+
+func (x *PeerDetails) GetStratum() string {
+	if x != nil {
+		return x.Stratum
+	}
+	return ""
+}
+
+func (x *PeerDetails) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PeerDetails) GetPoll() int32 {
+	if x != nil {
+		return x.Poll
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetWhen() int32 {
+	if x != nil {
+		return x.When
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetReach() string {
+	if x != nil {
+		return x.Reach
+	}
+	return ""
+}
+
+func (x *PeerDetails) GetDelay() float32 {
+	if x != nil {
+		return x.Delay
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetOffset() float32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetJitter() float32 {
+	if x != nil {
+		return x.Jitter
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetAssociationId() uint32 {
+	if x != nil {
+		return x.AssociationId
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PeerDetails) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetLocalAddress() string {
+	if x != nil {
+		return x.LocalAddress
+	}
+	return ""
+}
+
+func (x *PeerDetails) GetSelection() string {
+	if x != nil {
+		return x.Selection
+	}
+	return ""
+}
+
+func (x *PeerDetails) GetConfigured() bool {
+	if x != nil {
+		return x.Configured
+	}
+	return false
+}
+
+func (x *PeerDetails) GetAuthenticated() bool {
+	if x != nil {
+		return x.Authenticated
+	}
+	return false
+}
+
+func (x *PeerDetails) GetRootDelay() float32 {
+	if x != nil {
+		return x.RootDelay
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetRootDispersion() float32 {
+	if x != nil {
+		return x.RootDispersion
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetDispersion() float32 {
+	if x != nil {
+		return x.Dispersion
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetLeapIndicator() int32 {
+	if x != nil {
+		return x.LeapIndicator
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetPrecision() int32 {
+	if x != nil {
+		return x.Precision
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetHostPoll() int32 {
+	if x != nil {
+		return x.HostPoll
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetPeerPoll() int32 {
+	if x != nil {
+		return x.PeerPoll
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetFlash() uint32 {
+	if x != nil {
+		return x.Flash
+	}
+	return 0
+}
+
+func (x *PeerDetails) GetRefclock() *RefclockStatus {
+	if x != nil {
+		return x.Refclock
+	}
+	return nil
+}
+
+// Status of a reference clock driver read from ntpd.
+type RefclockStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`            // name of the driver and its unit, e.g. SHM(0)
+	Device        string                 `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`        // description of the device
+	Timecode      string                 `protobuf:"bytes,3,opt,name=timecode,proto3" json:"timecode,omitempty"`    // last time code received from the device
+	Polls         uint32                 `protobuf:"varint,4,opt,name=polls,proto3" json:"polls,omitempty"`         // number of polls of the device
+	NoReply       uint32                 `protobuf:"varint,5,opt,name=noReply,proto3" json:"noReply,omitempty"`     // number of polls without a reply
+	BadFormat     uint32                 `protobuf:"varint,6,opt,name=badFormat,proto3" json:"badFormat,omitempty"` // number of replies with a bad format
+	BadData       uint32                 `protobuf:"varint,7,opt,name=badData,proto3" json:"badData,omitempty"`     // number of replies with invalid data
+	Time1         float32                `protobuf:"fixed32,8,opt,name=time1,proto3" json:"time1,omitempty"`        // fudge time1 in use (in milliseconds)
+	Time2         float32                `protobuf:"fixed32,9,opt,name=time2,proto3" json:"time2,omitempty"`        // fudge time2 in use (in milliseconds)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefclockStatus) Reset() {
+	*x = RefclockStatus{}
+	mi := &file_Ntp_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefclockStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefclockStatus) ProtoMessage() {}
+
+func (x *RefclockStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefclockStatus.ProtoReflect.Descriptor instead.
+func (*RefclockStatus) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RefclockStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RefclockStatus) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *RefclockStatus) GetTimecode() string {
+	if x != nil {
+		return x.Timecode
+	}
+	return ""
+}
+
+func (x *RefclockStatus) GetPolls() uint32 {
+	if x != nil {
+		return x.Polls
+	}
+	return 0
+}
+
+func (x *RefclockStatus) GetNoReply() uint32 {
+	if x != nil {
+		return x.NoReply
+	}
+	return 0
+}
+
+func (x *RefclockStatus) GetBadFormat() uint32 {
+	if x != nil {
+		return x.BadFormat
+	}
+	return 0
+}
+
+func (x *RefclockStatus) GetBadData() uint32 {
+	if x != nil {
+		return x.BadData
+	}
+	return 0
+}
+
+func (x *RefclockStatus) GetTime1() float32 {
+	if x != nil {
+		return x.Time1
+	}
+	return 0
+}
+
+func (x *RefclockStatus) GetTime2() float32 {
+	if x != nil {
+		return x.Time2
+	}
+	return 0
+}
+
+// Type for ntp current sync status
+type Status struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	IsNtpServiceRunning   bool                   `protobuf:"varint,1,opt,name=isNtpServiceRunning,proto3" json:"isNtpServiceRunning,omitempty"`    // indicates that ntp service is running or not
+	IsSynced              bool                   `protobuf:"varint,2,opt,name=isSynced,proto3" json:"isSynced,omitempty"`                          // indicates NTP server synced or not
+	LastConfigurationTime string                 `protobuf:"bytes,3,opt,name=lastConfigurationTime,proto3" json:"lastConfigurationTime,omitempty"` // time of the last performed iedk ntp configuration.
+	LastSyncTime          string                 `protobuf:"bytes,4,opt,name=lastSyncTime,proto3" json:"lastSyncTime,omitempty"`                   // time of the last ntp sync operation.
+	PeerDetails           []*PeerDetails         `protobuf:"bytes,5,rep,name=peerDetails,proto3" json:"peerDetails,omitempty"`                     // NTPQ peer information array. Only exist after ntp configuration done.
+	Pools                 []*PoolStatus          `protobuf:"bytes,6,rep,name=pools,proto3" json:"pools,omitempty"`                                 // configured pools and their resolved members.
+	Nts                   []*NtsStatus           `protobuf:"bytes,7,rep,name=nts,proto3" json:"nts,omitempty"`                                     // NTS state of ntpd for the servers with the nts option.
+	Serving               *ServingConfig         `protobuf:"bytes,8,opt,name=serving,proto3" json:"serving,omitempty"`                             // time service offered to other devices.
+	Orphan                bool                   `protobuf:"varint,9,opt,name=orphan,proto3" json:"orphan,omitempty"`                              // the device runs in orphan mode, its stratum is the orphan stratum or above.
+	SystemVariables       *SystemVariables       `protobuf:"bytes,10,opt,name=systemVariables,proto3" json:"systemVariables,omitempty"`            // system variables of the time daemon, missing if it does not answer.
+	Leap                  *LeapStatus            `protobuf:"bytes,11,opt,name=leap,proto3" json:"leap,omitempty"`                                  // leap seconds file and leap indicator, only set for ntpsec.
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Status) Reset() {
+	*x = Status{}
+	mi := &file_Ntp_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Status) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Ntp) ProtoMessage() {}
+func (*Status) ProtoMessage() {}
 
-func (x *Ntp) ProtoReflect() protoreflect.Message {
-	mi := &file_Ntp_proto_msgTypes[0]
+func (x *Status) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -64,50 +2131,121 @@ func (x *Ntp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Ntp.ProtoReflect.Descriptor instead.
-func (*Ntp) Descriptor() ([]byte, []int) {
-	return file_Ntp_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use Status.ProtoReflect.Descriptor instead.
+func (*Status) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *Ntp) GetNtpServer() []string {
+func (x *Status) GetIsNtpServiceRunning() bool {
 	if x != nil {
-		return x.NtpServer
+		return x.IsNtpServiceRunning
+	}
+	return false
+}
+
+func (x *Status) GetIsSynced() bool {
+	if x != nil {
+		return x.IsSynced
+	}
+	return false
+}
+
+func (x *Status) GetLastConfigurationTime() string {
+	if x != nil {
+		return x.LastConfigurationTime
+	}
+	return ""
+}
+
+func (x *Status) GetLastSyncTime() string {
+	if x != nil {
+		return x.LastSyncTime
+	}
+	return ""
+}
+
+func (x *Status) GetPeerDetails() []*PeerDetails {
+	if x != nil {
+		return x.PeerDetails
 	}
 	return nil
 }
 
-// Peer Details from ntpq -p output
-type PeerDetails struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RemoteServer  string                 `protobuf:"bytes,1,opt,name=remoteServer,proto3" json:"remoteServer,omitempty"` // NTP server address
-	ReferenceID   string                 `protobuf:"bytes,2,opt,name=referenceID,proto3" json:"referenceID,omitempty"`   // Reference id for the NTP server
-	Stratum       string                 `protobuf:"bytes,3,opt,name=stratum,proto3" json:"stratum,omitempty"`           // Stratum for the NTP Server
-	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`                 // Type of server (local, unicast, multicast, or broadcast)
-	Poll          int32                  `protobuf:"varint,5,opt,name=poll,proto3" json:"poll,omitempty"`                // How frequently to query server (in seconds)
-	When          int32                  `protobuf:"varint,6,opt,name=when,proto3" json:"when,omitempty"`                // How many seconds passed after the last poll.
-	Reach         string                 `protobuf:"bytes,7,opt,name=reach,proto3" json:"reach,omitempty"`               // octal bitmask of success or failure of last 8 queries (left-shifted). eg:375
-	Delay         float32                `protobuf:"fixed32,8,opt,name=delay,proto3" json:"delay,omitempty"`             // network round trip time (in milliseconds)
-	Offset        float32                `protobuf:"fixed32,9,opt,name=offset,proto3" json:"offset,omitempty"`           // difference between local clock and remote clock (in milliseconds)
-	Jitter        float32                `protobuf:"fixed32,10,opt,name=jitter,proto3" json:"jitter,omitempty"`          // Difference of successive time values from server (in milliseconds)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Status) GetPools() []*PoolStatus {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
 }
 
-func (x *PeerDetails) Reset() {
-	*x = PeerDetails{}
-	mi := &file_Ntp_proto_msgTypes[1]
+func (x *Status) GetNts() []*NtsStatus {
+	if x != nil {
+		return x.Nts
+	}
+	return nil
+}
+
+func (x *Status) GetServing() *ServingConfig {
+	if x != nil {
+		return x.Serving
+	}
+	return nil
+}
+
+func (x *Status) GetOrphan() bool {
+	if x != nil {
+		return x.Orphan
+	}
+	return false
+}
+
+func (x *Status) GetSystemVariables() *SystemVariables {
+	if x != nil {
+		return x.SystemVariables
+	}
+	return nil
+}
+
+func (x *Status) GetLeap() *LeapStatus {
+	if x != nil {
+		return x.Leap
+	}
+	return nil
+}
+
+// System variables of the time daemon as ntpq -c rv prints them. Values a backend does not report are 0.
+type SystemVariables struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Offset         float32                `protobuf:"fixed32,1,opt,name=offset,proto3" json:"offset,omitempty"`                 // offset of the system clock to the selected time (in milliseconds)
+	Frequency      float32                `protobuf:"fixed32,2,opt,name=frequency,proto3" json:"frequency,omitempty"`           // frequency correction of the system clock (in ppm)
+	SysJitter      float32                `protobuf:"fixed32,3,opt,name=sysJitter,proto3" json:"sysJitter,omitempty"`           // combined jitter of the selected sources (in milliseconds)
+	ClkWander      float32                `protobuf:"fixed32,4,opt,name=clkWander,proto3" json:"clkWander,omitempty"`           // frequency wander of the system clock (in ppm)
+	RootDelay      float32                `protobuf:"fixed32,5,opt,name=rootDelay,proto3" json:"rootDelay,omitempty"`           // total round trip time to the primary reference (in milliseconds)
+	RootDispersion float32                `protobuf:"fixed32,6,opt,name=rootDispersion,proto3" json:"rootDispersion,omitempty"` // total dispersion to the primary reference (in milliseconds)
+	Leap           uint32                 `protobuf:"varint,7,opt,name=leap,proto3" json:"leap,omitempty"`                      // leap indicator, 0 no warning, 1 second inserted, 2 second deleted, 3 not synchronized
+	Stratum        uint32                 `protobuf:"varint,8,opt,name=stratum,proto3" json:"stratum,omitempty"`                // stratum of the system clock, 16 while not synchronized
+	Refid          string                 `protobuf:"bytes,9,opt,name=refid,proto3" json:"refid,omitempty"`                     // reference id, the address of the system peer or the refid of a reference clock
+	SystemPeer     uint32                 `protobuf:"varint,10,opt,name=systemPeer,proto3" json:"systemPeer,omitempty"`         // association id of the system peer, 0 without system peer
+	Uptime         uint64                 `protobuf:"varint,11,opt,name=uptime,proto3" json:"uptime,omitempty"`                 // time since the time daemon was started (in seconds)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SystemVariables) Reset() {
+	*x = SystemVariables{}
+	mi := &file_Ntp_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PeerDetails) String() string {
+func (x *SystemVariables) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PeerDetails) ProtoMessage() {}
+func (*SystemVariables) ProtoMessage() {}
 
-func (x *PeerDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_Ntp_proto_msgTypes[1]
+func (x *SystemVariables) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -118,108 +2256,111 @@ func (x *PeerDetails) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PeerDetails.ProtoReflect.Descriptor instead.
-func (*PeerDetails) Descriptor() ([]byte, []int) {
-	return file_Ntp_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use SystemVariables.ProtoReflect.Descriptor instead.
+func (*SystemVariables) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *PeerDetails) GetRemoteServer() string {
+func (x *SystemVariables) GetOffset() float32 {
 	if x != nil {
-		return x.RemoteServer
+		return x.Offset
 	}
-	return ""
+	return 0
 }
 
-func (x *PeerDetails) GetReferenceID() string {
+func (x *SystemVariables) GetFrequency() float32 {
 	if x != nil {
-		return x.ReferenceID
+		return x.Frequency
 	}
-	return ""
+	return 0
 }
 
-func (x *PeerDetails) GetStratum() string {
+func (x *SystemVariables) GetSysJitter() float32 {
 	if x != nil {
-		return x.Stratum
+		return x.SysJitter
 	}
-	return ""
+	return 0
 }
 
-func (x *PeerDetails) GetType() string {
+func (x *SystemVariables) GetClkWander() float32 {
 	if x != nil {
-		return x.Type
+		return x.ClkWander
 	}
-	return ""
+	return 0
 }
 
-func (x *PeerDetails) GetPoll() int32 {
+func (x *SystemVariables) GetRootDelay() float32 {
 	if x != nil {
-		return x.Poll
+		return x.RootDelay
 	}
 	return 0
 }
 
-func (x *PeerDetails) GetWhen() int32 {
+func (x *SystemVariables) GetRootDispersion() float32 {
 	if x != nil {
-		return x.When
+		return x.RootDispersion
 	}
 	return 0
 }
 
-func (x *PeerDetails) GetReach() string {
+func (x *SystemVariables) GetLeap() uint32 {
 	if x != nil {
-		return x.Reach
+		return x.Leap
 	}
-	return ""
+	return 0
 }
 
-func (x *PeerDetails) GetDelay() float32 {
+func (x *SystemVariables) GetStratum() uint32 {
 	if x != nil {
-		return x.Delay
+		return x.Stratum
 	}
 	return 0
 }
 
-func (x *PeerDetails) GetOffset() float32 {
+func (x *SystemVariables) GetRefid() string {
 	if x != nil {
-		return x.Offset
+		return x.Refid
+	}
+	return ""
+}
+
+func (x *SystemVariables) GetSystemPeer() uint32 {
+	if x != nil {
+		return x.SystemPeer
 	}
 	return 0
 }
 
-func (x *PeerDetails) GetJitter() float32 {
+func (x *SystemVariables) GetUptime() uint64 {
 	if x != nil {
-		return x.Jitter
+		return x.Uptime
 	}
 	return 0
 }
 
-// Type for ntp current sync status
-type Status struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	IsNtpServiceRunning   bool                   `protobuf:"varint,1,opt,name=isNtpServiceRunning,proto3" json:"isNtpServiceRunning,omitempty"`    // indicates that ntp service is running or not
-	IsSynced              bool                   `protobuf:"varint,2,opt,name=isSynced,proto3" json:"isSynced,omitempty"`                          // indicates NTP server synced or not
-	LastConfigurationTime string                 `protobuf:"bytes,3,opt,name=lastConfigurationTime,proto3" json:"lastConfigurationTime,omitempty"` // time of the last performed iedk ntp configuration.
-	LastSyncTime          string                 `protobuf:"bytes,4,opt,name=lastSyncTime,proto3" json:"lastSyncTime,omitempty"`                   // time of the last ntp sync operation.
-	PeerDetails           []*PeerDetails         `protobuf:"bytes,5,rep,name=peerDetails,proto3" json:"peerDetails,omitempty"`                     // NTPQ peer information array. Only exist after ntp configuration done.
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+// Leap seconds file in the format of the IERS leap-seconds.list.
+type LeapSecondsFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"` // content of leap-seconds.list including the #$ update, #@ expiry and #h hash lines
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Status) Reset() {
-	*x = Status{}
-	mi := &file_Ntp_proto_msgTypes[2]
+func (x *LeapSecondsFile) Reset() {
+	*x = LeapSecondsFile{}
+	mi := &file_Ntp_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Status) String() string {
+func (x *LeapSecondsFile) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Status) ProtoMessage() {}
+func (*LeapSecondsFile) ProtoMessage() {}
 
-func (x *Status) ProtoReflect() protoreflect.Message {
-	mi := &file_Ntp_proto_msgTypes[2]
+func (x *LeapSecondsFile) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -230,53 +2371,298 @@ func (x *Status) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Status.ProtoReflect.Descriptor instead.
-func (*Status) Descriptor() ([]byte, []int) {
-	return file_Ntp_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use LeapSecondsFile.ProtoReflect.Descriptor instead.
+func (*LeapSecondsFile) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *Status) GetIsNtpServiceRunning() bool {
+func (x *LeapSecondsFile) GetContent() string {
 	if x != nil {
-		return x.IsNtpServiceRunning
+		return x.Content
+	}
+	return ""
+}
+
+// State of the leap seconds file of the leapfile directive and the leap indicator of ntpd.
+type LeapStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          string                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`                    // path of the leapfile directive of ntp.conf, empty if ntpsec uses no leap seconds file
+	Updated       string                 `protobuf:"bytes,2,opt,name=updated,proto3" json:"updated,omitempty"`              // time the file was updated by the IERS (RFC 3339)
+	Expires       string                 `protobuf:"bytes,3,opt,name=expires,proto3" json:"expires,omitempty"`              // expiry time of the file (RFC 3339)
+	Expired       bool                   `protobuf:"varint,4,opt,name=expired,proto3" json:"expired,omitempty"`             // the file is expired, ntpsec does not announce leap seconds from it anymore
+	ExpiresSoon   bool                   `protobuf:"varint,5,opt,name=expiresSoon,proto3" json:"expiresSoon,omitempty"`     // the file expires within 30 days, a new one should be uploaded
+	TaiOffset     int32                  `protobuf:"varint,6,opt,name=taiOffset,proto3" json:"taiOffset,omitempty"`         // current offset between TAI and UTC (in seconds)
+	PendingLeap   string                 `protobuf:"bytes,7,opt,name=pendingLeap,proto3" json:"pendingLeap,omitempty"`      // time of the next leap second announced by the file (RFC 3339), empty if there is none
+	LeapIndicator uint32                 `protobuf:"varint,8,opt,name=leapIndicator,proto3" json:"leapIndicator,omitempty"` // leap indicator of ntpd, 1 a second is inserted, 2 a second is deleted at the end of the day
+	Error         string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`                  // reason why the file can not be used, e.g. its hash does not match
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeapStatus) Reset() {
+	*x = LeapStatus{}
+	mi := &file_Ntp_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeapStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeapStatus) ProtoMessage() {}
+
+func (x *LeapStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeapStatus.ProtoReflect.Descriptor instead.
+func (*LeapStatus) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LeapStatus) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *LeapStatus) GetUpdated() string {
+	if x != nil {
+		return x.Updated
+	}
+	return ""
+}
+
+func (x *LeapStatus) GetExpires() string {
+	if x != nil {
+		return x.Expires
+	}
+	return ""
+}
+
+func (x *LeapStatus) GetExpired() bool {
+	if x != nil {
+		return x.Expired
 	}
 	return false
 }
 
-func (x *Status) GetIsSynced() bool {
+func (x *LeapStatus) GetExpiresSoon() bool {
 	if x != nil {
-		return x.IsSynced
+		return x.ExpiresSoon
 	}
 	return false
 }
 
-func (x *Status) GetLastConfigurationTime() string {
+func (x *LeapStatus) GetTaiOffset() int32 {
 	if x != nil {
-		return x.LastConfigurationTime
+		return x.TaiOffset
+	}
+	return 0
+}
+
+func (x *LeapStatus) GetPendingLeap() string {
+	if x != nil {
+		return x.PendingLeap
 	}
 	return ""
 }
 
-func (x *Status) GetLastSyncTime() string {
+func (x *LeapStatus) GetLeapIndicator() uint32 {
 	if x != nil {
-		return x.LastSyncTime
+		return x.LeapIndicator
+	}
+	return 0
+}
+
+func (x *LeapStatus) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-func (x *Status) GetPeerDetails() []*PeerDetails {
+// Filter of the WatchStatus stream.
+type WatchStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OffsetDeltaMs float32                `protobuf:"fixed32,1,opt,name=offsetDeltaMs,proto3" json:"offsetDeltaMs,omitempty"` // minimum change of the system peer offset which is reported (in milliseconds), 0 means 5
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchStatusRequest) Reset() {
+	*x = WatchStatusRequest{}
+	mi := &file_Ntp_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchStatusRequest) ProtoMessage() {}
+
+func (x *WatchStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_Ntp_proto_msgTypes[27]
 	if x != nil {
-		return x.PeerDetails
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchStatusRequest.ProtoReflect.Descriptor instead.
+func (*WatchStatusRequest) Descriptor() ([]byte, []int) {
+	return file_Ntp_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WatchStatusRequest) GetOffsetDeltaMs() float32 {
+	if x != nil {
+		return x.OffsetDeltaMs
+	}
+	return 0
 }
 
 var File_Ntp_proto protoreflect.FileDescriptor
 
 const file_Ntp_proto_rawDesc = "" +
 	"\n" +
-	"\tNtp.proto\x12\x1asiemens.iedge.dmapi.ntp.v1\x1a\x1bgoogle/protobuf/empty.proto\"#\n" +
+	"\tNtp.proto\x12\x1asiemens.iedge.dmapi.ntp.v1\x1a\x1bgoogle/protobuf/empty.proto\"\xcf\x01\n" +
 	"\x03Ntp\x12\x1c\n" +
-	"\tntpServer\x18\x01 \x03(\tR\tntpServer\"\x85\x02\n" +
+	"\tntpServer\x18\x01 \x03(\tR\tntpServer\x12V\n" +
+	"\x10ntpServerEntries\x18\x02 \x03(\v2*.siemens.iedge.dmapi.ntp.v1.NtpServerEntryR\x10ntpServerEntries\x12$\n" +
+	"\rsyncTimeoutMs\x18\x03 \x01(\rR\rsyncTimeoutMs\x12,\n" +
+	"\x11fallbackNtpServer\x18\x04 \x03(\tR\x11fallbackNtpServer\"\xda\x02\n" +
+	"\x0eNtpServerEntry\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06iburst\x18\x02 \x01(\bR\x06iburst\x12\x14\n" +
+	"\x05burst\x18\x03 \x01(\bR\x05burst\x12\x16\n" +
+	"\x06prefer\x18\x04 \x01(\bR\x06prefer\x12\x1a\n" +
+	"\bnoselect\x18\x05 \x01(\bR\bnoselect\x12\x18\n" +
+	"\aminpoll\x18\x06 \x01(\x05R\aminpoll\x12\x18\n" +
+	"\amaxpoll\x18\a \x01(\x05R\amaxpoll\x12\x14\n" +
+	"\x05keyId\x18\b \x01(\rR\x05keyId\x12\x18\n" +
+	"\aversion\x18\t \x01(\x05R\aversion\x12<\n" +
+	"\x04kind\x18\n" +
+	" \x01(\x0e2(.siemens.iedge.dmapi.ntp.v1.NtpEntryKindR\x04kind\x12\x18\n" +
+	"\apreempt\x18\v \x01(\bR\apreempt\x12\x10\n" +
+	"\x03nts\x18\f \x01(\bR\x03nts\"f\n" +
+	"\n" +
+	"PoolStatus\x12>\n" +
+	"\x04pool\x18\x01 \x01(\v2*.siemens.iedge.dmapi.ntp.v1.NtpServerEntryR\x04pool\x12\x18\n" +
+	"\amembers\x18\x02 \x03(\tR\amembers\"\x1f\n" +
+	"\vNtsCaBundle\x12\x10\n" +
+	"\x03pem\x18\x01 \x01(\tR\x03pem\"\x93\x01\n" +
+	"\tNtsStatus\x12\x16\n" +
+	"\x06server\x18\x01 \x01(\tR\x06server\x12 \n" +
+	"\vkeSucceeded\x18\x02 \x01(\bR\vkeSucceeded\x12\x18\n" +
+	"\acookies\x18\x03 \x01(\x05R\acookies\x12\x1c\n" +
+	"\tntpServer\x18\x04 \x01(\tR\tntpServer\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"u\n" +
+	"\fSymmetricKey\x12\x14\n" +
+	"\x05keyId\x18\x01 \x01(\rR\x05keyId\x127\n" +
+	"\x04type\x18\x02 \x01(\x0e2#.siemens.iedge.dmapi.ntp.v1.KeyTypeR\x04type\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\"&\n" +
+	"\x0eSymmetricKeyId\x12\x14\n" +
+	"\x05keyId\x18\x01 \x01(\rR\x05keyId\"M\n" +
+	"\rSymmetricKeys\x12<\n" +
+	"\x04keys\x18\x01 \x03(\v2(.siemens.iedge.dmapi.ntp.v1.SymmetricKeyR\x04keys\"\x80\x01\n" +
+	"\x12QueryServerRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x18\n" +
+	"\asamples\x18\x02 \x01(\x05R\asamples\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\x12\x1c\n" +
+	"\ttimeoutMs\x18\x04 \x01(\x05R\ttimeoutMs\"\xdf\x02\n" +
+	"\tNtpSample\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x02R\x06offset\x12\x14\n" +
+	"\x05delay\x18\x02 \x01(\x02R\x05delay\x12\x18\n" +
+	"\astratum\x18\x03 \x01(\x05R\astratum\x12 \n" +
+	"\vreferenceID\x18\x04 \x01(\tR\vreferenceID\x12$\n" +
+	"\rleapIndicator\x18\x05 \x01(\x05R\rleapIndicator\x12\x1c\n" +
+	"\trootDelay\x18\x06 \x01(\x02R\trootDelay\x12&\n" +
+	"\x0erootDispersion\x18\a \x01(\x02R\x0erootDispersion\x12\x1a\n" +
+	"\bkissCode\x18\b \x01(\tR\bkissCode\x12\x18\n" +
+	"\aversion\x18\t \x01(\x05R\aversion\x12\x12\n" +
+	"\x04poll\x18\n" +
+	" \x01(\x05R\x04poll\x12\x1c\n" +
+	"\tprecision\x18\v \x01(\x05R\tprecision\x12\x14\n" +
+	"\x05error\x18\f \x01(\tR\x05error\"\xa9\x01\n" +
+	"\x11QueryServerResult\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x129\n" +
+	"\x04best\x18\x02 \x01(\v2%.siemens.iedge.dmapi.ntp.v1.NtpSampleR\x04best\x12?\n" +
+	"\asamples\x18\x03 \x03(\v2%.siemens.iedge.dmapi.ntp.v1.NtpSampleR\asamples\"b\n" +
+	"\fRestrictRule\x12\x12\n" +
+	"\x04cidr\x18\x01 \x01(\tR\x04cidr\x12>\n" +
+	"\x05flags\x18\x02 \x03(\x0e2(.siemens.iedge.dmapi.ntp.v1.RestrictFlagR\x05flags\"i\n" +
+	"\rServingConfig\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12>\n" +
+	"\x05rules\x18\x02 \x03(\v2(.siemens.iedge.dmapi.ntp.v1.RestrictRuleR\x05rules\"\xaa\x01\n" +
+	"\fOrphanConfig\x12\x18\n" +
+	"\astratum\x18\x01 \x01(\rR\astratum\x12\x1e\n" +
+	"\n" +
+	"orphanWait\x18\x02 \x01(\rR\n" +
+	"orphanWait\x12@\n" +
+	"\x05peers\x18\x03 \x03(\v2*.siemens.iedge.dmapi.ntp.v1.NtpServerEntryR\x05peers\x12\x1e\n" +
+	"\n" +
+	"localClock\x18\x04 \x01(\bR\n" +
+	"localClock\"\xf3\x03\n" +
+	"\rRefclockEntry\x12B\n" +
+	"\x06driver\x18\x01 \x01(\x0e2*.siemens.iedge.dmapi.ntp.v1.RefclockDriverR\x06driver\x12\x12\n" +
+	"\x04unit\x18\x02 \x01(\rR\x04unit\x12\x14\n" +
+	"\x05refid\x18\x03 \x01(\tR\x05refid\x12\x14\n" +
+	"\x05time1\x18\x04 \x01(\x02R\x05time1\x12\x14\n" +
+	"\x05time2\x18\x05 \x01(\x02R\x05time2\x12\x16\n" +
+	"\x06prefer\x18\x06 \x01(\bR\x06prefer\x12\x1a\n" +
+	"\bnoselect\x18\a \x01(\bR\bnoselect\x12\x18\n" +
+	"\astratum\x18\b \x01(\rR\astratum\x12\x12\n" +
+	"\x04mode\x18\t \x01(\rR\x04mode\x12\x18\n" +
+	"\asubtype\x18\n" +
+	" \x01(\rR\asubtype\x12\x12\n" +
+	"\x04path\x18\v \x01(\tR\x04path\x12\x18\n" +
+	"\appspath\x18\f \x01(\tR\appspath\x12\x12\n" +
+	"\x04baud\x18\r \x01(\rR\x04baud\x12\x14\n" +
+	"\x05flag1\x18\x0e \x01(\bR\x05flag1\x12\x14\n" +
+	"\x05flag2\x18\x0f \x01(\bR\x05flag2\x12\x14\n" +
+	"\x05flag3\x18\x10 \x01(\bR\x05flag3\x12\x14\n" +
+	"\x05flag4\x18\x11 \x01(\bR\x05flag4\x12\x18\n" +
+	"\aminpoll\x18\x12 \x01(\x05R\aminpoll\x12\x18\n" +
+	"\amaxpoll\x18\x13 \x01(\x05R\amaxpoll\"T\n" +
+	"\tRefclocks\x12G\n" +
+	"\trefclocks\x18\x01 \x03(\v2).siemens.iedge.dmapi.ntp.v1.RefclockEntryR\trefclocks\"\xc4\x01\n" +
+	"\x0eConfigRevision\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\tR\x04time\x12\x18\n" +
+	"\aservers\x18\x03 \x03(\tR\aservers\x12\x18\n" +
+	"\aapplied\x18\x04 \x01(\bR\aapplied\x12\x16\n" +
+	"\x06result\x18\x05 \x01(\tR\x06result\x12\x1e\n" +
+	"\n" +
+	"failedStep\x18\x06 \x01(\tR\n" +
+	"failedStep\x12\"\n" +
+	"\frestoredFrom\x18\a \x01(\x04R\frestoredFrom\"Y\n" +
+	"\rConfigHistory\x12H\n" +
+	"\trevisions\x18\x01 \x03(\v2*.siemens.iedge.dmapi.ntp.v1.ConfigRevisionR\trevisions\"7\n" +
+	"\x11ConfigDiffRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x04R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x04R\x02to\" \n" +
+	"\n" +
+	"ConfigDiff\x12\x12\n" +
+	"\x04diff\x18\x01 \x01(\tR\x04diff\"L\n" +
+	"\x14RestoreConfigRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12$\n" +
+	"\rsyncTimeoutMs\x18\x02 \x01(\rR\rsyncTimeoutMs\"\xa1\x06\n" +
 	"\vPeerDetails\x12\"\n" +
 	"\fremoteServer\x18\x01 \x01(\tR\fremoteServer\x12 \n" +
 	"\vreferenceID\x18\x02 \x01(\tR\vreferenceID\x12\x18\n" +
@@ -288,18 +2674,130 @@ const file_Ntp_proto_rawDesc = "" +
 	"\x05delay\x18\b \x01(\x02R\x05delay\x12\x16\n" +
 	"\x06offset\x18\t \x01(\x02R\x06offset\x12\x16\n" +
 	"\x06jitter\x18\n" +
-	" \x01(\x02R\x06jitter\"\xfb\x01\n" +
+	" \x01(\x02R\x06jitter\x12$\n" +
+	"\rassociationId\x18\v \x01(\rR\rassociationId\x12\x18\n" +
+	"\aaddress\x18\f \x01(\tR\aaddress\x12\x12\n" +
+	"\x04port\x18\r \x01(\x05R\x04port\x12\"\n" +
+	"\flocalAddress\x18\x0e \x01(\tR\flocalAddress\x12\x1c\n" +
+	"\tselection\x18\x0f \x01(\tR\tselection\x12\x1e\n" +
+	"\n" +
+	"configured\x18\x10 \x01(\bR\n" +
+	"configured\x12$\n" +
+	"\rauthenticated\x18\x11 \x01(\bR\rauthenticated\x12\x1c\n" +
+	"\trootDelay\x18\x12 \x01(\x02R\trootDelay\x12&\n" +
+	"\x0erootDispersion\x18\x13 \x01(\x02R\x0erootDispersion\x12\x1e\n" +
+	"\n" +
+	"dispersion\x18\x14 \x01(\x02R\n" +
+	"dispersion\x12$\n" +
+	"\rleapIndicator\x18\x15 \x01(\x05R\rleapIndicator\x12\x1c\n" +
+	"\tprecision\x18\x16 \x01(\x05R\tprecision\x12\x1a\n" +
+	"\bhostPoll\x18\x17 \x01(\x05R\bhostPoll\x12\x1a\n" +
+	"\bpeerPoll\x18\x18 \x01(\x05R\bpeerPoll\x12\x14\n" +
+	"\x05flash\x18\x19 \x01(\rR\x05flash\x12F\n" +
+	"\brefclock\x18\x1a \x01(\v2*.siemens.iedge.dmapi.ntp.v1.RefclockStatusR\brefclock\"\xec\x01\n" +
+	"\x0eRefclockStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06device\x18\x02 \x01(\tR\x06device\x12\x1a\n" +
+	"\btimecode\x18\x03 \x01(\tR\btimecode\x12\x14\n" +
+	"\x05polls\x18\x04 \x01(\rR\x05polls\x12\x18\n" +
+	"\anoReply\x18\x05 \x01(\rR\anoReply\x12\x1c\n" +
+	"\tbadFormat\x18\x06 \x01(\rR\tbadFormat\x12\x18\n" +
+	"\abadData\x18\a \x01(\rR\abadData\x12\x14\n" +
+	"\x05time1\x18\b \x01(\x02R\x05time1\x12\x14\n" +
+	"\x05time2\x18\t \x01(\x02R\x05time2\"\xe2\x04\n" +
 	"\x06Status\x120\n" +
 	"\x13isNtpServiceRunning\x18\x01 \x01(\bR\x13isNtpServiceRunning\x12\x1a\n" +
 	"\bisSynced\x18\x02 \x01(\bR\bisSynced\x124\n" +
 	"\x15lastConfigurationTime\x18\x03 \x01(\tR\x15lastConfigurationTime\x12\"\n" +
 	"\flastSyncTime\x18\x04 \x01(\tR\flastSyncTime\x12I\n" +
-	"\vpeerDetails\x18\x05 \x03(\v2'.siemens.iedge.dmapi.ntp.v1.PeerDetailsR\vpeerDetails2\xe7\x01\n" +
+	"\vpeerDetails\x18\x05 \x03(\v2'.siemens.iedge.dmapi.ntp.v1.PeerDetailsR\vpeerDetails\x12<\n" +
+	"\x05pools\x18\x06 \x03(\v2&.siemens.iedge.dmapi.ntp.v1.PoolStatusR\x05pools\x127\n" +
+	"\x03nts\x18\a \x03(\v2%.siemens.iedge.dmapi.ntp.v1.NtsStatusR\x03nts\x12C\n" +
+	"\aserving\x18\b \x01(\v2).siemens.iedge.dmapi.ntp.v1.ServingConfigR\aserving\x12\x16\n" +
+	"\x06orphan\x18\t \x01(\bR\x06orphan\x12U\n" +
+	"\x0fsystemVariables\x18\n" +
+	" \x01(\v2+.siemens.iedge.dmapi.ntp.v1.SystemVariablesR\x0fsystemVariables\x12:\n" +
+	"\x04leap\x18\v \x01(\v2&.siemens.iedge.dmapi.ntp.v1.LeapStatusR\x04leap\"\xc5\x02\n" +
+	"\x0fSystemVariables\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x02R\x06offset\x12\x1c\n" +
+	"\tfrequency\x18\x02 \x01(\x02R\tfrequency\x12\x1c\n" +
+	"\tsysJitter\x18\x03 \x01(\x02R\tsysJitter\x12\x1c\n" +
+	"\tclkWander\x18\x04 \x01(\x02R\tclkWander\x12\x1c\n" +
+	"\trootDelay\x18\x05 \x01(\x02R\trootDelay\x12&\n" +
+	"\x0erootDispersion\x18\x06 \x01(\x02R\x0erootDispersion\x12\x12\n" +
+	"\x04leap\x18\a \x01(\rR\x04leap\x12\x18\n" +
+	"\astratum\x18\b \x01(\rR\astratum\x12\x14\n" +
+	"\x05refid\x18\t \x01(\tR\x05refid\x12\x1e\n" +
+	"\n" +
+	"systemPeer\x18\n" +
+	" \x01(\rR\n" +
+	"systemPeer\x12\x16\n" +
+	"\x06uptime\x18\v \x01(\x04R\x06uptime\"+\n" +
+	"\x0fLeapSecondsFile\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\"\x8c\x02\n" +
+	"\n" +
+	"LeapStatus\x12\x12\n" +
+	"\x04file\x18\x01 \x01(\tR\x04file\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\tR\aupdated\x12\x18\n" +
+	"\aexpires\x18\x03 \x01(\tR\aexpires\x12\x18\n" +
+	"\aexpired\x18\x04 \x01(\bR\aexpired\x12 \n" +
+	"\vexpiresSoon\x18\x05 \x01(\bR\vexpiresSoon\x12\x1c\n" +
+	"\ttaiOffset\x18\x06 \x01(\x05R\ttaiOffset\x12 \n" +
+	"\vpendingLeap\x18\a \x01(\tR\vpendingLeap\x12$\n" +
+	"\rleapIndicator\x18\b \x01(\rR\rleapIndicator\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\":\n" +
+	"\x12WatchStatusRequest\x12$\n" +
+	"\roffsetDeltaMs\x18\x01 \x01(\x02R\roffsetDeltaMs*.\n" +
+	"\fNtpEntryKind\x12\n" +
+	"\n" +
+	"\x06SERVER\x10\x00\x12\b\n" +
+	"\x04POOL\x10\x01\x12\b\n" +
+	"\x04PEER\x10\x02*,\n" +
+	"\aKeyType\x12\a\n" +
+	"\x03MD5\x10\x00\x12\b\n" +
+	"\x04SHA1\x10\x01\x12\x0e\n" +
+	"\n" +
+	"AES128CMAC\x10\x02*K\n" +
+	"\fRestrictFlag\x12\f\n" +
+	"\bNOMODIFY\x10\x00\x12\v\n" +
+	"\aNOQUERY\x10\x01\x12\v\n" +
+	"\aLIMITED\x10\x02\x12\a\n" +
+	"\x03KOD\x10\x03\x12\n" +
+	"\n" +
+	"\x06NOPEER\x10\x04*C\n" +
+	"\x0eRefclockDriver\x12\b\n" +
+	"\x04GPSD\x10\x00\x12\b\n" +
+	"\x04NMEA\x10\x01\x12\a\n" +
+	"\x03PPS\x10\x02\x12\a\n" +
+	"\x03SHM\x10\x03\x12\v\n" +
+	"\aGENERIC\x10\x042\xd2\x0e\n" +
 	"\n" +
 	"NtpService\x12G\n" +
 	"\fSetNtpServer\x12\x1f.siemens.iedge.dmapi.ntp.v1.Ntp\x1a\x16.google.protobuf.Empty\x12G\n" +
 	"\fGetNtpServer\x12\x16.google.protobuf.Empty\x1a\x1f.siemens.iedge.dmapi.ntp.v1.Ntp\x12G\n" +
-	"\tGetStatus\x12\x16.google.protobuf.Empty\x1a\".siemens.iedge.dmapi.ntp.v1.StatusB\x1aZ\x18.;siemens_iedge_dmapi_v1b\x06proto3"
+	"\tGetStatus\x12\x16.google.protobuf.Empty\x1a\".siemens.iedge.dmapi.ntp.v1.Status\x12c\n" +
+	"\vWatchStatus\x12..siemens.iedge.dmapi.ntp.v1.WatchStatusRequest\x1a\".siemens.iedge.dmapi.ntp.v1.Status0\x01\x12Q\n" +
+	"\x0eSetNtsCaBundle\x12'.siemens.iedge.dmapi.ntp.v1.NtsCaBundle\x1a\x16.google.protobuf.Empty\x12Q\n" +
+	"\x0eGetNtsCaBundle\x12\x16.google.protobuf.Empty\x1a'.siemens.iedge.dmapi.ntp.v1.NtsCaBundle\x12M\n" +
+	"\tCreateKey\x12(.siemens.iedge.dmapi.ntp.v1.SymmetricKey\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\bListKeys\x12\x16.google.protobuf.Empty\x1a).siemens.iedge.dmapi.ntp.v1.SymmetricKeys\x12M\n" +
+	"\tRotateKey\x12(.siemens.iedge.dmapi.ntp.v1.SymmetricKey\x1a\x16.google.protobuf.Empty\x12O\n" +
+	"\tDeleteKey\x12*.siemens.iedge.dmapi.ntp.v1.SymmetricKeyId\x1a\x16.google.protobuf.Empty\x12l\n" +
+	"\vQueryServer\x12..siemens.iedge.dmapi.ntp.v1.QueryServerRequest\x1a-.siemens.iedge.dmapi.ntp.v1.QueryServerResult\x12O\n" +
+	"\n" +
+	"SetServing\x12).siemens.iedge.dmapi.ntp.v1.ServingConfig\x1a\x16.google.protobuf.Empty\x12O\n" +
+	"\n" +
+	"GetServing\x12\x16.google.protobuf.Empty\x1a).siemens.iedge.dmapi.ntp.v1.ServingConfig\x12Q\n" +
+	"\rSetOrphanMode\x12(.siemens.iedge.dmapi.ntp.v1.OrphanConfig\x1a\x16.google.protobuf.Empty\x12Q\n" +
+	"\rGetOrphanMode\x12\x16.google.protobuf.Empty\x1a(.siemens.iedge.dmapi.ntp.v1.OrphanConfig\x12M\n" +
+	"\fSetRefclocks\x12%.siemens.iedge.dmapi.ntp.v1.Refclocks\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\fGetRefclocks\x12\x16.google.protobuf.Empty\x1a%.siemens.iedge.dmapi.ntp.v1.Refclocks\x12V\n" +
+	"\x11ListConfigHistory\x12\x16.google.protobuf.Empty\x1a).siemens.iedge.dmapi.ntp.v1.ConfigHistory\x12c\n" +
+	"\n" +
+	"DiffConfig\x12-.siemens.iedge.dmapi.ntp.v1.ConfigDiffRequest\x1a&.siemens.iedge.dmapi.ntp.v1.ConfigDiff\x12Y\n" +
+	"\rRestoreConfig\x120.siemens.iedge.dmapi.ntp.v1.RestoreConfigRequest\x1a\x16.google.protobuf.Empty\x12Y\n" +
+	"\x12SetLeapSecondsFile\x12+.siemens.iedge.dmapi.ntp.v1.LeapSecondsFile\x1a\x16.google.protobuf.Empty\x12Y\n" +
+	"\x12GetLeapSecondsFile\x12\x16.google.protobuf.Empty\x1a+.siemens.iedge.dmapi.ntp.v1.LeapSecondsFileB\x1aZ\x18.;siemens_iedge_dmapi_v1b\x06proto3"
 
 var (
 	file_Ntp_proto_rawDescOnce sync.Once
@@ -313,26 +2811,113 @@ func file_Ntp_proto_rawDescGZIP() []byte {
 	return file_Ntp_proto_rawDescData
 }
 
-var file_Ntp_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_Ntp_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_Ntp_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_Ntp_proto_goTypes = []any{
-	(*Ntp)(nil),           // 0: siemens.iedge.dmapi.ntp.v1.Ntp
-	(*PeerDetails)(nil),   // 1: siemens.iedge.dmapi.ntp.v1.PeerDetails
-	(*Status)(nil),        // 2: siemens.iedge.dmapi.ntp.v1.Status
-	(*emptypb.Empty)(nil), // 3: google.protobuf.Empty
+	(NtpEntryKind)(0),            // 0: siemens.iedge.dmapi.ntp.v1.NtpEntryKind
+	(KeyType)(0),                 // 1: siemens.iedge.dmapi.ntp.v1.KeyType
+	(RestrictFlag)(0),            // 2: siemens.iedge.dmapi.ntp.v1.RestrictFlag
+	(RefclockDriver)(0),          // 3: siemens.iedge.dmapi.ntp.v1.RefclockDriver
+	(*Ntp)(nil),                  // 4: siemens.iedge.dmapi.ntp.v1.Ntp
+	(*NtpServerEntry)(nil),       // 5: siemens.iedge.dmapi.ntp.v1.NtpServerEntry
+	(*PoolStatus)(nil),           // 6: siemens.iedge.dmapi.ntp.v1.PoolStatus
+	(*NtsCaBundle)(nil),          // 7: siemens.iedge.dmapi.ntp.v1.NtsCaBundle
+	(*NtsStatus)(nil),            // 8: siemens.iedge.dmapi.ntp.v1.NtsStatus
+	(*SymmetricKey)(nil),         // 9: siemens.iedge.dmapi.ntp.v1.SymmetricKey
+	(*SymmetricKeyId)(nil),       // 10: siemens.iedge.dmapi.ntp.v1.SymmetricKeyId
+	(*SymmetricKeys)(nil),        // 11: siemens.iedge.dmapi.ntp.v1.SymmetricKeys
+	(*QueryServerRequest)(nil),   // 12: siemens.iedge.dmapi.ntp.v1.QueryServerRequest
+	(*NtpSample)(nil),            // 13: siemens.iedge.dmapi.ntp.v1.NtpSample
+	(*QueryServerResult)(nil),    // 14: siemens.iedge.dmapi.ntp.v1.QueryServerResult
+	(*RestrictRule)(nil),         // 15: siemens.iedge.dmapi.ntp.v1.RestrictRule
+	(*ServingConfig)(nil),        // 16: siemens.iedge.dmapi.ntp.v1.ServingConfig
+	(*OrphanConfig)(nil),         // 17: siemens.iedge.dmapi.ntp.v1.OrphanConfig
+	(*RefclockEntry)(nil),        // 18: siemens.iedge.dmapi.ntp.v1.RefclockEntry
+	(*Refclocks)(nil),            // 19: siemens.iedge.dmapi.ntp.v1.Refclocks
+	(*ConfigRevision)(nil),       // 20: siemens.iedge.dmapi.ntp.v1.ConfigRevision
+	(*ConfigHistory)(nil),        // 21: siemens.iedge.dmapi.ntp.v1.ConfigHistory
+	(*ConfigDiffRequest)(nil),    // 22: siemens.iedge.dmapi.ntp.v1.ConfigDiffRequest
+	(*ConfigDiff)(nil),           // 23: siemens.iedge.dmapi.ntp.v1.ConfigDiff
+	(*RestoreConfigRequest)(nil), // 24: siemens.iedge.dmapi.ntp.v1.RestoreConfigRequest
+	(*PeerDetails)(nil),          // 25: siemens.iedge.dmapi.ntp.v1.PeerDetails
+	(*RefclockStatus)(nil),       // 26: siemens.iedge.dmapi.ntp.v1.RefclockStatus
+	(*Status)(nil),               // 27: siemens.iedge.dmapi.ntp.v1.Status
+	(*SystemVariables)(nil),      // 28: siemens.iedge.dmapi.ntp.v1.SystemVariables
+	(*LeapSecondsFile)(nil),      // 29: siemens.iedge.dmapi.ntp.v1.LeapSecondsFile
+	(*LeapStatus)(nil),           // 30: siemens.iedge.dmapi.ntp.v1.LeapStatus
+	(*WatchStatusRequest)(nil),   // 31: siemens.iedge.dmapi.ntp.v1.WatchStatusRequest
+	(*emptypb.Empty)(nil),        // 32: google.protobuf.Empty
 }
 var file_Ntp_proto_depIdxs = []int32{
-	1, // 0: siemens.iedge.dmapi.ntp.v1.Status.peerDetails:type_name -> siemens.iedge.dmapi.ntp.v1.PeerDetails
-	0, // 1: siemens.iedge.dmapi.ntp.v1.NtpService.SetNtpServer:input_type -> siemens.iedge.dmapi.ntp.v1.Ntp
-	3, // 2: siemens.iedge.dmapi.ntp.v1.NtpService.GetNtpServer:input_type -> google.protobuf.Empty
-	3, // 3: siemens.iedge.dmapi.ntp.v1.NtpService.GetStatus:input_type -> google.protobuf.Empty
-	3, // 4: siemens.iedge.dmapi.ntp.v1.NtpService.SetNtpServer:output_type -> google.protobuf.Empty
-	0, // 5: siemens.iedge.dmapi.ntp.v1.NtpService.GetNtpServer:output_type -> siemens.iedge.dmapi.ntp.v1.Ntp
-	2, // 6: siemens.iedge.dmapi.ntp.v1.NtpService.GetStatus:output_type -> siemens.iedge.dmapi.ntp.v1.Status
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	5,  // 0: siemens.iedge.dmapi.ntp.v1.Ntp.ntpServerEntries:type_name -> siemens.iedge.dmapi.ntp.v1.NtpServerEntry
+	0,  // 1: siemens.iedge.dmapi.ntp.v1.NtpServerEntry.kind:type_name -> siemens.iedge.dmapi.ntp.v1.NtpEntryKind
+	5,  // 2: siemens.iedge.dmapi.ntp.v1.PoolStatus.pool:type_name -> siemens.iedge.dmapi.ntp.v1.NtpServerEntry
+	1,  // 3: siemens.iedge.dmapi.ntp.v1.SymmetricKey.type:type_name -> siemens.iedge.dmapi.ntp.v1.KeyType
+	9,  // 4: siemens.iedge.dmapi.ntp.v1.SymmetricKeys.keys:type_name -> siemens.iedge.dmapi.ntp.v1.SymmetricKey
+	13, // 5: siemens.iedge.dmapi.ntp.v1.QueryServerResult.best:type_name -> siemens.iedge.dmapi.ntp.v1.NtpSample
+	13, // 6: siemens.iedge.dmapi.ntp.v1.QueryServerResult.samples:type_name -> siemens.iedge.dmapi.ntp.v1.NtpSample
+	2,  // 7: siemens.iedge.dmapi.ntp.v1.RestrictRule.flags:type_name -> siemens.iedge.dmapi.ntp.v1.RestrictFlag
+	15, // 8: siemens.iedge.dmapi.ntp.v1.ServingConfig.rules:type_name -> siemens.iedge.dmapi.ntp.v1.RestrictRule
+	5,  // 9: siemens.iedge.dmapi.ntp.v1.OrphanConfig.peers:type_name -> siemens.iedge.dmapi.ntp.v1.NtpServerEntry
+	3,  // 10: siemens.iedge.dmapi.ntp.v1.RefclockEntry.driver:type_name -> siemens.iedge.dmapi.ntp.v1.RefclockDriver
+	18, // 11: siemens.iedge.dmapi.ntp.v1.Refclocks.refclocks:type_name -> siemens.iedge.dmapi.ntp.v1.RefclockEntry
+	20, // 12: siemens.iedge.dmapi.ntp.v1.ConfigHistory.revisions:type_name -> siemens.iedge.dmapi.ntp.v1.ConfigRevision
+	26, // 13: siemens.iedge.dmapi.ntp.v1.PeerDetails.refclock:type_name -> siemens.iedge.dmapi.ntp.v1.RefclockStatus
+	25, // 14: siemens.iedge.dmapi.ntp.v1.Status.peerDetails:type_name -> siemens.iedge.dmapi.ntp.v1.PeerDetails
+	6,  // 15: siemens.iedge.dmapi.ntp.v1.Status.pools:type_name -> siemens.iedge.dmapi.ntp.v1.PoolStatus
+	8,  // 16: siemens.iedge.dmapi.ntp.v1.Status.nts:type_name -> siemens.iedge.dmapi.ntp.v1.NtsStatus
+	16, // 17: siemens.iedge.dmapi.ntp.v1.Status.serving:type_name -> siemens.iedge.dmapi.ntp.v1.ServingConfig
+	28, // 18: siemens.iedge.dmapi.ntp.v1.Status.systemVariables:type_name -> siemens.iedge.dmapi.ntp.v1.SystemVariables
+	30, // 19: siemens.iedge.dmapi.ntp.v1.Status.leap:type_name -> siemens.iedge.dmapi.ntp.v1.LeapStatus
+	4,  // 20: siemens.iedge.dmapi.ntp.v1.NtpService.SetNtpServer:input_type -> siemens.iedge.dmapi.ntp.v1.Ntp
+	32, // 21: siemens.iedge.dmapi.ntp.v1.NtpService.GetNtpServer:input_type -> google.protobuf.Empty
+	32, // 22: siemens.iedge.dmapi.ntp.v1.NtpService.GetStatus:input_type -> google.protobuf.Empty
+	31, // 23: siemens.iedge.dmapi.ntp.v1.NtpService.WatchStatus:input_type -> siemens.iedge.dmapi.ntp.v1.WatchStatusRequest
+	7,  // 24: siemens.iedge.dmapi.ntp.v1.NtpService.SetNtsCaBundle:input_type -> siemens.iedge.dmapi.ntp.v1.NtsCaBundle
+	32, // 25: siemens.iedge.dmapi.ntp.v1.NtpService.GetNtsCaBundle:input_type -> google.protobuf.Empty
+	9,  // 26: siemens.iedge.dmapi.ntp.v1.NtpService.CreateKey:input_type -> siemens.iedge.dmapi.ntp.v1.SymmetricKey
+	32, // 27: siemens.iedge.dmapi.ntp.v1.NtpService.ListKeys:input_type -> google.protobuf.Empty
+	9,  // 28: siemens.iedge.dmapi.ntp.v1.NtpService.RotateKey:input_type -> siemens.iedge.dmapi.ntp.v1.SymmetricKey
+	10, // 29: siemens.iedge.dmapi.ntp.v1.NtpService.DeleteKey:input_type -> siemens.iedge.dmapi.ntp.v1.SymmetricKeyId
+	12, // 30: siemens.iedge.dmapi.ntp.v1.NtpService.QueryServer:input_type -> siemens.iedge.dmapi.ntp.v1.QueryServerRequest
+	16, // 31: siemens.iedge.dmapi.ntp.v1.NtpService.SetServing:input_type -> siemens.iedge.dmapi.ntp.v1.ServingConfig
+	32, // 32: siemens.iedge.dmapi.ntp.v1.NtpService.GetServing:input_type -> google.protobuf.Empty
+	17, // 33: siemens.iedge.dmapi.ntp.v1.NtpService.SetOrphanMode:input_type -> siemens.iedge.dmapi.ntp.v1.OrphanConfig
+	32, // 34: siemens.iedge.dmapi.ntp.v1.NtpService.GetOrphanMode:input_type -> google.protobuf.Empty
+	19, // 35: siemens.iedge.dmapi.ntp.v1.NtpService.SetRefclocks:input_type -> siemens.iedge.dmapi.ntp.v1.Refclocks
+	32, // 36: siemens.iedge.dmapi.ntp.v1.NtpService.GetRefclocks:input_type -> google.protobuf.Empty
+	32, // 37: siemens.iedge.dmapi.ntp.v1.NtpService.ListConfigHistory:input_type -> google.protobuf.Empty
+	22, // 38: siemens.iedge.dmapi.ntp.v1.NtpService.DiffConfig:input_type -> siemens.iedge.dmapi.ntp.v1.ConfigDiffRequest
+	24, // 39: siemens.iedge.dmapi.ntp.v1.NtpService.RestoreConfig:input_type -> siemens.iedge.dmapi.ntp.v1.RestoreConfigRequest
+	29, // 40: siemens.iedge.dmapi.ntp.v1.NtpService.SetLeapSecondsFile:input_type -> siemens.iedge.dmapi.ntp.v1.LeapSecondsFile
+	32, // 41: siemens.iedge.dmapi.ntp.v1.NtpService.GetLeapSecondsFile:input_type -> google.protobuf.Empty
+	32, // 42: siemens.iedge.dmapi.ntp.v1.NtpService.SetNtpServer:output_type -> google.protobuf.Empty
+	4,  // 43: siemens.iedge.dmapi.ntp.v1.NtpService.GetNtpServer:output_type -> siemens.iedge.dmapi.ntp.v1.Ntp
+	27, // 44: siemens.iedge.dmapi.ntp.v1.NtpService.GetStatus:output_type -> siemens.iedge.dmapi.ntp.v1.Status
+	27, // 45: siemens.iedge.dmapi.ntp.v1.NtpService.WatchStatus:output_type -> siemens.iedge.dmapi.ntp.v1.Status
+	32, // 46: siemens.iedge.dmapi.ntp.v1.NtpService.SetNtsCaBundle:output_type -> google.protobuf.Empty
+	7,  // 47: siemens.iedge.dmapi.ntp.v1.NtpService.GetNtsCaBundle:output_type -> siemens.iedge.dmapi.ntp.v1.NtsCaBundle
+	32, // 48: siemens.iedge.dmapi.ntp.v1.NtpService.CreateKey:output_type -> google.protobuf.Empty
+	11, // 49: siemens.iedge.dmapi.ntp.v1.NtpService.ListKeys:output_type -> siemens.iedge.dmapi.ntp.v1.SymmetricKeys
+	32, // 50: siemens.iedge.dmapi.ntp.v1.NtpService.RotateKey:output_type -> google.protobuf.Empty
+	32, // 51: siemens.iedge.dmapi.ntp.v1.NtpService.DeleteKey:output_type -> google.protobuf.Empty
+	14, // 52: siemens.iedge.dmapi.ntp.v1.NtpService.QueryServer:output_type -> siemens.iedge.dmapi.ntp.v1.QueryServerResult
+	32, // 53: siemens.iedge.dmapi.ntp.v1.NtpService.SetServing:output_type -> google.protobuf.Empty
+	16, // 54: siemens.iedge.dmapi.ntp.v1.NtpService.GetServing:output_type -> siemens.iedge.dmapi.ntp.v1.ServingConfig
+	32, // 55: siemens.iedge.dmapi.ntp.v1.NtpService.SetOrphanMode:output_type -> google.protobuf.Empty
+	17, // 56: siemens.iedge.dmapi.ntp.v1.NtpService.GetOrphanMode:output_type -> siemens.iedge.dmapi.ntp.v1.OrphanConfig
+	32, // 57: siemens.iedge.dmapi.ntp.v1.NtpService.SetRefclocks:output_type -> google.protobuf.Empty
+	19, // 58: siemens.iedge.dmapi.ntp.v1.NtpService.GetRefclocks:output_type -> siemens.iedge.dmapi.ntp.v1.Refclocks
+	21, // 59: siemens.iedge.dmapi.ntp.v1.NtpService.ListConfigHistory:output_type -> siemens.iedge.dmapi.ntp.v1.ConfigHistory
+	23, // 60: siemens.iedge.dmapi.ntp.v1.NtpService.DiffConfig:output_type -> siemens.iedge.dmapi.ntp.v1.ConfigDiff
+	32, // 61: siemens.iedge.dmapi.ntp.v1.NtpService.RestoreConfig:output_type -> google.protobuf.Empty
+	32, // 62: siemens.iedge.dmapi.ntp.v1.NtpService.SetLeapSecondsFile:output_type -> google.protobuf.Empty
+	29, // 63: siemens.iedge.dmapi.ntp.v1.NtpService.GetLeapSecondsFile:output_type -> siemens.iedge.dmapi.ntp.v1.LeapSecondsFile
+	42, // [42:64] is the sub-list for method output_type
+	20, // [20:42] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_Ntp_proto_init() }
@@ -345,13 +2930,14 @@ func file_Ntp_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_Ntp_proto_rawDesc), len(file_Ntp_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      4,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_Ntp_proto_goTypes,
 		DependencyIndexes: file_Ntp_proto_depIdxs,
+		EnumInfos:         file_Ntp_proto_enumTypes,
 		MessageInfos:      file_Ntp_proto_msgTypes,
 	}.Build()
 	File_Ntp_proto = out.File
@@ -29,9 +29,28 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	NtpService_SetNtpServer_FullMethodName = "/siemens.iedge.dmapi.ntp.v1.NtpService/SetNtpServer"
-	NtpService_GetNtpServer_FullMethodName = "/siemens.iedge.dmapi.ntp.v1.NtpService/GetNtpServer"
-	NtpService_GetStatus_FullMethodName    = "/siemens.iedge.dmapi.ntp.v1.NtpService/GetStatus"
+	NtpService_SetNtpServer_FullMethodName       = "/siemens.iedge.dmapi.ntp.v1.NtpService/SetNtpServer"
+	NtpService_GetNtpServer_FullMethodName       = "/siemens.iedge.dmapi.ntp.v1.NtpService/GetNtpServer"
+	NtpService_GetStatus_FullMethodName          = "/siemens.iedge.dmapi.ntp.v1.NtpService/GetStatus"
+	NtpService_WatchStatus_FullMethodName        = "/siemens.iedge.dmapi.ntp.v1.NtpService/WatchStatus"
+	NtpService_SetNtsCaBundle_FullMethodName     = "/siemens.iedge.dmapi.ntp.v1.NtpService/SetNtsCaBundle"
+	NtpService_GetNtsCaBundle_FullMethodName     = "/siemens.iedge.dmapi.ntp.v1.NtpService/GetNtsCaBundle"
+	NtpService_CreateKey_FullMethodName          = "/siemens.iedge.dmapi.ntp.v1.NtpService/CreateKey"
+	NtpService_ListKeys_FullMethodName           = "/siemens.iedge.dmapi.ntp.v1.NtpService/ListKeys"
+	NtpService_RotateKey_FullMethodName          = "/siemens.iedge.dmapi.ntp.v1.NtpService/RotateKey"
+	NtpService_DeleteKey_FullMethodName          = "/siemens.iedge.dmapi.ntp.v1.NtpService/DeleteKey"
+	NtpService_QueryServer_FullMethodName        = "/siemens.iedge.dmapi.ntp.v1.NtpService/QueryServer"
+	NtpService_SetServing_FullMethodName         = "/siemens.iedge.dmapi.ntp.v1.NtpService/SetServing"
+	NtpService_GetServing_FullMethodName         = "/siemens.iedge.dmapi.ntp.v1.NtpService/GetServing"
+	NtpService_SetOrphanMode_FullMethodName      = "/siemens.iedge.dmapi.ntp.v1.NtpService/SetOrphanMode"
+	NtpService_GetOrphanMode_FullMethodName      = "/siemens.iedge.dmapi.ntp.v1.NtpService/GetOrphanMode"
+	NtpService_SetRefclocks_FullMethodName       = "/siemens.iedge.dmapi.ntp.v1.NtpService/SetRefclocks"
+	NtpService_GetRefclocks_FullMethodName       = "/siemens.iedge.dmapi.ntp.v1.NtpService/GetRefclocks"
+	NtpService_ListConfigHistory_FullMethodName  = "/siemens.iedge.dmapi.ntp.v1.NtpService/ListConfigHistory"
+	NtpService_DiffConfig_FullMethodName         = "/siemens.iedge.dmapi.ntp.v1.NtpService/DiffConfig"
+	NtpService_RestoreConfig_FullMethodName      = "/siemens.iedge.dmapi.ntp.v1.NtpService/RestoreConfig"
+	NtpService_SetLeapSecondsFile_FullMethodName = "/siemens.iedge.dmapi.ntp.v1.NtpService/SetLeapSecondsFile"
+	NtpService_GetLeapSecondsFile_FullMethodName = "/siemens.iedge.dmapi.ntp.v1.NtpService/GetLeapSecondsFile"
 )
 
 // NtpServiceClient is the client API for NtpService service.
@@ -42,12 +61,52 @@ const (
 // protoc  generates both client and server instance for this Service.
 // GRPC Status codes : https://developers.google.com/maps-booking/reference/grpc-api/status_codes .
 type NtpServiceClient interface {
-	// Set ntp server
+	// Set ntp server. The configuration is applied as a transaction, if a step fails the previous ntp.conf is restored
+	// and ABORTED is returned with an ErrorInfo detail naming the failed step.
 	SetNtpServer(ctx context.Context, in *Ntp, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Returns ntp servers
 	GetNtpServer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Ntp, error)
 	// Returns NTP Status message.
 	GetStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Status, error)
+	// Streams NTP Status messages when the running state, sync state, system peer, reach or offset changes.
+	// The statuses do not contain the NTS results, use GetStatus for them.
+	WatchStatus(ctx context.Context, in *WatchStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Status], error)
+	// Set CA bundle used for NTS key establishment
+	SetNtsCaBundle(ctx context.Context, in *NtsCaBundle, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Returns CA bundle used for NTS key establishment
+	GetNtsCaBundle(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NtsCaBundle, error)
+	// Create symmetric key
+	CreateKey(ctx context.Context, in *SymmetricKey, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Returns symmetric keys without secrets
+	ListKeys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SymmetricKeys, error)
+	// Replace type and secret of an existing symmetric key
+	RotateKey(ctx context.Context, in *SymmetricKey, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Delete symmetric key which is not used by a server
+	DeleteKey(ctx context.Context, in *SymmetricKeyId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Query any NTP server without changing the configuration
+	QueryServer(ctx context.Context, in *QueryServerRequest, opts ...grpc.CallOption) (*QueryServerResult, error)
+	// Serve time to other devices, the restrict rules of ntp.conf are replaced
+	SetServing(ctx context.Context, in *ServingConfig, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Returns the time service offered to other devices
+	GetServing(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServingConfig, error)
+	// Set orphan mode and the sibling peers of the cell
+	SetOrphanMode(ctx context.Context, in *OrphanConfig, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Returns the orphan mode and the sibling peers of the cell
+	GetOrphanMode(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*OrphanConfig, error)
+	// Set the reference clocks
+	SetRefclocks(ctx context.Context, in *Refclocks, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Returns the reference clocks
+	GetRefclocks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Refclocks, error)
+	// Returns the kept ntp.conf revisions
+	ListConfigHistory(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConfigHistory, error)
+	// Returns the unified diff between two ntp.conf revisions
+	DiffConfig(ctx context.Context, in *ConfigDiffRequest, opts ...grpc.CallOption) (*ConfigDiff, error)
+	// Applies a kept ntp.conf revision the same way as SetNtpServer
+	RestoreConfig(ctx context.Context, in *RestoreConfigRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Stores a leap-seconds.list after checking its hash and expiry and points the leapfile directive of ntp.conf to it
+	SetLeapSecondsFile(ctx context.Context, in *LeapSecondsFile, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Returns the leap seconds file of the leapfile directive of ntp.conf
+	GetLeapSecondsFile(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LeapSecondsFile, error)
 }
 
 type ntpServiceClient struct {
@@ -88,6 +147,205 @@ func (c *ntpServiceClient) GetStatus(ctx context.Context, in *emptypb.Empty, opt
 	return out, nil
 }
 
+func (c *ntpServiceClient) WatchStatus(ctx context.Context, in *WatchStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Status], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NtpService_ServiceDesc.Streams[0], NtpService_WatchStatus_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchStatusRequest, Status]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NtpService_WatchStatusClient = grpc.ServerStreamingClient[Status]
+
+func (c *ntpServiceClient) SetNtsCaBundle(ctx context.Context, in *NtsCaBundle, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NtpService_SetNtsCaBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) GetNtsCaBundle(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*NtsCaBundle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NtsCaBundle)
+	err := c.cc.Invoke(ctx, NtpService_GetNtsCaBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) CreateKey(ctx context.Context, in *SymmetricKey, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NtpService_CreateKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) ListKeys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SymmetricKeys, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SymmetricKeys)
+	err := c.cc.Invoke(ctx, NtpService_ListKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) RotateKey(ctx context.Context, in *SymmetricKey, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NtpService_RotateKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) DeleteKey(ctx context.Context, in *SymmetricKeyId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NtpService_DeleteKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) QueryServer(ctx context.Context, in *QueryServerRequest, opts ...grpc.CallOption) (*QueryServerResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryServerResult)
+	err := c.cc.Invoke(ctx, NtpService_QueryServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) SetServing(ctx context.Context, in *ServingConfig, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NtpService_SetServing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) GetServing(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServingConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServingConfig)
+	err := c.cc.Invoke(ctx, NtpService_GetServing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) SetOrphanMode(ctx context.Context, in *OrphanConfig, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NtpService_SetOrphanMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) GetOrphanMode(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*OrphanConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrphanConfig)
+	err := c.cc.Invoke(ctx, NtpService_GetOrphanMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) SetRefclocks(ctx context.Context, in *Refclocks, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NtpService_SetRefclocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) GetRefclocks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Refclocks, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Refclocks)
+	err := c.cc.Invoke(ctx, NtpService_GetRefclocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) ListConfigHistory(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConfigHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigHistory)
+	err := c.cc.Invoke(ctx, NtpService_ListConfigHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) DiffConfig(ctx context.Context, in *ConfigDiffRequest, opts ...grpc.CallOption) (*ConfigDiff, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigDiff)
+	err := c.cc.Invoke(ctx, NtpService_DiffConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) RestoreConfig(ctx context.Context, in *RestoreConfigRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NtpService_RestoreConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) SetLeapSecondsFile(ctx context.Context, in *LeapSecondsFile, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, NtpService_SetLeapSecondsFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ntpServiceClient) GetLeapSecondsFile(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LeapSecondsFile, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeapSecondsFile)
+	err := c.cc.Invoke(ctx, NtpService_GetLeapSecondsFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NtpServiceServer is the server API for NtpService service.
 // All implementations must embed UnimplementedNtpServiceServer
 // for forward compatibility.
@@ -96,12 +354,52 @@ func (c *ntpServiceClient) GetStatus(ctx context.Context, in *emptypb.Empty, opt
 // protoc  generates both client and server instance for this Service.
 // GRPC Status codes : https://developers.google.com/maps-booking/reference/grpc-api/status_codes .
 type NtpServiceServer interface {
-	// Set ntp server
+	// Set ntp server. The configuration is applied as a transaction, if a step fails the previous ntp.conf is restored
+	// and ABORTED is returned with an ErrorInfo detail naming the failed step.
 	SetNtpServer(context.Context, *Ntp) (*emptypb.Empty, error)
 	// Returns ntp servers
 	GetNtpServer(context.Context, *emptypb.Empty) (*Ntp, error)
 	// Returns NTP Status message.
 	GetStatus(context.Context, *emptypb.Empty) (*Status, error)
+	// Streams NTP Status messages when the running state, sync state, system peer, reach or offset changes.
+	// The statuses do not contain the NTS results, use GetStatus for them.
+	WatchStatus(*WatchStatusRequest, grpc.ServerStreamingServer[Status]) error
+	// Set CA bundle used for NTS key establishment
+	SetNtsCaBundle(context.Context, *NtsCaBundle) (*emptypb.Empty, error)
+	// Returns CA bundle used for NTS key establishment
+	GetNtsCaBundle(context.Context, *emptypb.Empty) (*NtsCaBundle, error)
+	// Create symmetric key
+	CreateKey(context.Context, *SymmetricKey) (*emptypb.Empty, error)
+	// Returns symmetric keys without secrets
+	ListKeys(context.Context, *emptypb.Empty) (*SymmetricKeys, error)
+	// Replace type and secret of an existing symmetric key
+	RotateKey(context.Context, *SymmetricKey) (*emptypb.Empty, error)
+	// Delete symmetric key which is not used by a server
+	DeleteKey(context.Context, *SymmetricKeyId) (*emptypb.Empty, error)
+	// Query any NTP server without changing the configuration
+	QueryServer(context.Context, *QueryServerRequest) (*QueryServerResult, error)
+	// Serve time to other devices, the restrict rules of ntp.conf are replaced
+	SetServing(context.Context, *ServingConfig) (*emptypb.Empty, error)
+	// Returns the time service offered to other devices
+	GetServing(context.Context, *emptypb.Empty) (*ServingConfig, error)
+	// Set orphan mode and the sibling peers of the cell
+	SetOrphanMode(context.Context, *OrphanConfig) (*emptypb.Empty, error)
+	// Returns the orphan mode and the sibling peers of the cell
+	GetOrphanMode(context.Context, *emptypb.Empty) (*OrphanConfig, error)
+	// Set the reference clocks
+	SetRefclocks(context.Context, *Refclocks) (*emptypb.Empty, error)
+	// Returns the reference clocks
+	GetRefclocks(context.Context, *emptypb.Empty) (*Refclocks, error)
+	// Returns the kept ntp.conf revisions
+	ListConfigHistory(context.Context, *emptypb.Empty) (*ConfigHistory, error)
+	// Returns the unified diff between two ntp.conf revisions
+	DiffConfig(context.Context, *ConfigDiffRequest) (*ConfigDiff, error)
+	// Applies a kept ntp.conf revision the same way as SetNtpServer
+	RestoreConfig(context.Context, *RestoreConfigRequest) (*emptypb.Empty, error)
+	// Stores a leap-seconds.list after checking its hash and expiry and points the leapfile directive of ntp.conf to it
+	SetLeapSecondsFile(context.Context, *LeapSecondsFile) (*emptypb.Empty, error)
+	// Returns the leap seconds file of the leapfile directive of ntp.conf
+	GetLeapSecondsFile(context.Context, *emptypb.Empty) (*LeapSecondsFile, error)
 	mustEmbedUnimplementedNtpServiceServer()
 }
 
@@ -121,6 +419,63 @@ func (UnimplementedNtpServiceServer) GetNtpServer(context.Context, *emptypb.Empt
 func (UnimplementedNtpServiceServer) GetStatus(context.Context, *emptypb.Empty) (*Status, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedNtpServiceServer) WatchStatus(*WatchStatusRequest, grpc.ServerStreamingServer[Status]) error {
+	return status.Error(codes.Unimplemented, "method WatchStatus not implemented")
+}
+func (UnimplementedNtpServiceServer) SetNtsCaBundle(context.Context, *NtsCaBundle) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetNtsCaBundle not implemented")
+}
+func (UnimplementedNtpServiceServer) GetNtsCaBundle(context.Context, *emptypb.Empty) (*NtsCaBundle, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNtsCaBundle not implemented")
+}
+func (UnimplementedNtpServiceServer) CreateKey(context.Context, *SymmetricKey) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateKey not implemented")
+}
+func (UnimplementedNtpServiceServer) ListKeys(context.Context, *emptypb.Empty) (*SymmetricKeys, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListKeys not implemented")
+}
+func (UnimplementedNtpServiceServer) RotateKey(context.Context, *SymmetricKey) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RotateKey not implemented")
+}
+func (UnimplementedNtpServiceServer) DeleteKey(context.Context, *SymmetricKeyId) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteKey not implemented")
+}
+func (UnimplementedNtpServiceServer) QueryServer(context.Context, *QueryServerRequest) (*QueryServerResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method QueryServer not implemented")
+}
+func (UnimplementedNtpServiceServer) SetServing(context.Context, *ServingConfig) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetServing not implemented")
+}
+func (UnimplementedNtpServiceServer) GetServing(context.Context, *emptypb.Empty) (*ServingConfig, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetServing not implemented")
+}
+func (UnimplementedNtpServiceServer) SetOrphanMode(context.Context, *OrphanConfig) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetOrphanMode not implemented")
+}
+func (UnimplementedNtpServiceServer) GetOrphanMode(context.Context, *emptypb.Empty) (*OrphanConfig, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOrphanMode not implemented")
+}
+func (UnimplementedNtpServiceServer) SetRefclocks(context.Context, *Refclocks) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetRefclocks not implemented")
+}
+func (UnimplementedNtpServiceServer) GetRefclocks(context.Context, *emptypb.Empty) (*Refclocks, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRefclocks not implemented")
+}
+func (UnimplementedNtpServiceServer) ListConfigHistory(context.Context, *emptypb.Empty) (*ConfigHistory, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListConfigHistory not implemented")
+}
+func (UnimplementedNtpServiceServer) DiffConfig(context.Context, *ConfigDiffRequest) (*ConfigDiff, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiffConfig not implemented")
+}
+func (UnimplementedNtpServiceServer) RestoreConfig(context.Context, *RestoreConfigRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreConfig not implemented")
+}
+func (UnimplementedNtpServiceServer) SetLeapSecondsFile(context.Context, *LeapSecondsFile) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetLeapSecondsFile not implemented")
+}
+func (UnimplementedNtpServiceServer) GetLeapSecondsFile(context.Context, *emptypb.Empty) (*LeapSecondsFile, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLeapSecondsFile not implemented")
+}
 func (UnimplementedNtpServiceServer) mustEmbedUnimplementedNtpServiceServer() {}
 func (UnimplementedNtpServiceServer) testEmbeddedByValue()                    {}
 
@@ -196,6 +551,341 @@ func _NtpService_GetStatus_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NtpService_WatchStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NtpServiceServer).WatchStatus(m, &grpc.GenericServerStream[WatchStatusRequest, Status]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NtpService_WatchStatusServer = grpc.ServerStreamingServer[Status]
+
+func _NtpService_SetNtsCaBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NtsCaBundle)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).SetNtsCaBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_SetNtsCaBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).SetNtsCaBundle(ctx, req.(*NtsCaBundle))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_GetNtsCaBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).GetNtsCaBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_GetNtsCaBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).GetNtsCaBundle(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_CreateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SymmetricKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).CreateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_CreateKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).CreateKey(ctx, req.(*SymmetricKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_ListKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).ListKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_ListKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).ListKeys(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_RotateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SymmetricKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).RotateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_RotateKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).RotateKey(ctx, req.(*SymmetricKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_DeleteKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SymmetricKeyId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).DeleteKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_DeleteKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).DeleteKey(ctx, req.(*SymmetricKeyId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_QueryServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).QueryServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_QueryServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).QueryServer(ctx, req.(*QueryServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_SetServing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServingConfig)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).SetServing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_SetServing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).SetServing(ctx, req.(*ServingConfig))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_GetServing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).GetServing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_GetServing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).GetServing(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_SetOrphanMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrphanConfig)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).SetOrphanMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_SetOrphanMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).SetOrphanMode(ctx, req.(*OrphanConfig))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_GetOrphanMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).GetOrphanMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_GetOrphanMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).GetOrphanMode(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_SetRefclocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Refclocks)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).SetRefclocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_SetRefclocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).SetRefclocks(ctx, req.(*Refclocks))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_GetRefclocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).GetRefclocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_GetRefclocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).GetRefclocks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_ListConfigHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).ListConfigHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_ListConfigHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).ListConfigHistory(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_DiffConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).DiffConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_DiffConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).DiffConfig(ctx, req.(*ConfigDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_RestoreConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).RestoreConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_RestoreConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).RestoreConfig(ctx, req.(*RestoreConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_SetLeapSecondsFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeapSecondsFile)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).SetLeapSecondsFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_SetLeapSecondsFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).SetLeapSecondsFile(ctx, req.(*LeapSecondsFile))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NtpService_GetLeapSecondsFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NtpServiceServer).GetLeapSecondsFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NtpService_GetLeapSecondsFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NtpServiceServer).GetLeapSecondsFile(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NtpService_ServiceDesc is the grpc.ServiceDesc for NtpService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -215,7 +905,85 @@ var NtpService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatus",
 			Handler:    _NtpService_GetStatus_Handler,
 		},
+		{
+			MethodName: "SetNtsCaBundle",
+			Handler:    _NtpService_SetNtsCaBundle_Handler,
+		},
+		{
+			MethodName: "GetNtsCaBundle",
+			Handler:    _NtpService_GetNtsCaBundle_Handler,
+		},
+		{
+			MethodName: "CreateKey",
+			Handler:    _NtpService_CreateKey_Handler,
+		},
+		{
+			MethodName: "ListKeys",
+			Handler:    _NtpService_ListKeys_Handler,
+		},
+		{
+			MethodName: "RotateKey",
+			Handler:    _NtpService_RotateKey_Handler,
+		},
+		{
+			MethodName: "DeleteKey",
+			Handler:    _NtpService_DeleteKey_Handler,
+		},
+		{
+			MethodName: "QueryServer",
+			Handler:    _NtpService_QueryServer_Handler,
+		},
+		{
+			MethodName: "SetServing",
+			Handler:    _NtpService_SetServing_Handler,
+		},
+		{
+			MethodName: "GetServing",
+			Handler:    _NtpService_GetServing_Handler,
+		},
+		{
+			MethodName: "SetOrphanMode",
+			Handler:    _NtpService_SetOrphanMode_Handler,
+		},
+		{
+			MethodName: "GetOrphanMode",
+			Handler:    _NtpService_GetOrphanMode_Handler,
+		},
+		{
+			MethodName: "SetRefclocks",
+			Handler:    _NtpService_SetRefclocks_Handler,
+		},
+		{
+			MethodName: "GetRefclocks",
+			Handler:    _NtpService_GetRefclocks_Handler,
+		},
+		{
+			MethodName: "ListConfigHistory",
+			Handler:    _NtpService_ListConfigHistory_Handler,
+		},
+		{
+			MethodName: "DiffConfig",
+			Handler:    _NtpService_DiffConfig_Handler,
+		},
+		{
+			MethodName: "RestoreConfig",
+			Handler:    _NtpService_RestoreConfig_Handler,
+		},
+		{
+			MethodName: "SetLeapSecondsFile",
+			Handler:    _NtpService_SetLeapSecondsFile_Handler,
+		},
+		{
+			MethodName: "GetLeapSecondsFile",
+			Handler:    _NtpService_GetLeapSecondsFile_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchStatus",
+			Handler:       _NtpService_WatchStatus_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "Ntp.proto",
 }
@@ -0,0 +1,76 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	ntpcf "ntpservice/internal/ntpconfigurator"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListConfigHistory the kept ntp.conf revisions are sent to the client, newest first.
+func (n ntpServer) ListConfigHistory(ctx context.Context, e *emptypb.Empty) (*v1.ConfigHistory, error) {
+	log.Println("ListConfigHistory() enter")
+	defer log.Println("ListConfigHistory() leave")
+	revisions, err := n.ntpConfigurator.ListConfigHistory()
+	if err != nil {
+		log.Println("ListConfigHistory() Failed to read the history: ", err.Error())
+		return nil, status.New(codes.Unknown, "Failed to read the configuration history").Err()
+	}
+	return &v1.ConfigHistory{Revisions: revisions}, status.New(codes.OK, "fine").Err()
+}
+
+// DiffConfig the unified diff between two ntp.conf revisions is sent to the client.
+func (n ntpServer) DiffConfig(ctx context.Context, request *v1.ConfigDiffRequest) (*v1.ConfigDiff, error) {
+	log.Println("DiffConfig() enter, from:", request.GetFrom(), "to:", request.GetTo())
+	defer log.Println("DiffConfig() leave")
+	diff, err := n.ntpConfigurator.DiffConfig(request.GetFrom(), request.GetTo())
+	if err != nil {
+		return nil, historyError(err)
+	}
+	return &v1.ConfigDiff{Diff: diff}, status.New(codes.OK, "fine").Err()
+}
+
+// RestoreConfig a kept ntp.conf revision is applied the same way as a configuration sent by SetNtpServer.
+func (n ntpServer) RestoreConfig(ctx context.Context, request *v1.RestoreConfigRequest) (*emptypb.Empty, error) {
+	log.Println("RestoreConfig() enter, revision:", request.GetId())
+	defer log.Println("RestoreConfig() leave")
+	if err := ntpcf.ValidateSyncTimeout(request.GetSyncTimeoutMs()); err != nil {
+		log.Println("RestoreConfig() Invalid sync timeout: ", err.Error())
+		return &emptypb.Empty{}, status.New(codes.InvalidArgument, err.Error()).Err()
+	}
+	if err := n.apply(ctx, applyRequest{restore: request}); err != nil {
+		log.Println("RestoreConfig() Failed to restore: ", err.Error())
+		if _, canceled := status.FromError(err); canceled {
+			return &emptypb.Empty{}, err
+		}
+		if errors.Is(err, ntpcf.ErrRevisionNotFound) {
+			return &emptypb.Empty{}, historyError(err)
+		}
+		return &emptypb.Empty{}, applyError(err)
+	}
+	if err := n.saveLastSettingTime(); err != nil {
+		return &emptypb.Empty{}, status.New(codes.FailedPrecondition, err.Error()).Err()
+	}
+	return &emptypb.Empty{}, status.New(codes.OK, "fine").Err()
+}
+
+// historyError converts the errors of the configuration history into grpc status errors.
+func historyError(err error) error {
+	if errors.Is(err, ntpcf.ErrRevisionNotFound) {
+		return status.New(codes.NotFound, err.Error()).Err()
+	}
+	log.Println("Configuration history failed: ", err.Error())
+	return status.New(codes.Unknown, "Failed to read the configuration history").Err()
+}
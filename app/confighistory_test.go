@@ -0,0 +1,72 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	ntpcf "ntpservice/internal/ntpconfigurator"
+	"ntpservice/internal/serviceconfig"
+	"ntpservice/utils/executor"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func prepareHistoryApp(t *testing.T) *MainApp {
+	tApp := CreateServiceApp(serviceconfig.Default())
+	tApp.serverInstance.ntpConfigurator.HistoryPath = t.TempDir()
+	configurator := ntpcf.NewNtpConfigurator(executor.OsExecutor{}, serviceconfig.Default())
+	configurator.HistoryPath = tApp.serverInstance.ntpConfigurator.HistoryPath
+	tApp.configurator = configurator
+	return tApp
+}
+
+func Test_ListConfigHistoryEmpty(t *testing.T) {
+	tApp := prepareHistoryApp(t)
+
+	history, err := tApp.serverInstance.ListConfigHistory(context.Background(), &emptypb.Empty{})
+
+	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
+	assert.Empty(t, history.Revisions)
+}
+
+func Test_DiffConfigNotFound(t *testing.T) {
+	tApp := prepareHistoryApp(t)
+
+	_, err := tApp.serverInstance.DiffConfig(context.Background(), &v1.ConfigDiffRequest{From: 1, To: 2})
+
+	assert.Equal(t, codes.NotFound, status.Code(err), "Did not get expected result. got: %q", err)
+}
+
+func Test_RestoreConfigNotFound(t *testing.T) {
+	tApp := prepareHistoryApp(t)
+	tApp.StartApp()
+
+	_, err := tApp.serverInstance.RestoreConfig(context.Background(), &v1.RestoreConfigRequest{Id: 42})
+
+	tApp.done <- true
+	assert.Equal(t, codes.NotFound, status.Code(err), "Did not get expected result. got: %q", err)
+}
+
+func Test_RestoreConfigSyncTimeoutAboveMaximum(t *testing.T) {
+	tApp := prepareHistoryApp(t)
+
+	_, err := tApp.serverInstance.RestoreConfig(context.Background(), &v1.RestoreConfigRequest{Id: 1, SyncTimeoutMs: 300001})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Did not get expected result. got: %q", err)
+}
+
+func Test_historyError(t *testing.T) {
+	assert.Equal(t, codes.NotFound, status.Code(historyError(ntpcf.ErrRevisionNotFound)))
+	assert.Equal(t, codes.Unknown, status.Code(historyError(errors.New("permission denied"))))
+}
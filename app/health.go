@@ -0,0 +1,108 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthInterval is the interval of the status reads for the health state. It is longer than the poll interval of
+// GetStatusUpdates, the health state follows the synchronization which changes slowly.
+const healthInterval = 30 * time.Second
+
+// healthServices are the services whose state is reported, the empty name is the overall state of the server.
+var healthServices = []string{"", v1.NtpService_ServiceDesc.ServiceName}
+
+// healthReporter sets the state of the grpc.health.v1.Health service from the status read every interval,
+// the health server notifies its Watch subscribers when the state changes.
+type healthReporter struct {
+	server      *health.Server
+	source      statusSource
+	interval    time.Duration
+	maxOffsetMs float32
+	reason      string
+}
+
+func newHealthReporter(source statusSource, maxOffsetMs float32) *healthReporter {
+	h := &healthReporter{server: health.NewServer(), source: source, interval: healthInterval, maxOffsetMs: maxOffsetMs, reason: "status not read yet"}
+	for _, service := range healthServices {
+		h.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return h
+}
+
+// run reads the status and updates the state every interval until stop is closed.
+func (h *healthReporter) run(stop <-chan struct{}) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		current, err := h.source.GetSyncStatus(context.Background())
+		if err != nil {
+			log.Println("Health status read failed:", err.Error())
+		} else {
+			h.update(current)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *healthReporter) update(current *v1.Status) {
+	servingStatus := healthpb.HealthCheckResponse_SERVING
+	reason := unhealthyReason(current, h.maxOffsetMs)
+	if reason != "" {
+		servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	if reason != h.reason {
+		log.Println("Health changed to", servingStatus.String()+":", reason)
+		h.reason = reason
+	}
+	for _, service := range healthServices {
+		h.server.SetServingStatus(service, servingStatus)
+	}
+}
+
+// unhealthyReason returns why the time synchronization is not healthy, empty if the time daemon is running,
+// a peer is reachable and the clock is synchronized within maxOffsetMs.
+func unhealthyReason(current *v1.Status, maxOffsetMs float32) string {
+	if !current.GetIsNtpServiceRunning() {
+		return "time daemon is not running"
+	}
+	reachable := false
+	for _, peer := range current.GetPeerDetails() {
+		if reach, err := strconv.ParseUint(peer.GetReach(), 8, 8); err == nil && reach != 0 {
+			reachable = true
+		}
+	}
+	if !reachable {
+		return "no peer is reachable"
+	}
+	if !current.GetIsSynced() {
+		return "clock is not synchronized"
+	}
+	offset := systemPeer(current).GetOffset()
+	if system := current.GetSystemVariables(); system != nil {
+		offset = system.GetOffset()
+	}
+	if math.Abs(float64(offset)) > float64(maxOffsetMs) {
+		return fmt.Sprintf("offset %.3f ms exceeds %.3f ms", offset, maxOffsetMs)
+	}
+	return ""
+}
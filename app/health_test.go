@@ -0,0 +1,77 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/serviceconfig"
+
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func Test_unhealthyReason(t *testing.T) {
+	stopped := tWatchedStatus(true, "377", 1)
+	stopped.IsNtpServiceRunning = false
+	unreachable := tWatchedStatus(true, "0", 1)
+	unreachable.PeerDetails[1].Reach = "0"
+	systemOffset := tWatchedStatus(true, "377", 1)
+	systemOffset.SystemVariables = &v1.SystemVariables{Offset: -200}
+
+	tests := []struct {
+		name    string
+		current *v1.Status
+		want    string
+	}{
+		{"healthy", tWatchedStatus(true, "377", 1), ""},
+		{"one peer reachable", tWatchedStatus(true, "0", 1), ""},
+		{"not running", stopped, "time daemon is not running"},
+		{"no peer reachable", unreachable, "no peer is reachable"},
+		{"no peers", &v1.Status{IsNtpServiceRunning: true, IsSynced: true}, "no peer is reachable"},
+		{"not synced", tWatchedStatus(false, "377", 1), "clock is not synchronized"},
+		{"peer offset", tWatchedStatus(true, "377", 150), "offset 150.000 ms exceeds 128.000 ms"},
+		{"system offset", systemOffset, "offset -200.000 ms exceeds 128.000 ms"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unhealthyReason(tt.current, serviceconfig.DefaultHealthMaxOffsetMs))
+		})
+	}
+}
+
+func Test_healthReporter(t *testing.T) {
+	source := &fakeStatusSource{current: tWatchedStatus(false, "377", 1)}
+	reporter := newHealthReporter(source, serviceconfig.DefaultHealthMaxOffsetMs)
+	reporter.interval = 10 * time.Millisecond
+	request := &healthpb.HealthCheckRequest{Service: v1.NtpService_ServiceDesc.ServiceName}
+
+	response, err := reporter.server.Check(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, response.Status)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go reporter.run(stop)
+	source.set(tWatchedStatus(true, "377", 1))
+
+	for _, service := range healthServices {
+		assert.Eventually(t, func() bool {
+			response, err := reporter.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			return err == nil && response.Status == healthpb.HealthCheckResponse_SERVING
+		}, time.Second, 10*time.Millisecond, "Did not get expected result for service %q", service)
+	}
+
+	source.set(tWatchedStatus(true, "377", 500))
+	assert.Eventually(t, func() bool {
+		response, err := reporter.server.Check(context.Background(), request)
+		return err == nil && response.Status == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	ntpcf "ntpservice/internal/ntpconfigurator"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// leapCheckInterval is the interval of the warning about an expiring leap seconds file.
+const leapCheckInterval = 24 * time.Hour
+
+// SetLeapSecondsFile stores the leap seconds file used by ntpsec to announce leap seconds.
+func (n ntpServer) SetLeapSecondsFile(ctx context.Context, file *v1.LeapSecondsFile) (*emptypb.Empty, error) {
+	log.Println("SetLeapSecondsFile() enter")
+	defer log.Println("SetLeapSecondsFile() leave")
+	if err := n.ntpConfigurator.SetLeapSeconds(ctx, file.GetContent()); err != nil {
+		log.Println("SetLeapSecondsFile() Failed to Set: ", err.Error())
+		if errors.Is(err, ntpcf.ErrInvalidLeapFile) {
+			return &emptypb.Empty{}, status.New(codes.InvalidArgument, err.Error()).Err()
+		}
+		if errors.Is(err, ntpcf.ErrNotSupported) {
+			return &emptypb.Empty{}, status.New(codes.Unimplemented, err.Error()).Err()
+		}
+		return &emptypb.Empty{}, applyError(err)
+	}
+	return &emptypb.Empty{}, status.New(codes.OK, "fine").Err()
+}
+
+// GetLeapSecondsFile the leap seconds file of the leapfile directive is sent to the client.
+func (n ntpServer) GetLeapSecondsFile(ctx context.Context, e *emptypb.Empty) (*v1.LeapSecondsFile, error) {
+	log.Println("GetLeapSecondsFile() enter")
+	defer log.Println("GetLeapSecondsFile() leave")
+	content, err := n.ntpConfigurator.GetLeapSeconds()
+	if err != nil {
+		log.Println("GetLeapSecondsFile() Failed to Get: ", err.Error())
+		return nil, status.New(codes.Unknown, "Failed to Get").Err()
+	}
+	return &v1.LeapSecondsFile{Content: content}, status.New(codes.OK, "fine").Err()
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/serviceconfig"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func Test_SetLeapSecondsFileInvalid(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+	dir := t.TempDir()
+	tApp.serverInstance.ntpConfigurator.NtpConfPath = filepath.Join(dir, "ntp.conf")
+	tApp.serverInstance.ntpConfigurator.LeapPath = filepath.Join(dir, "leap-seconds.list")
+
+	_, err := tApp.serverInstance.SetLeapSecondsFile(context.Background(), &v1.LeapSecondsFile{Content: "#@\t4291401600\n"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Did not get expected result. got: %q", err)
+}
+
+func Test_GetLeapSecondsFile(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+	dir := t.TempDir()
+	confPath := filepath.Join(dir, "ntp.conf")
+	leapPath := filepath.Join(dir, "leap-seconds.list")
+	assert.NoError(t, os.WriteFile(confPath, []byte("leapfile "+leapPath+"\n"), 0644))
+	assert.NoError(t, os.WriteFile(leapPath, []byte("#@\t4291401600\n"), 0644))
+	tApp.serverInstance.ntpConfigurator.NtpConfPath = confPath
+
+	file, err := tApp.serverInstance.GetLeapSecondsFile(context.Background(), &emptypb.Empty{})
+
+	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
+	assert.Equal(t, "#@\t4291401600\n", file.GetContent())
+}
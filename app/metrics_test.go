@@ -0,0 +1,40 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"ntpservice/internal/serviceconfig"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_serveMetrics(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go tApp.serveMetrics(lis)
+	defer lis.Close()
+
+	response, err := http.Get("http://" + lis.Addr().String() + metricsPath)
+
+	assert.NoError(t, err)
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Contains(t, string(body), "ntpservice_status_up")
+}
+
+func Test_StartMetrics_InvalidAddress(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+
+	assert.Error(t, tApp.StartMetrics("invalid address"))
+}
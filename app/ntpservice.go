@@ -16,16 +16,29 @@ import (
 
 	"log"
 	"net"
+	"net/http"
 	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/access"
+	"ntpservice/internal/metrics"
 	ntpcf "ntpservice/internal/ntpconfigurator"
+	"ntpservice/internal/serviceconfig"
+	"ntpservice/utils/executor"
 	"os"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// errorDomain is the domain of the ErrorInfo details sent by the service.
+const errorDomain = "ntpservice.siemens.com"
+
+const metricsPath = "/metrics"
+const metricsReadHeaderTimeout = 10 * time.Second
+
 type DeviceModelService interface {
 	StartGRPC(args []string)
 	StartApp()
@@ -33,44 +46,66 @@ type DeviceModelService interface {
 
 type ntpServer struct {
 	v1.UnimplementedNtpServiceServer
-	channelWr       chan []string
-	errWr           chan error
+	channelWr       chan applyRequest
 	ntpConfigurator *ntpcf.NtpConfigurator
+	statusWatcher   *statusWatcher
 }
 
 type MainApp struct {
 	serverInstance *ntpServer
 	configurator   configuratorApi
+	metrics        *metrics.Metrics
+	health         *healthReporter
+	tls            tlsSettings
+	policyPath     string
+	socket         serviceconfig.Socket
 	done           chan bool
 }
 
 type configuratorApi interface {
-	WriteConfiguration(serverList []string) error
+	WriteConfiguration(ctx context.Context, config *v1.Ntp) error
+	RestoreConfiguration(ctx context.Context, id uint64, syncTimeoutMs uint32) error
+}
+
+// applyRequest is either a configuration sent by SetNtpServer or a revision to restore sent by RestoreConfig,
+// ctx is the context of the RPC. The worker sends the outcome to result, which is buffered so that the worker does
+// not block when the RPC stopped waiting.
+type applyRequest struct {
+	ctx     context.Context
+	config  *v1.Ntp
+	restore *v1.RestoreConfigRequest
+	result  chan error
 }
 
-func CreateServiceApp() *MainApp {
+// CreateServiceApp returns the service with the loaded service configuration.
+func CreateServiceApp(config *serviceconfig.Config) *MainApp {
 	app := MainApp{}
-	ut := ntpcf.OsUtils{}
-	vt := ntpcf.NewNtpConfigurator(ut)
+	ex := executor.OsExecutor{}
+	vt := ntpcf.NewNtpConfigurator(ex, config)
 	app.serverInstance = &ntpServer{
-		channelWr:       make(chan []string),
-		errWr:           make(chan error),
+		channelWr:       make(chan applyRequest),
 		ntpConfigurator: vt,
+		statusWatcher:   newStatusWatcher(vt),
 	}
+	app.metrics = metrics.New(vt, vt.Backend.Name(), ntpcf.LastConfigTimeLayout)
+	app.health = newHealthReporter(vt, float32(config.Health.MaxOffsetMs))
+	app.tls = newTLSSettings(config.TLS)
+	app.policyPath = config.Paths.AccessPolicy
+	app.socket = config.Socket
 	app.done = make(chan bool)
 
-	app.configurator = ntpcf.NewNtpConfigurator(ut)
+	app.configurator = ntpcf.NewNtpConfigurator(ex, config)
 
 	return &app
 }
 
-func chownSocket(address string, userName string, groupName string) error {
-	us, err1 := user.Lookup(userName)
-	group, err2 := user.LookupGroup(groupName)
+func chownSocket(address string, socket serviceconfig.Socket) error {
+	us, err1 := user.Lookup(socket.Owner)
+	group, err2 := user.LookupGroup(socket.Group)
 	if err1 == nil && err2 == nil {
 		uid, _ := strconv.Atoi(us.Uid)
 		gid, _ := strconv.Atoi(group.Gid)
-		err3 := os.Chmod(address, os.FileMode.Perm(0660))
+		err3 := os.Chmod(address, os.FileMode(socket.Mode).Perm())
 		err4 := os.Chown(address, uid, gid)
 		if err3 != nil || err4 != nil {
 			return errors.New("file permissions failed")
@@ -108,6 +143,34 @@ func (app *MainApp) StartGRPC(args []string) error {
 		}
 	}
 
+	options := []grpc.ServerOption{grpc.ChainUnaryInterceptor(app.metrics.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(app.metrics.StreamServerInterceptor())}
+	if typeOfConnection == "unix" {
+		policy, err := access.LoadPolicy(app.policyPath)
+		if err != nil {
+			log.Println("Cannot load the access policy: ", err.Error())
+			return errors.New("Cannot load the access policy: " + err.Error())
+		}
+		if policy == nil {
+			log.Println("No access policy at", app.policyPath, "every client of the socket may call every RPC")
+		}
+		// the interceptors are chained after the metrics, denied calls are counted too
+		options = append(options, grpc.Creds(access.Credentials()),
+			grpc.ChainUnaryInterceptor(access.UnaryServerInterceptor(policy)),
+			grpc.ChainStreamInterceptor(access.StreamServerInterceptor(policy)))
+	}
+	if typeOfConnection == "tcp" {
+		creds, err := tcpCredentials(address, app.tls)
+		if err != nil {
+			log.Println("Cannot configure TLS: ", err.Error())
+			return errors.New("Cannot configure TLS: " + err.Error())
+		}
+		if creds != nil {
+			options = append(options, grpc.Creds(creds))
+			log.Println("TLS enabled, client certificates required:", app.tls.options.ClientCAFile != "")
+		}
+	}
+
 	lis, err := net.Listen(typeOfConnection, address)
 
 	if err != nil {
@@ -115,15 +178,22 @@ func (app *MainApp) StartGRPC(args []string) error {
 		return errors.New("Failed to listen: " + err.Error())
 
 	}
-	err = chownSocket(address, "root", "docker")
-	if err != nil {
-		return err
+	// only the unix socket is restricted to the configured group, a tcp listener has no file
+	if typeOfConnection == "unix" {
+		err = chownSocket(address, app.socket)
+		if err != nil {
+			return err
+		}
 	}
 
 	log.Print("Started listening on : ", typeOfConnection, " - ", address)
-	s := grpc.NewServer()
+	s := grpc.NewServer(options...)
 
 	v1.RegisterNtpServiceServer(s, app.serverInstance)
+	healthgrpc.RegisterHealthServer(s, app.health.server)
+	stopHealth := make(chan struct{})
+	defer close(stopHealth)
+	go app.health.run(stopHealth)
 	if err := s.Serve(lis); err != nil {
 		log.Printf("Failed to serve: %v", err)
 		return errors.New("Failed to serve: " + err.Error())
@@ -132,20 +202,52 @@ func (app *MainApp) StartGRPC(args []string) error {
 	return nil
 }
 
+// StartMetrics serves the Prometheus metrics on http://address/metrics until the listener fails.
+func (app *MainApp) StartMetrics(address string) error {
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Println("Failed to listen for metrics: ", err.Error())
+		return errors.New("Failed to listen for metrics: " + err.Error())
+	}
+	log.Print("Serving metrics on : ", lis.Addr().String(), metricsPath)
+	return app.serveMetrics(lis)
+}
+
+func (app *MainApp) serveMetrics(lis net.Listener) error {
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, app.metrics.Handler())
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: metricsReadHeaderTimeout}
+	if err := server.Serve(lis); err != nil {
+		log.Printf("Failed to serve metrics: %v", err)
+		return errors.New("Failed to serve metrics: " + err.Error())
+	}
+	return nil
+}
+
 // StartApp When a request is received by the client, the processes start here.
 func (app *MainApp) StartApp() {
-	var serverList []string
-	// waits for the new ServerList
+	var request applyRequest
+	// waits for the new ServerList or the revision to restore, the leap seconds file is checked once a day
 	go func() {
+		leapCheck := time.NewTicker(leapCheckInterval)
+		defer leapCheck.Stop()
+		app.serverInstance.ntpConfigurator.WarnLeapExpiry(time.Now())
 
 		for {
 			select {
 			case <-app.done:
 				log.Println("app done!")
 				return
-			case serverList = <-app.serverInstance.channelWr:
-				err := app.configurator.WriteConfiguration(serverList)
-				app.serverInstance.errWr <- err
+			case <-leapCheck.C:
+				app.serverInstance.ntpConfigurator.WarnLeapExpiry(time.Now())
+			case request = <-app.serverInstance.channelWr:
+				var err error
+				if request.restore != nil {
+					err = app.configurator.RestoreConfiguration(request.ctx, request.restore.GetId(), request.restore.GetSyncTimeoutMs())
+				} else {
+					err = app.configurator.WriteConfiguration(request.ctx, request.config)
+				}
+				request.result <- err
 			}
 		}
 	}()
@@ -160,51 +262,286 @@ func (app *MainApp) StartApp() {
 func (n ntpServer) SetNtpServer(ctx context.Context, serverList *v1.Ntp) (*emptypb.Empty, error) {
 	log.Println("SetNtpServer() enter")
 	log.Println("Values passed by the client to the SetNtpServer() method: ", serverList)
-	//pass the server list for WriteConfiguration
-	n.channelWr <- serverList.NtpServer
 	defer log.Println("SetNtpServer() leave")
-	//err result
-	if err := <-n.errWr; err != nil {
-		log.Println("SetNtpServer() Failed to Set")
-		return &emptypb.Empty{}, status.New(codes.Unknown, "Failed to Set").Err()
+	if err := ntpcf.ValidateNtpServers(serverList); err != nil {
+		log.Println("SetNtpServer() Invalid server entry: ", err.Error())
+		return &emptypb.Empty{}, status.New(codes.InvalidArgument, err.Error()).Err()
+	}
+	if err := n.ntpConfigurator.ValidateBackend(serverList); err != nil {
+		log.Println("SetNtpServer() Not supported: ", err.Error())
+		return &emptypb.Empty{}, status.New(codes.Unimplemented, err.Error()).Err()
+	}
+	if err := n.ntpConfigurator.ValidateKeyReferences(serverList); err != nil {
+		log.Println("SetNtpServer() Invalid key reference: ", err.Error())
+		if errors.Is(err, ntpcf.ErrNotSupported) {
+			return &emptypb.Empty{}, status.New(codes.Unimplemented, err.Error()).Err()
+		}
+		return &emptypb.Empty{}, status.New(codes.InvalidArgument, err.Error()).Err()
+	}
+	//pass the server list for WriteConfiguration
+	if err := n.apply(ctx, applyRequest{config: serverList}); err != nil {
+		log.Println("SetNtpServer() Failed to Set: ", err.Error())
+		if _, canceled := status.FromError(err); canceled {
+			return &emptypb.Empty{}, err
+		}
+		return &emptypb.Empty{}, applyError(err)
+	}
+	if err := n.saveLastSettingTime(); err != nil {
+		return &emptypb.Empty{}, status.New(codes.FailedPrecondition, err.Error()).Err()
+	}
+
+	return &emptypb.Empty{}, status.New(codes.OK, "fine").Err()
+}
+
+// apply passes the request to the apply worker and waits for its outcome. If ctx is done first the CANCELLED or
+// DEADLINE_EXCEEDED status is returned, an apply the worker already started is finished by the worker.
+func (n ntpServer) apply(ctx context.Context, request applyRequest) error {
+	request.ctx = ctx
+	request.result = make(chan error, 1)
+	select {
+	case n.channelWr <- request:
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
 	}
+	select {
+	case err := <-request.result:
+		return err
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
 
-	//Save ntp last setting time
+// saveLastSettingTime saves the time of the last applied configuration.
+func (n ntpServer) saveLastSettingTime() error {
 	currentTime := time.Now()
-	ntpSettingTime := currentTime.Format("2006.01.02 15:04:05")
+	ntpSettingTime := currentTime.Format(ntpcf.LastConfigTimeLayout)
 	log.Println("Ntp Last Setting Time : " + ntpSettingTime)
 
-	if err := os.MkdirAll(filepath.Dir(ntpcf.NtpLastConfigPath), ntpcf.DefaultResourcePermissions); err != nil {
-		return &emptypb.Empty{}, status.New(codes.FailedPrecondition, err.Error()).Err()
+	if err := os.MkdirAll(filepath.Dir(n.ntpConfigurator.ConfigPath), ntpcf.DefaultResourcePermissions); err != nil {
+		return err
 	}
 
-	f, err := os.OpenFile(ntpcf.NtpLastConfigPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, ntpcf.DefaultResourcePermissions)
-	if err != nil {
-		log.Println("Error Opening file : ")
-	} else {
-		_, err = f.WriteString(ntpSettingTime)
+	if err := n.ntpConfigurator.Files.WriteFileAtomic(n.ntpConfigurator.ConfigPath, []byte(ntpSettingTime), 0644); err != nil {
+		log.Println("Error writing last setting time: ", err.Error())
+		return err
 	}
-	defer f.Close()
+	return nil
+}
 
-	return &emptypb.Empty{}, status.New(codes.OK, "fine").Err()
+// applyError converts a failed apply into an ABORTED status naming the failed step and the rollback outcome.
+func applyError(err error) error {
+	var applyErr *ntpcf.ApplyError
+	if !errors.As(err, &applyErr) {
+		return status.New(codes.Unknown, "Failed to Set").Err()
+	}
+	metadata := map[string]string{
+		"step":       applyErr.Step,
+		"rolledBack": strconv.FormatBool(applyErr.RolledBack),
+	}
+	if applyErr.RollbackErr != nil {
+		metadata["rollbackError"] = applyErr.RollbackErr.Error()
+	}
+	st, detailErr := status.New(codes.Aborted, "Failed to Set: "+applyErr.Error()).WithDetails(&errdetails.ErrorInfo{
+		Reason:   "APPLY_FAILED",
+		Domain:   errorDomain,
+		Metadata: metadata,
+	})
+	if detailErr != nil {
+		return status.New(codes.Aborted, "Failed to Set: "+applyErr.Error()).Err()
+	}
+	return st.Err()
 }
 
 // GetNtpServer ntp configurations in the device are sent to the client.
 func (n ntpServer) GetNtpServer(ctx context.Context, e *emptypb.Empty) (serverList *v1.Ntp, err error) {
 	log.Println("GetNtpServer() enter")
-	valueWithServerPrefix, err := n.ntpConfigurator.GetCurrentNtpServers()
+	serverList, err = n.ntpConfigurator.GetCurrentNtpServers()
 	if err != nil {
 		log.Fatalln("GetNtpServer() Failed to GetCurrentNtpServers()")
 	}
-	serverList = &v1.Ntp{NtpServer: valueWithServerPrefix}
 	log.Println("Server list sent to client:", serverList)
 	log.Println("GetNtpServer() leave")
 	return serverList, status.New(codes.OK, "fine").Err()
 }
 
+// SetNtsCaBundle stores the CA bundle used for the NTS key establishment.
+func (n ntpServer) SetNtsCaBundle(ctx context.Context, bundle *v1.NtsCaBundle) (*emptypb.Empty, error) {
+	log.Println("SetNtsCaBundle() enter")
+	defer log.Println("SetNtsCaBundle() leave")
+	if err := n.ntpConfigurator.SetNtsCaBundle(ctx, bundle.GetPem()); err != nil {
+		log.Println("SetNtsCaBundle() Failed to Set: ", err.Error())
+		if errors.Is(err, ntpcf.ErrInvalidCaBundle) {
+			return &emptypb.Empty{}, status.New(codes.InvalidArgument, err.Error()).Err()
+		}
+		if errors.Is(err, ntpcf.ErrNotSupported) {
+			return &emptypb.Empty{}, status.New(codes.Unimplemented, err.Error()).Err()
+		}
+		return &emptypb.Empty{}, applyError(err)
+	}
+	return &emptypb.Empty{}, status.New(codes.OK, "fine").Err()
+}
+
+// GetNtsCaBundle the CA bundle used for the NTS key establishment is sent to the client.
+func (n ntpServer) GetNtsCaBundle(ctx context.Context, e *emptypb.Empty) (*v1.NtsCaBundle, error) {
+	log.Println("GetNtsCaBundle() enter")
+	defer log.Println("GetNtsCaBundle() leave")
+	bundle, err := n.ntpConfigurator.GetNtsCaBundle()
+	if err != nil {
+		log.Println("GetNtsCaBundle() Failed to Get: ", err.Error())
+		return nil, status.New(codes.Unknown, "Failed to Get").Err()
+	}
+	return &v1.NtsCaBundle{Pem: bundle}, status.New(codes.OK, "fine").Err()
+}
+
+// CreateKey adds a symmetric key to ntp.keys.
+func (n ntpServer) CreateKey(ctx context.Context, key *v1.SymmetricKey) (*emptypb.Empty, error) {
+	log.Println("CreateKey() enter, key id:", key.GetKeyId())
+	defer log.Println("CreateKey() leave")
+	return &emptypb.Empty{}, keyError(n.ntpConfigurator.CreateKey(ctx, key))
+}
+
+// ListKeys the symmetric keys without their secrets are sent to the client.
+func (n ntpServer) ListKeys(ctx context.Context, e *emptypb.Empty) (*v1.SymmetricKeys, error) {
+	log.Println("ListKeys() enter")
+	defer log.Println("ListKeys() leave")
+	keys, err := n.ntpConfigurator.ListKeys()
+	if err != nil {
+		return nil, keyError(err)
+	}
+	return &v1.SymmetricKeys{Keys: keys}, status.New(codes.OK, "fine").Err()
+}
+
+// RotateKey replaces the type and secret of a symmetric key.
+func (n ntpServer) RotateKey(ctx context.Context, key *v1.SymmetricKey) (*emptypb.Empty, error) {
+	log.Println("RotateKey() enter, key id:", key.GetKeyId())
+	defer log.Println("RotateKey() leave")
+	return &emptypb.Empty{}, keyError(n.ntpConfigurator.RotateKey(ctx, key))
+}
+
+// DeleteKey removes a symmetric key which is not used by a server.
+func (n ntpServer) DeleteKey(ctx context.Context, id *v1.SymmetricKeyId) (*emptypb.Empty, error) {
+	log.Println("DeleteKey() enter, key id:", id.GetKeyId())
+	defer log.Println("DeleteKey() leave")
+	return &emptypb.Empty{}, keyError(n.ntpConfigurator.DeleteKey(ctx, id.GetKeyId()))
+}
+
+// keyError converts the errors of the key management into grpc status errors, the secrets are never part of them.
+func keyError(err error) error {
+	switch {
+	case err == nil:
+		return status.New(codes.OK, "fine").Err()
+	case errors.Is(err, ntpcf.ErrInvalidKey):
+		return status.New(codes.InvalidArgument, err.Error()).Err()
+	case errors.Is(err, ntpcf.ErrKeyExists):
+		return status.New(codes.AlreadyExists, err.Error()).Err()
+	case errors.Is(err, ntpcf.ErrKeyNotFound):
+		return status.New(codes.NotFound, err.Error()).Err()
+	case errors.Is(err, ntpcf.ErrKeyInUse):
+		return status.New(codes.FailedPrecondition, err.Error()).Err()
+	case errors.Is(err, ntpcf.ErrNotSupported):
+		return status.New(codes.Unimplemented, err.Error()).Err()
+	}
+	log.Println("Key operation failed: ", err.Error())
+	var applyErr *ntpcf.ApplyError
+	if errors.As(err, &applyErr) {
+		return applyError(err)
+	}
+	return status.New(codes.Unknown, "Failed to update keys").Err()
+}
+
+// SetServing replaces the restrict rules of ntp.conf to serve time to other devices.
+func (n ntpServer) SetServing(ctx context.Context, serving *v1.ServingConfig) (*emptypb.Empty, error) {
+	log.Println("SetServing() enter")
+	log.Println("Values passed by the client to the SetServing() method: ", serving)
+	defer log.Println("SetServing() leave")
+	if err := n.ntpConfigurator.SetServing(ctx, serving); err != nil {
+		log.Println("SetServing() Failed to Set: ", err.Error())
+		if errors.Is(err, ntpcf.ErrInvalidServing) {
+			return &emptypb.Empty{}, status.New(codes.InvalidArgument, err.Error()).Err()
+		}
+		if errors.Is(err, ntpcf.ErrNotSupported) {
+			return &emptypb.Empty{}, status.New(codes.Unimplemented, err.Error()).Err()
+		}
+		return &emptypb.Empty{}, applyError(err)
+	}
+	return &emptypb.Empty{}, status.New(codes.OK, "fine").Err()
+}
+
+// GetServing the time service offered to other devices is sent to the client.
+func (n ntpServer) GetServing(ctx context.Context, e *emptypb.Empty) (*v1.ServingConfig, error) {
+	log.Println("GetServing() enter")
+	defer log.Println("GetServing() leave")
+	serving, err := n.ntpConfigurator.GetServing()
+	if err != nil {
+		log.Println("GetServing() Failed to Get: ", err.Error())
+		return nil, status.New(codes.Unknown, "Failed to Get").Err()
+	}
+	return serving, status.New(codes.OK, "fine").Err()
+}
+
+// SetOrphanMode sets the orphan stratum and the sibling peers used when no upstream server is reachable.
+func (n ntpServer) SetOrphanMode(ctx context.Context, orphan *v1.OrphanConfig) (*emptypb.Empty, error) {
+	log.Println("SetOrphanMode() enter")
+	log.Println("Values passed by the client to the SetOrphanMode() method: ", orphan)
+	defer log.Println("SetOrphanMode() leave")
+	if err := n.ntpConfigurator.SetOrphanMode(ctx, orphan); err != nil {
+		log.Println("SetOrphanMode() Failed to Set: ", err.Error())
+		if errors.Is(err, ntpcf.ErrInvalidOrphanMode) || errors.Is(err, ntpcf.ErrInvalidServerEntry) || errors.Is(err, ntpcf.ErrKeyNotFound) {
+			return &emptypb.Empty{}, status.New(codes.InvalidArgument, err.Error()).Err()
+		}
+		if errors.Is(err, ntpcf.ErrNotSupported) {
+			return &emptypb.Empty{}, status.New(codes.Unimplemented, err.Error()).Err()
+		}
+		return &emptypb.Empty{}, applyError(err)
+	}
+	return &emptypb.Empty{}, status.New(codes.OK, "fine").Err()
+}
+
+// GetOrphanMode the orphan stratum and the sibling peers are sent to the client.
+func (n ntpServer) GetOrphanMode(ctx context.Context, e *emptypb.Empty) (*v1.OrphanConfig, error) {
+	log.Println("GetOrphanMode() enter")
+	defer log.Println("GetOrphanMode() leave")
+	orphan, err := n.ntpConfigurator.GetOrphanMode()
+	if err != nil {
+		log.Println("GetOrphanMode() Failed to Get: ", err.Error())
+		return nil, status.New(codes.Unknown, "Failed to Get").Err()
+	}
+	return orphan, status.New(codes.OK, "fine").Err()
+}
+
+// SetRefclocks replaces the reference clocks of ntp.conf.
+func (n ntpServer) SetRefclocks(ctx context.Context, refclocks *v1.Refclocks) (*emptypb.Empty, error) {
+	log.Println("SetRefclocks() enter")
+	log.Println("Values passed by the client to the SetRefclocks() method: ", refclocks)
+	defer log.Println("SetRefclocks() leave")
+	if err := n.ntpConfigurator.SetRefclocks(ctx, refclocks); err != nil {
+		log.Println("SetRefclocks() Failed to Set: ", err.Error())
+		if errors.Is(err, ntpcf.ErrInvalidRefclock) {
+			return &emptypb.Empty{}, status.New(codes.InvalidArgument, err.Error()).Err()
+		}
+		if errors.Is(err, ntpcf.ErrNotSupported) {
+			return &emptypb.Empty{}, status.New(codes.Unimplemented, err.Error()).Err()
+		}
+		return &emptypb.Empty{}, applyError(err)
+	}
+	return &emptypb.Empty{}, status.New(codes.OK, "fine").Err()
+}
+
+// GetRefclocks the reference clocks of ntp.conf are sent to the client.
+func (n ntpServer) GetRefclocks(ctx context.Context, e *emptypb.Empty) (*v1.Refclocks, error) {
+	log.Println("GetRefclocks() enter")
+	defer log.Println("GetRefclocks() leave")
+	refclocks, err := n.ntpConfigurator.GetRefclocks()
+	if err != nil {
+		log.Println("GetRefclocks() Failed to Get: ", err.Error())
+		return nil, status.New(codes.Unknown, "Failed to Get").Err()
+	}
+	return refclocks, status.New(codes.OK, "fine").Err()
+}
+
 // GetStatus check ntp peers and synced behaviours with setting date time.
 func (n ntpServer) GetStatus(ctx context.Context, e *emptypb.Empty) (status *v1.Status, err error) {
 	log.Println("GetStatus() enter")
-	status, err = n.ntpConfigurator.GetNtpStatus()
+	status, err = n.ntpConfigurator.GetSyncStatus(ctx)
 	return status, err
 }
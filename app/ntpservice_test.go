@@ -11,10 +11,17 @@ import (
 	"errors"
 	"google.golang.org/protobuf/types/known/emptypb"
 	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	ntpcf "ntpservice/internal/ntpconfigurator"
+	"ntpservice/internal/serviceconfig"
+	"ntpservice/internal/servicemanager/servicemanagertest"
+	"ntpservice/utils/executor"
 	"os/exec"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"time"
 )
@@ -22,13 +29,17 @@ import (
 type tConfigurator struct {
 }
 
-func (c tConfigurator) WriteConfiguration(serverList []string) error {
+func (c tConfigurator) WriteConfiguration(ctx context.Context, config *v1.Ntp) error {
 	return errors.New("Failed WriteConfiguratiob")
 }
 
+func (c tConfigurator) RestoreConfiguration(ctx context.Context, id uint64, syncTimeoutMs uint32) error {
+	return errors.New("Failed RestoreConfiguration")
+}
+
 func Test_VerifyArgsForStartGRPC_WithLessArgs(t *testing.T) {
 	//Create App to use
-	tApp := CreateServiceApp()
+	tApp := CreateServiceApp(serviceconfig.Default())
 
 	//Test with 0 argument
 	tArgs := []string{}
@@ -51,7 +62,7 @@ func Test_VerifyArgsForStartGRPC_WithLessArgs(t *testing.T) {
 
 func Test_VerifyArgsForStartGRPC_WithInappropriateArgs(t *testing.T) {
 	//Create App to use
-	tApp := CreateServiceApp()
+	tApp := CreateServiceApp(serviceconfig.Default())
 
 	tApp.StartApp()
 
@@ -67,7 +78,7 @@ func Test_VerifyArgsForStartGRPC_WithInappropriateArgs(t *testing.T) {
 
 func Test_VerifyArgsForStartGRPC_WithDummySocketForUnix(t *testing.T) {
 	//Create App to use
-	tApp := CreateServiceApp()
+	tApp := CreateServiceApp(serviceconfig.Default())
 
 	//wait until system is up and goroutines are running
 	tApp.StartApp()
@@ -85,10 +96,10 @@ func Test_VerifyArgsForStartGRPC_WithDummySocketForUnix(t *testing.T) {
 
 func Test_SetNtpServerFailure(t *testing.T) {
 	//Prepare Test Data
-	var dummyctx context.Context
+	dummyctx := context.Background()
 
 	//Create App to use
-	tApp := CreateServiceApp()
+	tApp := CreateServiceApp(serviceconfig.Default())
 
 	//inject new configurator
 	tApp.configurator = tConfigurator{}
@@ -107,9 +118,49 @@ func Test_SetNtpServerFailure(t *testing.T) {
 	assert.Contains(t, err.Error(), "Failed to Set", "Did not get expected result. expected: %q got: %q", "Failed to Set", err.Error())
 }
 
+type tBlockingConfigurator struct {
+	tConfigurator
+	started chan bool
+	release chan bool
+}
+
+func (c tBlockingConfigurator) WriteConfiguration(ctx context.Context, config *v1.Ntp) error {
+	c.started <- true
+	<-c.release
+	return errors.New("Failed WriteConfiguration")
+}
+
+func Test_SetNtpServerCanceled(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+	configurator := tBlockingConfigurator{started: make(chan bool, 2), release: make(chan bool, 2)}
+	tApp.configurator = configurator
+
+	// Without the worker the request is not accepted.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := tApp.serverInstance.SetNtpServer(ctx, &v1.Ntp{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err), "Did not get expected result. got: %q", err)
+
+	// The worker finishes an apply whose request was canceled and accepts the next request.
+	tApp.StartApp()
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		<-configurator.started
+		cancel()
+	}()
+	_, err = tApp.serverInstance.SetNtpServer(ctx, &v1.Ntp{})
+	assert.Equal(t, codes.Canceled, status.Code(err), "Did not get expected result. got: %q", err)
+	configurator.release <- true
+
+	configurator.release <- true
+	_, err = tApp.serverInstance.SetNtpServer(context.Background(), &v1.Ntp{})
+	assert.Contains(t, err.Error(), "Failed to Set", "Did not get expected result. got: %q", err)
+	tApp.done <- true
+}
+
 func Test_chownSocketFailure(t *testing.T) {
 	//Fail the function with Non existing file path
-	err := chownSocket("Non/existing/Path", "root", "root")
+	err := chownSocket("Non/existing/Path", serviceconfig.Socket{Owner: "root", Group: "root", Mode: 0660})
 
 	assert.NotNil(t, err, "Did not get expected result. got: %q", err)
 }
@@ -119,7 +170,7 @@ func Test_GetNtpServerFailure(t *testing.T) {
 	var dummyctx context.Context
 
 	//Create App to use
-	tApp := CreateServiceApp()
+	tApp := CreateServiceApp(serviceconfig.Default())
 
 	//inject new configurator
 	tApp.configurator = tConfigurator{}
@@ -137,3 +188,100 @@ func Test_GetNtpServerFailure(t *testing.T) {
 
 	assert.Nil(t, err2, "Did not get expected result for GetNtpServer() method. Wanted: Nil, got: %q", err2)
 }
+
+func Test_SetNtpServerInvalidEntry(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+	tApp.configurator = tConfigurator{}
+
+	serverList := v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "1.2.3.4", Minpoll: 1}}}
+
+	_, err := tApp.serverInstance.SetNtpServer(context.Background(), &serverList)
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Did not get expected result. got: %q", err)
+}
+
+func Test_SetNtsCaBundleInvalid(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+
+	_, err := tApp.serverInstance.SetNtsCaBundle(context.Background(), &v1.NtsCaBundle{Pem: "not a certificate"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Did not get expected result. got: %q", err)
+}
+
+func Test_keyError(t *testing.T) {
+	assert.Nil(t, keyError(nil))
+	assert.Equal(t, codes.InvalidArgument, status.Code(keyError(ntpcf.ErrInvalidKey)))
+	assert.Equal(t, codes.AlreadyExists, status.Code(keyError(ntpcf.ErrKeyExists)))
+	assert.Equal(t, codes.NotFound, status.Code(keyError(ntpcf.ErrKeyNotFound)))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(keyError(ntpcf.ErrKeyInUse)))
+	assert.Equal(t, codes.Unknown, status.Code(keyError(errors.New("disk full"))))
+	assert.Equal(t, codes.Aborted, status.Code(keyError(&ntpcf.ApplyError{Step: ntpcf.StepRestart, Err: errors.New("action failed"), RolledBack: true})))
+}
+
+func Test_SetNtpServerUnknownKey(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+	tApp.serverInstance.ntpConfigurator.KeysPath = "/non/existing/ntp.keys"
+
+	serverList := v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "1.2.3.4", KeyId: 42}}}
+
+	_, err := tApp.serverInstance.SetNtpServer(context.Background(), &serverList)
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Did not get expected result. got: %q", err)
+}
+
+func Test_applyError(t *testing.T) {
+	err := applyError(&ntpcf.ApplyError{Step: ntpcf.StepStart, Err: errors.New("exit status 1"), RolledBack: true})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.Aborted, st.Code())
+	assert.Contains(t, st.Message(), "Failed to Set")
+	assert.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.True(t, ok, "Did not get expected result. Wanted: ErrorInfo, got: %T", st.Details()[0])
+	assert.Equal(t, ntpcf.StepStart, info.Metadata["step"])
+	assert.Equal(t, "true", info.Metadata["rolledBack"])
+	assert.Equal(t, codes.Unknown, status.Code(applyError(errors.New("disk full"))))
+}
+
+func Test_SetServingInvalid(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+
+	_, err := tApp.serverInstance.SetServing(context.Background(), &v1.ServingConfig{Rules: []*v1.RestrictRule{{Cidr: "10.0.0.0/8"}}})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Did not get expected result. got: %q", err)
+}
+
+func Test_SetOrphanModeInvalid(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+
+	_, err := tApp.serverInstance.SetOrphanMode(context.Background(), &v1.OrphanConfig{Stratum: 16})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Did not get expected result. got: %q", err)
+}
+
+func Test_SetRefclocksInvalid(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+
+	_, err := tApp.serverInstance.SetRefclocks(context.Background(), &v1.Refclocks{Refclocks: []*v1.RefclockEntry{{Driver: v1.RefclockDriver_PPS, Baud: 9600}}})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "Did not get expected result. got: %q", err)
+}
+
+func Test_SetServingNotSupported(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+	tApp.serverInstance.ntpConfigurator.Backend = ntpcf.NewChronyBackend(executor.OsExecutor{}, servicemanagertest.New())
+
+	_, err := tApp.serverInstance.SetServing(context.Background(), &v1.ServingConfig{Enabled: true})
+
+	assert.Equal(t, codes.Unimplemented, status.Code(err), "Did not get expected result. got: %q", err)
+}
+
+func Test_saveLastSettingTimeFailure(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+	// a directory can not be replaced by the record file
+	tApp.serverInstance.ntpConfigurator.ConfigPath = t.TempDir()
+
+	err := tApp.serverInstance.saveLastSettingTime()
+
+	assert.Error(t, err, "Did not get expected result. The failed write must be returned")
+}
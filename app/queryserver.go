@@ -0,0 +1,74 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/sntp"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// QueryServer sends NTP requests to the server given by the client and returns the measured samples.
+func (n ntpServer) QueryServer(ctx context.Context, request *v1.QueryServerRequest) (*v1.QueryServerResult, error) {
+	log.Println("QueryServer() enter, address:", request.GetAddress())
+	defer log.Println("QueryServer() leave")
+	if request.GetAddress() == "" {
+		return nil, status.New(codes.InvalidArgument, "address is empty").Err()
+	}
+	options := sntp.Options{
+		Samples: int(request.GetSamples()),
+		Version: int(request.GetVersion()),
+		Timeout: time.Duration(request.GetTimeoutMs()) * time.Millisecond,
+	}
+	result, err := sntp.Query(ctx, request.GetAddress(), options)
+	if errors.Is(err, sntp.ErrInvalidOptions) {
+		return nil, status.New(codes.InvalidArgument, err.Error()).Err()
+	}
+	if err != nil {
+		log.Println("QueryServer() Failed to query: ", err.Error())
+		return nil, status.New(codes.Unavailable, err.Error()).Err()
+	}
+	response := &v1.QueryServerResult{Address: result.Address}
+	for _, sample := range result.Samples {
+		response.Samples = append(response.Samples, toNtpSample(sample))
+	}
+	if result.Best != nil {
+		response.Best = toNtpSample(*result.Best)
+	}
+	return response, status.New(codes.OK, "fine").Err()
+}
+
+func toNtpSample(sample sntp.Sample) *v1.NtpSample {
+	ntpSample := &v1.NtpSample{
+		Offset:         milliseconds(sample.Offset),
+		Delay:          milliseconds(sample.Delay),
+		Stratum:        int32(sample.Stratum),
+		ReferenceID:    sample.ReferenceID,
+		LeapIndicator:  int32(sample.Leap),
+		RootDelay:      milliseconds(sample.RootDelay),
+		RootDispersion: milliseconds(sample.RootDispersion),
+		KissCode:       sample.KissCode,
+		Version:        int32(sample.Version),
+		Poll:           int32(sample.Poll),
+		Precision:      int32(sample.Precision),
+	}
+	if sample.Err != nil {
+		ntpSample.Error = sample.Err.Error()
+	}
+	return ntpSample
+}
+
+func milliseconds(d time.Duration) float32 {
+	return float32(d.Seconds() * 1000)
+}
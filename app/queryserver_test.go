@@ -0,0 +1,64 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/serviceconfig"
+	"ntpservice/internal/sntp/sntptest"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_QueryServer(t *testing.T) {
+	server, err := sntptest.NewServerWithResponse(sntptest.DefaultResponse(), -100*time.Millisecond)
+	assert.NoError(t, err)
+	defer server.Close()
+	tApp := CreateServiceApp(serviceconfig.Default())
+
+	result, err := tApp.serverInstance.QueryServer(context.Background(), &v1.QueryServerRequest{Address: server.Addr, TimeoutMs: 1000})
+
+	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
+	assert.Equal(t, server.Addr, result.Address)
+	assert.Len(t, result.Samples, 1)
+	assert.InDelta(t, -100, result.Best.Offset, 20)
+	assert.Equal(t, int32(1), result.Best.Stratum)
+	assert.Equal(t, "GPS", result.Best.ReferenceID)
+	assert.Empty(t, result.Best.Error)
+}
+
+func Test_QueryServerKissOfDeath(t *testing.T) {
+	response := sntptest.DefaultResponse()
+	response.Stratum = 0
+	response.ReferenceID = [4]byte{'D', 'E', 'N', 'Y'}
+	server, err := sntptest.NewServerWithResponse(response, 0)
+	assert.NoError(t, err)
+	defer server.Close()
+	tApp := CreateServiceApp(serviceconfig.Default())
+
+	result, err := tApp.serverInstance.QueryServer(context.Background(), &v1.QueryServerRequest{Address: server.Addr, Samples: 2})
+
+	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
+	assert.Nil(t, result.Best)
+	assert.Equal(t, "DENY", result.Samples[0].KissCode)
+	assert.NotEmpty(t, result.Samples[0].Error)
+}
+
+func Test_QueryServerInvalidArgument(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+
+	for _, request := range []*v1.QueryServerRequest{{}, {Address: "127.0.0.1", Samples: 9}, {Address: "127.0.0.1", Version: 7}} {
+		_, err := tApp.serverInstance.QueryServer(context.Background(), request)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "Did not get expected result for %v. got: %q", request, err)
+	}
+}
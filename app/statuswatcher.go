@@ -0,0 +1,184 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const statusPollInterval = 2 * time.Second
+const defaultOffsetDeltaMs = 5
+const systemPeerSelection = "sys.peer"
+
+type statusSource interface {
+	GetSyncStatus(ctx context.Context) (*v1.Status, error)
+}
+
+// statusWatcher polls the status while there are subscribers and hands every polled status to all of them.
+type statusWatcher struct {
+	source      statusSource
+	interval    time.Duration
+	mutex       sync.Mutex
+	subscribers map[chan *v1.Status]struct{}
+	latest      *v1.Status
+	stop        chan struct{}
+}
+
+func newStatusWatcher(source statusSource) *statusWatcher {
+	return &statusWatcher{
+		source:      source,
+		interval:    statusPollInterval,
+		subscribers: make(map[chan *v1.Status]struct{}),
+	}
+}
+
+// subscribe returns a channel holding the latest polled status and a function to cancel the subscription.
+// The poller is started by the first subscriber and stopped when the last one cancels.
+func (w *statusWatcher) subscribe() (<-chan *v1.Status, func()) {
+	updates := make(chan *v1.Status, 1)
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.subscribers[updates] = struct{}{}
+	if w.latest != nil {
+		updates <- w.latest
+	}
+	if w.stop == nil {
+		w.stop = make(chan struct{})
+		go w.poll(w.stop)
+	}
+	return updates, func() {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+		delete(w.subscribers, updates)
+		if len(w.subscribers) == 0 && w.stop != nil {
+			close(w.stop)
+			w.stop = nil
+			w.latest = nil
+		}
+	}
+}
+
+func (w *statusWatcher) poll(stop chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		current, err := w.source.GetSyncStatus(context.Background())
+		if err != nil {
+			log.Println("Status poll failed:", err.Error())
+		} else {
+			w.publish(stop, current)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publish replaces the status not yet taken by a subscriber with the current one.
+func (w *statusWatcher) publish(stop chan struct{}, current *v1.Status) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.stop != stop {
+		return
+	}
+	w.latest = current
+	for updates := range w.subscribers {
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- current
+	}
+}
+
+// statusChanged reports whether current differs meaningfully from the previously sent status.
+func statusChanged(previous *v1.Status, current *v1.Status, offsetDeltaMs float32) bool {
+	if previous == nil {
+		return true
+	}
+	if previous.GetIsNtpServiceRunning() != current.GetIsNtpServiceRunning() || previous.GetIsSynced() != current.GetIsSynced() {
+		return true
+	}
+	if previous.GetOrphan() != current.GetOrphan() {
+		return true
+	}
+	if previous.GetLeap().GetExpiresSoon() != current.GetLeap().GetExpiresSoon() ||
+		previous.GetLeap().GetPendingLeap() != current.GetLeap().GetPendingLeap() {
+		return true
+	}
+	previousPeer, currentPeer := systemPeer(previous), systemPeer(current)
+	if previousPeer.GetAddress() != currentPeer.GetAddress() {
+		return true
+	}
+	if math.Abs(float64(currentPeer.GetOffset()-previousPeer.GetOffset())) >= float64(offsetDeltaMs) {
+		return true
+	}
+	previousReach := make(map[uint32]string)
+	for _, peer := range previous.GetPeerDetails() {
+		previousReach[peer.GetAssociationId()] = peer.GetReach()
+	}
+	if len(previousReach) != len(current.GetPeerDetails()) {
+		return true
+	}
+	for _, peer := range current.GetPeerDetails() {
+		if reach, ok := previousReach[peer.GetAssociationId()]; !ok || reach != peer.GetReach() {
+			return true
+		}
+	}
+	return false
+}
+
+func systemPeer(s *v1.Status) *v1.PeerDetails {
+	for _, peer := range s.GetPeerDetails() {
+		if peer.GetSelection() == systemPeerSelection {
+			return peer
+		}
+	}
+	return nil
+}
+
+// WatchStatus sends the current status and then every meaningful change of it until the client cancels.
+func (n ntpServer) WatchStatus(request *v1.WatchStatusRequest, stream grpc.ServerStreamingServer[v1.Status]) error {
+	log.Println("WatchStatus() enter")
+	defer log.Println("WatchStatus() leave")
+	offsetDeltaMs := request.GetOffsetDeltaMs()
+	if offsetDeltaMs < 0 {
+		return status.New(codes.InvalidArgument, "offsetDeltaMs must not be negative").Err()
+	}
+	if offsetDeltaMs == 0 {
+		offsetDeltaMs = defaultOffsetDeltaMs
+	}
+	updates, cancel := n.statusWatcher.subscribe()
+	defer cancel()
+	var sent *v1.Status
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case current := <-updates:
+			if !statusChanged(sent, current, offsetDeltaMs) {
+				continue
+			}
+			if err := stream.Send(current); err != nil {
+				return err
+			}
+			sent = current
+		}
+	}
+}
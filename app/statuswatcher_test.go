@@ -0,0 +1,168 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/serviceconfig"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeStatusSource struct {
+	mutex   sync.Mutex
+	current *v1.Status
+	polls   int
+}
+
+func (f *fakeStatusSource) GetSyncStatus(ctx context.Context) (*v1.Status, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.polls++
+	return f.current, nil
+}
+
+func (f *fakeStatusSource) set(s *v1.Status) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.current = s
+}
+
+func (f *fakeStatusSource) pollCount() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.polls
+}
+
+type fakeStatusStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *v1.Status
+}
+
+func (f *fakeStatusStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeStatusStream) Send(s *v1.Status) error {
+	f.sent <- s
+	return nil
+}
+
+func tWatchedStatus(synced bool, reach string, offset float32) *v1.Status {
+	return &v1.Status{
+		IsNtpServiceRunning: true,
+		IsSynced:            synced,
+		PeerDetails: []*v1.PeerDetails{
+			{AssociationId: 1, Address: "192.168.1.1", Selection: "sys.peer", Reach: reach, Offset: offset},
+			{AssociationId: 2, Address: "192.168.1.2", Selection: "candidate", Reach: "377", Offset: 3},
+		},
+	}
+}
+
+func Test_statusChanged(t *testing.T) {
+	previous := tWatchedStatus(true, "377", 1)
+	otherSystemPeer := tWatchedStatus(true, "377", 1)
+	otherSystemPeer.PeerDetails[0].Selection = "candidate"
+	otherSystemPeer.PeerDetails[1].Selection = "sys.peer"
+	stopped := tWatchedStatus(true, "377", 1)
+	stopped.IsNtpServiceRunning = false
+	leapExpiring := tWatchedStatus(true, "377", 1)
+	leapExpiring.Leap = &v1.LeapStatus{ExpiresSoon: true}
+
+	tests := []struct {
+		name    string
+		current *v1.Status
+		want    bool
+	}{
+		{"unchanged", tWatchedStatus(true, "377", 1), false},
+		{"offset within delta", tWatchedStatus(true, "377", 4), false},
+		{"offset beyond delta", tWatchedStatus(true, "377", -5), true},
+		{"synced", tWatchedStatus(false, "377", 1), true},
+		{"running", stopped, true},
+		{"reach", tWatchedStatus(true, "376", 1), true},
+		{"system peer", otherSystemPeer, true},
+		{"leap file expiring", leapExpiring, true},
+		{"peer removed", &v1.Status{IsNtpServiceRunning: true, IsSynced: true, PeerDetails: previous.PeerDetails[:1]}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, statusChanged(previous, tt.current, defaultOffsetDeltaMs))
+		})
+	}
+	assert.True(t, statusChanged(nil, previous, defaultOffsetDeltaMs), "Did not get expected result. The first status must always be sent")
+}
+
+func Test_statusWatcherSharedPoller(t *testing.T) {
+	source := &fakeStatusSource{current: tWatchedStatus(true, "377", 1)}
+	watcher := newStatusWatcher(source)
+	watcher.interval = 10 * time.Millisecond
+
+	first, cancelFirst := watcher.subscribe()
+	second, cancelSecond := watcher.subscribe()
+	assert.Equal(t, source.current, <-first)
+	assert.Equal(t, source.current, <-second)
+
+	changed := tWatchedStatus(false, "377", 1)
+	source.set(changed)
+	assert.Eventually(t, func() bool {
+		select {
+		case s := <-second:
+			return s == changed
+		default:
+			return false
+		}
+	}, time.Second, 5*time.Millisecond)
+
+	cancelFirst()
+	cancelSecond()
+	watcher.mutex.Lock()
+	assert.Nil(t, watcher.stop, "Did not get expected result. The poller must stop without subscribers")
+	watcher.mutex.Unlock()
+	polls := source.pollCount()
+	time.Sleep(50 * time.Millisecond)
+	assert.LessOrEqual(t, source.pollCount(), polls+1)
+}
+
+func Test_WatchStatus(t *testing.T) {
+	source := &fakeStatusSource{current: tWatchedStatus(true, "377", 1)}
+	server := ntpServer{statusWatcher: newStatusWatcher(source)}
+	server.statusWatcher.interval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeStatusStream{ctx: ctx, sent: make(chan *v1.Status, 10)}
+	done := make(chan error)
+
+	go func() {
+		done <- server.WatchStatus(&v1.WatchStatusRequest{}, stream)
+	}()
+
+	assert.True(t, (<-stream.sent).IsSynced)
+	source.set(tWatchedStatus(true, "377", 2))
+	time.Sleep(50 * time.Millisecond)
+	source.set(tWatchedStatus(false, "377", 2))
+	assert.False(t, (<-stream.sent).IsSynced)
+	assert.Empty(t, stream.sent, "Did not get expected result. Unchanged status must not be sent")
+	cancel()
+	assert.Nil(t, <-done)
+}
+
+func Test_WatchStatusInvalidArgument(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+	stream := &fakeStatusStream{ctx: context.Background(), sent: make(chan *v1.Status, 1)}
+
+	err := tApp.serverInstance.WatchStatus(&v1.WatchStatusRequest{OffsetDeltaMs: -1}, stream)
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
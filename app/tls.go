@@ -0,0 +1,72 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"errors"
+	"net"
+
+	"ntpservice/internal/serviceconfig"
+	"ntpservice/internal/tlsreload"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// ErrTLSRequired is returned when the TCP listener would accept plaintext connections from other hosts.
+var ErrTLSRequired = errors.New("tls is required for a tcp listener on a non-loopback address")
+
+// tlsSettings is the TLS configuration of the TCP listener.
+type tlsSettings struct {
+	options tlsreload.Options
+	require bool
+}
+
+// newTLSSettings returns the TLS settings of the service configuration.
+func newTLSSettings(config serviceconfig.TLS) tlsSettings {
+	return tlsSettings{
+		options: tlsreload.Options{
+			CertFile:     config.Cert,
+			KeyFile:      config.Key,
+			ClientCAFile: config.ClientCA,
+		},
+		require: config.Require,
+	}
+}
+
+// enabled reports whether a certificate is configured.
+func (s tlsSettings) enabled() bool {
+	return s.options.CertFile != "" || s.options.KeyFile != ""
+}
+
+// tcpCredentials returns the credentials of a TCP listener on the address, nil for plaintext.
+func tcpCredentials(address string, settings tlsSettings) (credentials.TransportCredentials, error) {
+	if !settings.enabled() {
+		if settings.require && !isLoopback(address) {
+			return nil, ErrTLSRequired
+		}
+		return nil, nil
+	}
+	reloader, err := tlsreload.New(settings.options)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(reloader.Config()), nil
+}
+
+// isLoopback reports whether the listener address only accepts connections of the local host,
+// an empty host listens on all interfaces.
+func isLoopback(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	"ntpservice/internal/serviceconfig"
+	"ntpservice/internal/tlsreload"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_isLoopback(t *testing.T) {
+	for _, address := range []string{"localhost:50006", "127.0.0.1:50006", "[::1]:50006"} {
+		assert.True(t, isLoopback(address), "Did not get expected result for %s", address)
+	}
+	for _, address := range []string{":50006", "0.0.0.0:50006", "192.168.1.10:50006", "[::]:50006", "device.local:50006", "invalid"} {
+		assert.False(t, isLoopback(address), "Did not get expected result for %s", address)
+	}
+}
+
+func Test_tcpCredentials(t *testing.T) {
+	creds, err := tcpCredentials("0.0.0.0:50006", tlsSettings{})
+	assert.NoError(t, err)
+	assert.Nil(t, creds)
+
+	creds, err = tcpCredentials("localhost:50006", tlsSettings{require: true})
+	assert.NoError(t, err)
+	assert.Nil(t, creds)
+
+	_, err = tcpCredentials("0.0.0.0:50006", tlsSettings{require: true})
+	assert.ErrorIs(t, err, ErrTLSRequired)
+
+	missing := filepath.Join(t.TempDir(), "missing.pem")
+	_, err = tcpCredentials("0.0.0.0:50006", tlsSettings{options: tlsreload.Options{CertFile: missing, KeyFile: missing}, require: true})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTLSRequired)
+}
+
+func Test_StartGRPC_TLSRequired(t *testing.T) {
+	tApp := CreateServiceApp(serviceconfig.Default())
+	tApp.tls = tlsSettings{require: true}
+
+	err := tApp.StartGRPC([]string{"ntpservice", "tcp", "0.0.0.0:0"})
+
+	assert.ErrorContains(t, err, ErrTLSRequired.Error())
+}
+
+func Test_newTLSSettings(t *testing.T) {
+	settings := newTLSSettings(serviceconfig.TLS{Cert: "/etc/ntpservice/server.pem", Key: "/etc/ntpservice/server.key",
+		ClientCA: "/etc/ntpservice/client-ca.pem", Require: true})
+
+	assert.Equal(t, tlsSettings{options: tlsreload.Options{CertFile: "/etc/ntpservice/server.pem",
+		KeyFile: "/etc/ntpservice/server.key", ClientCAFile: "/etc/ntpservice/client-ca.pem"}, require: true}, settings)
+	assert.True(t, settings.enabled())
+}
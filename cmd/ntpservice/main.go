@@ -9,35 +9,48 @@ package main
 import (
 	"log"
 	ntpservice "ntpservice/app"
+	"ntpservice/internal/serviceconfig"
 	"ntpservice/migration"
 	"os"
 )
 
 func main() {
-	runMigrations()
+	config, err := serviceconfig.FromEnv()
+	if err != nil {
+		log.Printf("Cannot load the service configuration! : %s \n", err)
+		os.Exit(1)
+	}
+	runMigrations(config)
 
-	ntpServiceApp := ntpservice.CreateServiceApp()
+	ntpServiceApp := ntpservice.CreateServiceApp(config)
 	ntpServiceApp.StartApp()
+	if address := config.Metrics.Address; address != "" {
+		go func() {
+			if err := ntpServiceApp.StartMetrics(address); err != nil {
+				log.Printf("Cannot start metrics server! : %s \n", err)
+			}
+		}()
+	}
 	if err := ntpServiceApp.StartGRPC(os.Args); err != nil {
 		log.Printf("Cannot start gRPC server! : %s \n", err)
 		return
 	}
 }
 
-func runMigrations() {
-	runLastConfiguredTimeOfNTPClientMigration()
-	runNtpClassicToNtpSecMigration()
+func runMigrations(config *serviceconfig.Config) {
+	runLastConfiguredTimeOfNTPClientMigration(config)
+	runNtpClassicToNtpSecMigration(config)
 }
 
-func runNtpClassicToNtpSecMigration() {
-	ntpClassicToNTPSecMigration := migration.NewNTPClassicToNTPSecMigration()
+func runNtpClassicToNtpSecMigration(config *serviceconfig.Config) {
+	ntpClassicToNTPSecMigration := migration.NewNTPClassicToNTPSecMigration(config)
 	if err := ntpClassicToNTPSecMigration.Start(); err != nil {
 		log.Printf("Migration failed, %s", err.Error())
 		os.Exit(1)
 	}
 }
 
-func runLastConfiguredTimeOfNTPClientMigration() {
-	lastConfTimeMigration := migration.NewLastConfigurationTimeOfNTPClientMigration()
+func runLastConfiguredTimeOfNTPClientMigration(config *serviceconfig.Config) {
+	lastConfTimeMigration := migration.NewLastConfigurationTimeOfNTPClientMigration(config.Paths)
 	lastConfTimeMigration.Start()
 }
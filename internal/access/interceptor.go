@@ -0,0 +1,89 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package access
+
+import (
+	"context"
+	"log"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// readMethods are the RPCs which do not change the device, every other RPC needs write access.
+var readMethods = map[string]bool{
+	v1.NtpService_GetNtpServer_FullMethodName:       true,
+	v1.NtpService_GetStatus_FullMethodName:          true,
+	v1.NtpService_WatchStatus_FullMethodName:        true,
+	v1.NtpService_GetNtsCaBundle_FullMethodName:     true,
+	v1.NtpService_ListKeys_FullMethodName:           true,
+	v1.NtpService_QueryServer_FullMethodName:        true,
+	v1.NtpService_GetServing_FullMethodName:         true,
+	v1.NtpService_GetOrphanMode_FullMethodName:      true,
+	v1.NtpService_GetRefclocks_FullMethodName:       true,
+	v1.NtpService_ListConfigHistory_FullMethodName:  true,
+	v1.NtpService_DiffConfig_FullMethodName:         true,
+	v1.NtpService_GetLeapSecondsFile_FullMethodName: true,
+	healthpb.Health_Check_FullMethodName:            true,
+	healthpb.Health_List_FullMethodName:             true,
+	healthpb.Health_Watch_FullMethodName:            true,
+}
+
+// RequiredAccess returns the access needed to call the method.
+func RequiredAccess(fullMethod string) Access {
+	if readMethods[fullMethod] {
+		return AccessRead
+	}
+	return AccessWrite
+}
+
+// UnaryServerInterceptor refuses the unary calls the policy does not allow with PermissionDenied.
+func UnaryServerInterceptor(policy *Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authorize(ctx, policy, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor refuses the streams the policy does not allow with PermissionDenied.
+func StreamServerInterceptor(policy *Policy) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(stream.Context(), policy, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// authorize checks the identity of a unix socket connection against the policy. Without policy and for connections
+// without peer credentials, e.g. the TCP listener, every call is allowed.
+func authorize(ctx context.Context, policy *Policy, fullMethod string) error {
+	if policy == nil {
+		return nil
+	}
+	caller, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := caller.AuthInfo.(AuthInfo)
+	if !ok {
+		return nil
+	}
+	required := RequiredAccess(fullMethod)
+	if policy.Allows(info.Identity, required) {
+		return nil
+	}
+	log.Println("Permission denied:", fullMethod, "needs", string(required), "access, called by", info.Identity.String())
+	return status.New(codes.PermissionDenied, "permission denied: "+fullMethod+" needs "+string(required)+" access").Err()
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package access
+
+import (
+	"context"
+	"testing"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type tStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s tStream) Context() context.Context {
+	return s.ctx
+}
+
+func tContext(identity Identity) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: AuthInfo{Identity: identity}})
+}
+
+func tCall(policy *Policy, ctx context.Context, method string) error {
+	_, err := UnaryServerInterceptor(policy)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(context.Context, any) (any, error) { return nil, nil })
+	return err
+}
+
+func Test_RequiredAccess(t *testing.T) {
+	assert.Equal(t, AccessRead, RequiredAccess(v1.NtpService_GetNtpServer_FullMethodName))
+	assert.Equal(t, AccessRead, RequiredAccess(v1.NtpService_GetStatus_FullMethodName))
+	assert.Equal(t, AccessWrite, RequiredAccess(v1.NtpService_SetNtpServer_FullMethodName))
+	assert.Equal(t, AccessWrite, RequiredAccess("/unknown.Service/Method"), "Did not get expected result. Unknown methods must need write access")
+}
+
+func Test_UnaryServerInterceptor(t *testing.T) {
+	policy, err := ParsePolicy([]byte(tPolicy))
+	assert.NoError(t, err)
+	reader := tContext(Identity{Uid: 1100, User: "monitoring"})
+
+	assert.NoError(t, tCall(policy, reader, v1.NtpService_GetStatus_FullMethodName))
+	err = tCall(policy, reader, v1.NtpService_SetNtpServer_FullMethodName)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	err = tCall(policy, tContext(Identity{Uid: 1400}), v1.NtpService_GetNtpServer_FullMethodName)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func Test_UnaryServerInterceptor_Unrestricted(t *testing.T) {
+	policy, err := ParsePolicy([]byte(tPolicy))
+	assert.NoError(t, err)
+
+	assert.NoError(t, tCall(nil, tContext(Identity{Uid: 1400}), v1.NtpService_SetNtpServer_FullMethodName),
+		"Did not get expected result. Without policy every call must be allowed")
+	assert.NoError(t, tCall(policy, context.Background(), v1.NtpService_SetNtpServer_FullMethodName),
+		"Did not get expected result. Calls without peer credentials must be allowed")
+}
+
+func Test_StreamServerInterceptor(t *testing.T) {
+	policy, err := ParsePolicy([]byte(tPolicy))
+	assert.NoError(t, err)
+	interceptor := StreamServerInterceptor(policy)
+	handler := func(any, grpc.ServerStream) error { return nil }
+	info := &grpc.StreamServerInfo{FullMethod: v1.NtpService_WatchStatus_FullMethodName}
+
+	assert.NoError(t, interceptor(nil, tStream{ctx: tContext(Identity{Uid: 1200})}, info, handler))
+	err = interceptor(nil, tStream{ctx: tContext(Identity{Uid: 1400})}, info, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
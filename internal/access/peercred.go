@@ -0,0 +1,115 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/user"
+	"strconv"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// authType is the security protocol of the unix socket credentials.
+const authType = "peercred"
+
+// ErrClientHandshake is returned by the client side of the credentials, only servers read the peer credentials.
+var ErrClientHandshake = errors.New("peer credentials are only read by the server")
+
+// Identity is the process connected to the unix socket, the names are resolved from the user database.
+type Identity struct {
+	Uid      uint32
+	Gid      uint32
+	Pid      int32
+	User     string   // name of the user, empty if the uid has no entry
+	Group    string   // name of the primary group, empty if the gid has no entry
+	GroupIds []uint32 // ids of the primary and supplementary groups
+	Groups   []string // names of the primary and supplementary groups
+}
+
+// String formats the identity for the log, e.g. `uid=1000(alice) gid=999(docker) pid=4242`.
+func (i Identity) String() string {
+	return fmt.Sprintf("uid=%d(%s) gid=%d(%s) pid=%d", i.Uid, i.User, i.Gid, i.Group, i.Pid)
+}
+
+// AuthInfo carries the identity of the peer of a unix socket connection.
+type AuthInfo struct {
+	credentials.CommonAuthInfo
+	Identity Identity
+}
+
+// AuthType returns the security protocol of the credentials.
+func (AuthInfo) AuthType() string {
+	return authType
+}
+
+// peerCredentials are the server credentials of a unix socket, every connection is tagged with its peer identity.
+type peerCredentials struct{}
+
+// Credentials returns the transport credentials which read SO_PEERCRED on every accepted unix socket connection.
+func Credentials() credentials.TransportCredentials {
+	return peerCredentials{}
+}
+
+func (peerCredentials) ClientHandshake(context.Context, string, net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, ErrClientHandshake
+}
+
+func (peerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	uid, gid, pid, err := readPeerCredentials(conn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading the peer credentials: %w", err)
+	}
+	return conn, AuthInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity},
+		Identity:       lookupIdentity(uid, gid, pid),
+	}, nil
+}
+
+func (peerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: authType}
+}
+
+func (c peerCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (peerCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+// lookupIdentity resolves the user name and the names of the primary and supplementary groups of the user.
+func lookupIdentity(uid uint32, gid uint32, pid int32) Identity {
+	identity := Identity{Uid: uid, Gid: gid, Pid: pid}
+	groupIds := []string{strconv.FormatUint(uint64(gid), 10)}
+	if account, err := user.LookupId(strconv.FormatUint(uint64(uid), 10)); err == nil {
+		identity.User = account.Username
+		if ids, err := account.GroupIds(); err == nil {
+			groupIds = append(groupIds, ids...)
+		}
+	}
+	seen := make(map[string]bool)
+	for i, id := range groupIds {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if groupId, err := strconv.ParseUint(id, 10, 32); err == nil {
+			identity.GroupIds = append(identity.GroupIds, uint32(groupId))
+		}
+		if group, err := user.LookupGroupId(id); err == nil {
+			identity.Groups = append(identity.Groups, group.Name)
+			if i == 0 {
+				identity.Group = group.Name
+			}
+		}
+	}
+	return identity
+}
@@ -0,0 +1,38 @@
+//go:build linux
+
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package access
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// readPeerCredentials returns the uid, gid and pid of the process connected to the unix socket.
+func readPeerCredentials(conn net.Conn) (uint32, uint32, int32, error) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, 0, 0, errors.New("connection is not a unix socket")
+	}
+	raw, err := unixConn.SyscallConn()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	var ucred *syscall.Ucred
+	var ucredErr error
+	if err := raw.Control(func(fd uintptr) {
+		ucred, ucredErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return 0, 0, 0, err
+	}
+	if ucredErr != nil {
+		return 0, 0, 0, ucredErr
+	}
+	return ucred.Uid, ucred.Gid, ucred.Pid, nil
+}
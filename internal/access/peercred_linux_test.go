@@ -0,0 +1,49 @@
+//go:build linux
+
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package access
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ServerHandshake(t *testing.T) {
+	lis, err := net.Listen("unix", filepath.Join(t.TempDir(), "ntp.socket"))
+	assert.NoError(t, err)
+	defer lis.Close()
+	client, err := net.Dial("unix", lis.Addr().String())
+	assert.NoError(t, err)
+	defer client.Close()
+	conn, err := lis.Accept()
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	_, info, err := Credentials().ServerHandshake(conn)
+
+	assert.NoError(t, err)
+	identity := info.(AuthInfo).Identity
+	assert.Equal(t, uint32(os.Getuid()), identity.Uid)
+	assert.Equal(t, uint32(os.Getgid()), identity.Gid)
+	assert.Equal(t, int32(os.Getpid()), identity.Pid)
+	assert.Contains(t, identity.GroupIds, uint32(os.Getgid()))
+}
+
+func Test_ServerHandshake_NotUnix(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	_, _, err := Credentials().ServerHandshake(server)
+
+	assert.Error(t, err)
+}
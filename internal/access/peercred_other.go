@@ -0,0 +1,19 @@
+//go:build !linux
+
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package access
+
+import (
+	"errors"
+	"net"
+)
+
+// readPeerCredentials fails, SO_PEERCRED is only available on linux.
+func readPeerCredentials(conn net.Conn) (uint32, uint32, int32, error) {
+	return 0, 0, 0, errors.New("peer credentials are only supported on linux")
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+// Package access authorizes the RPCs of the unix socket with the peer credentials of the calling process.
+package access
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyVersion is the supported version of the policy file.
+const PolicyVersion = 1
+
+// Access is the level of access a rule grants.
+type Access string
+
+const (
+	// AccessRead allows the RPCs which do not change the device, e.g. GetNtpServer and GetStatus.
+	AccessRead Access = "read"
+	// AccessWrite allows every RPC, e.g. SetNtpServer.
+	AccessWrite Access = "write"
+)
+
+// ErrInvalidPolicy is returned when the policy file can not be parsed or contains invalid rules.
+var ErrInvalidPolicy = errors.New("invalid access policy")
+
+// Policy maps users and groups to their access, root always has write access.
+//
+//	version: 1
+//	rules:
+//	  - groups: [ntpadmin]
+//	    access: write
+//	  - users: [monitoring]
+//	    groups: [docker]
+//	    access: read
+type Policy struct {
+	Version int    `yaml:"version"`
+	Rules   []Rule `yaml:"rules"`
+}
+
+// Rule grants the access to the users and the members of the groups, given by name or numeric id.
+type Rule struct {
+	Users  []string `yaml:"users"`
+	Groups []string `yaml:"groups"`
+	Access Access   `yaml:"access"`
+}
+
+// LoadPolicy reads the policy file, nil if it does not exist.
+func LoadPolicy(path string) (*Policy, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParsePolicy(content)
+}
+
+// ParsePolicy parses and validates the policy, unknown keys are rejected.
+func ParsePolicy(content []byte) (*Policy, error) {
+	policy := &Policy{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPolicy, err)
+	}
+	if policy.Version != PolicyVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d, expected %d", ErrInvalidPolicy, policy.Version, PolicyVersion)
+	}
+	for i, rule := range policy.Rules {
+		if rule.Access != AccessRead && rule.Access != AccessWrite {
+			return nil, fmt.Errorf("%w: rule %d has access %q, expected %s or %s", ErrInvalidPolicy, i+1, rule.Access, AccessRead, AccessWrite)
+		}
+		if len(rule.Users) == 0 && len(rule.Groups) == 0 {
+			return nil, fmt.Errorf("%w: rule %d has neither users nor groups", ErrInvalidPolicy, i+1)
+		}
+	}
+	return policy, nil
+}
+
+// Allows reports whether the identity may call an RPC which needs the access.
+func (p *Policy) Allows(identity Identity, access Access) bool {
+	if identity.Uid == 0 {
+		return true
+	}
+	for _, rule := range p.Rules {
+		if access == AccessWrite && rule.Access != AccessWrite {
+			continue
+		}
+		if rule.matches(identity) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matches(identity Identity) bool {
+	for _, name := range r.Users {
+		if name == identity.User || name == strconv.FormatUint(uint64(identity.Uid), 10) {
+			return true
+		}
+	}
+	for _, name := range r.Groups {
+		if slices.Contains(identity.Groups, name) {
+			return true
+		}
+		if id, err := strconv.ParseUint(name, 10, 32); err == nil && slices.Contains(identity.GroupIds, uint32(id)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package access
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tPolicy = `version: 1
+rules:
+  - groups: [ntpadmin]
+    access: write
+  - users: [monitoring, "1200"]
+    groups: ["999"]
+    access: read
+`
+
+func Test_ParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy([]byte(tPolicy))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Policy{Version: 1, Rules: []Rule{
+		{Groups: []string{"ntpadmin"}, Access: AccessWrite},
+		{Users: []string{"monitoring", "1200"}, Groups: []string{"999"}, Access: AccessRead},
+	}}, policy)
+}
+
+func Test_ParsePolicy_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"version":      "version: 2\nrules: []\n",
+		"access":       "version: 1\nrules:\n  - users: [alice]\n    access: admin\n",
+		"no principal": "version: 1\nrules:\n  - access: read\n",
+		"unknown key":  "version: 1\nrules:\n  - user: [alice]\n    access: read\n",
+		"syntax":       "version: [1\n",
+	} {
+		_, err := ParsePolicy([]byte(content))
+		assert.ErrorIs(t, err, ErrInvalidPolicy, "Did not get expected result. The %s policy must be refused", name)
+	}
+}
+
+func Test_LoadPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.yaml")
+	policy, err := LoadPolicy(path)
+	assert.NoError(t, err)
+	assert.Nil(t, policy, "Did not get expected result. A missing policy must not restrict the access")
+
+	assert.NoError(t, os.WriteFile(path, []byte(tPolicy), 0644))
+	policy, err = LoadPolicy(path)
+	assert.NoError(t, err)
+	assert.Len(t, policy.Rules, 2)
+}
+
+func Test_Allows(t *testing.T) {
+	policy, err := ParsePolicy([]byte(tPolicy))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		identity Identity
+		read     bool
+		write    bool
+	}{
+		{"root", Identity{Uid: 0}, true, true},
+		{"admin group", Identity{Uid: 1000, User: "alice", Groups: []string{"alice", "ntpadmin"}, GroupIds: []uint32{1000, 1001}}, true, true},
+		{"user name", Identity{Uid: 1100, User: "monitoring"}, true, false},
+		{"user id", Identity{Uid: 1200}, true, false},
+		{"group id", Identity{Uid: 1300, GroupIds: []uint32{1300, 999}}, true, false},
+		{"unknown", Identity{Uid: 1400, User: "bob", Groups: []string{"bob"}, GroupIds: []uint32{1400}}, false, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.read, policy.Allows(test.identity, AccessRead), "Did not get expected result for read access of %s", test.name)
+		assert.Equal(t, test.write, policy.Allows(test.identity, AccessWrite), "Did not get expected result for write access of %s", test.name)
+	}
+}
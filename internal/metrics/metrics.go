@@ -0,0 +1,195 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+// Package metrics exports the time synchronization status and the gRPC requests of the service as Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "ntpservice"
+
+// scrapeTimeout bounds the status read by a scrape, ntpd is queried with a shorter control timeout.
+const scrapeTimeout = 10 * time.Second
+
+// millisecondsPerSecond converts the milliseconds of the status into the seconds Prometheus uses.
+const millisecondsPerSecond = 1000
+
+// StatusSource reads the synchronization status, it is read on every scrape.
+type StatusSource interface {
+	GetSyncStatus(ctx context.Context) (*v1.Status, error)
+}
+
+// Metrics holds the registry of the service, the status collector and the request metrics of the gRPC server.
+type Metrics struct {
+	registry *prometheus.Registry
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+// New returns the metrics reading the status of the daemon, e.g. ntpsec, from source.
+// lastConfigLayout is the time layout of lastConfigurationTime in the status.
+func New(source StatusSource, daemon string, lastConfigLayout string) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "grpc_requests_total",
+			Help:      "Number of handled gRPC requests by method and status code.",
+		}, []string{"method", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "Duration of the handled gRPC requests by method.",
+			Buckets:   []float64{0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60},
+		}, []string{"method"}),
+	}
+	m.registry.MustRegister(m.requests, m.latency, newStatusCollector(source, daemon, lastConfigLayout))
+	return m
+}
+
+// Handler returns the handler serving the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// UnaryServerInterceptor counts the unary requests and observes their duration.
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observe(info.FullMethod, err, time.Since(start))
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor counts the streams and observes their duration, e.g. the time a client watched the status.
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		m.observe(info.FullMethod, err, time.Since(start))
+		return err
+	}
+}
+
+func (m *Metrics) observe(method string, err error, duration time.Duration) {
+	m.requests.WithLabelValues(method, status.Code(err).String()).Inc()
+	m.latency.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+// statusCollector reads the status on every scrape, the peers of the last scrape disappear with their associations.
+type statusCollector struct {
+	source           StatusSource
+	daemon           string
+	lastConfigLayout string
+	peerOffset       *prometheus.Desc
+	peerDelay        *prometheus.Desc
+	peerJitter       *prometheus.Desc
+	peerReach        *prometheus.Desc
+	peerStratum      *prometheus.Desc
+	systemOffset     *prometheus.Desc
+	systemFrequency  *prometheus.Desc
+	synced           *prometheus.Desc
+	running          *prometheus.Desc
+	configAge        *prometheus.Desc
+	up               *prometheus.Desc
+}
+
+func newStatusCollector(source StatusSource, daemon string, lastConfigLayout string) *statusCollector {
+	peerLabels := []string{"address", "association_id"}
+	return &statusCollector{
+		source:           source,
+		daemon:           daemon,
+		lastConfigLayout: lastConfigLayout,
+		peerOffset: prometheus.NewDesc(namespace+"_peer_offset_seconds",
+			"Offset between the local clock and the peer.", peerLabels, nil),
+		peerDelay: prometheus.NewDesc(namespace+"_peer_delay_seconds",
+			"Round trip time to the peer.", peerLabels, nil),
+		peerJitter: prometheus.NewDesc(namespace+"_peer_jitter_seconds",
+			"Jitter of the offsets of the peer.", peerLabels, nil),
+		peerReach: prometheus.NewDesc(namespace+"_peer_reach",
+			"Reach register of the peer, 255 if the last 8 polls were answered.", peerLabels, nil),
+		peerStratum: prometheus.NewDesc(namespace+"_peer_stratum",
+			"Stratum of the peer.", peerLabels, nil),
+		systemOffset: prometheus.NewDesc(namespace+"_system_offset_seconds",
+			"Offset of the system clock to the selected time.", nil, nil),
+		systemFrequency: prometheus.NewDesc(namespace+"_system_frequency_ppm",
+			"Frequency correction of the system clock.", nil, nil),
+		synced: prometheus.NewDesc(namespace+"_synced",
+			"1 if the clock is synchronized to a system peer.", nil, nil),
+		running: prometheus.NewDesc(namespace+"_daemon_running",
+			"1 if the time daemon is running.", []string{"daemon"}, nil),
+		configAge: prometheus.NewDesc(namespace+"_last_configuration_age_seconds",
+			"Time since the servers were configured the last time.", nil, nil),
+		up: prometheus.NewDesc(namespace+"_status_up",
+			"1 if the status could be read.", nil, nil),
+	}
+}
+
+// Describe sends the descriptions without reading the status, Collect queries the time daemon.
+func (c *statusCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{c.peerOffset, c.peerDelay, c.peerJitter, c.peerReach, c.peerStratum,
+		c.systemOffset, c.systemFrequency, c.synced, c.running, c.configAge, c.up} {
+		ch <- desc
+	}
+}
+
+func (c *statusCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+	current, err := c.source.GetSyncStatus(ctx)
+	if err != nil {
+		log.Println("Cannot read the status for the metrics:", err.Error())
+		ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 0)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 1)
+	ch <- prometheus.MustNewConstMetric(c.running, prometheus.GaugeValue, boolValue(current.GetIsNtpServiceRunning()), c.daemon)
+	ch <- prometheus.MustNewConstMetric(c.synced, prometheus.GaugeValue, boolValue(current.GetIsSynced()))
+	if system := current.GetSystemVariables(); system != nil {
+		ch <- prometheus.MustNewConstMetric(c.systemOffset, prometheus.GaugeValue, seconds(system.GetOffset()))
+		ch <- prometheus.MustNewConstMetric(c.systemFrequency, prometheus.GaugeValue, float64(system.GetFrequency()))
+	}
+	if configured, err := time.ParseInLocation(c.lastConfigLayout, current.GetLastConfigurationTime(), time.Local); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.configAge, prometheus.GaugeValue, time.Since(configured).Seconds())
+	}
+	for _, peer := range current.GetPeerDetails() {
+		labels := []string{peer.GetAddress(), strconv.FormatUint(uint64(peer.GetAssociationId()), 10)}
+		ch <- prometheus.MustNewConstMetric(c.peerOffset, prometheus.GaugeValue, seconds(peer.GetOffset()), labels...)
+		ch <- prometheus.MustNewConstMetric(c.peerDelay, prometheus.GaugeValue, seconds(peer.GetDelay()), labels...)
+		ch <- prometheus.MustNewConstMetric(c.peerJitter, prometheus.GaugeValue, seconds(peer.GetJitter()), labels...)
+		if reach, err := strconv.ParseUint(peer.GetReach(), 8, 8); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.peerReach, prometheus.GaugeValue, float64(reach), labels...)
+		}
+		if stratum, err := strconv.Atoi(peer.GetStratum()); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.peerStratum, prometheus.GaugeValue, float64(stratum), labels...)
+		}
+	}
+}
+
+func boolValue(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+func seconds(milliseconds float32) float64 {
+	return float64(milliseconds) / millisecondsPerSecond
+}
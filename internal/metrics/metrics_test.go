@@ -0,0 +1,121 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const tLayout = "2006.01.02 15:04:05"
+
+type tStatusSource struct {
+	status *v1.Status
+	err    error
+}
+
+func (s tStatusSource) GetSyncStatus(ctx context.Context) (*v1.Status, error) {
+	return s.status, s.err
+}
+
+func scrape(t *testing.T, m *Metrics) string {
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func Test_StatusMetrics(t *testing.T) {
+	source := tStatusSource{status: &v1.Status{
+		IsNtpServiceRunning:   true,
+		IsSynced:              true,
+		LastConfigurationTime: time.Now().Add(-time.Hour).Format(tLayout),
+		PeerDetails: []*v1.PeerDetails{
+			{Address: "192.168.1.1", AssociationId: 1, Offset: -1.5, Delay: 2, Jitter: 0.25, Reach: "377", Stratum: "2"},
+			{Address: "192.168.1.2", AssociationId: 2, Reach: "0", Stratum: "16"},
+		},
+		SystemVariables: &v1.SystemVariables{Offset: -1.5, Frequency: -12.5},
+	}}
+
+	body := scrape(t, New(source, "ntpsec", tLayout))
+
+	for _, line := range []string{
+		`ntpservice_status_up 1`,
+		`ntpservice_daemon_running{daemon="ntpsec"} 1`,
+		`ntpservice_synced 1`,
+		`ntpservice_system_offset_seconds -0.0015`,
+		`ntpservice_system_frequency_ppm -12.5`,
+		`ntpservice_peer_offset_seconds{address="192.168.1.1",association_id="1"} -0.0015`,
+		`ntpservice_peer_delay_seconds{address="192.168.1.1",association_id="1"} 0.002`,
+		`ntpservice_peer_jitter_seconds{address="192.168.1.1",association_id="1"} 0.00025`,
+		`ntpservice_peer_reach{address="192.168.1.1",association_id="1"} 255`,
+		`ntpservice_peer_stratum{address="192.168.1.2",association_id="2"} 16`,
+		`ntpservice_last_configuration_age_seconds 3600`,
+	} {
+		assert.Contains(t, body, line, "Did not get expected result for %s", line)
+	}
+}
+
+func Test_StatusMetrics_NotConfigured(t *testing.T) {
+	body := scrape(t, New(tStatusSource{status: &v1.Status{}}, "chrony", tLayout))
+
+	assert.Contains(t, body, `ntpservice_daemon_running{daemon="chrony"} 0`)
+	assert.NotContains(t, body, "ntpservice_last_configuration_age_seconds")
+	assert.NotContains(t, body, "ntpservice_system_offset_seconds")
+	assert.NotContains(t, body, "ntpservice_peer_offset_seconds")
+}
+
+func Test_StatusMetrics_StatusFailed(t *testing.T) {
+	body := scrape(t, New(tStatusSource{err: errors.New("permission denied")}, "ntpsec", tLayout))
+
+	assert.Contains(t, body, "ntpservice_status_up 0")
+	assert.NotContains(t, body, "ntpservice_synced")
+}
+
+func Test_UnaryServerInterceptor(t *testing.T) {
+	m := New(tStatusSource{status: &v1.Status{}}, "ntpsec", tLayout)
+	interceptor := m.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/siemens.iedge.dmapi.ntp.v1.NtpService/SetNtpServer"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.New(codes.InvalidArgument, "invalid").Err()
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `ntpservice_grpc_requests_total{code="OK",method="/siemens.iedge.dmapi.ntp.v1.NtpService/SetNtpServer"} 1`)
+	assert.Contains(t, body, `ntpservice_grpc_requests_total{code="InvalidArgument",method="/siemens.iedge.dmapi.ntp.v1.NtpService/SetNtpServer"} 1`)
+	assert.Contains(t, body, `ntpservice_grpc_request_duration_seconds_count{method="/siemens.iedge.dmapi.ntp.v1.NtpService/SetNtpServer"} 2`)
+}
+
+func Test_StreamServerInterceptor(t *testing.T) {
+	m := New(tStatusSource{status: &v1.Status{}}, "ntpsec", tLayout)
+	info := &grpc.StreamServerInfo{FullMethod: "/siemens.iedge.dmapi.ntp.v1.NtpService/WatchStatus", IsServerStream: true}
+
+	err := m.StreamServerInterceptor()(nil, nil, info, func(srv any, stream grpc.ServerStream) error {
+		return status.New(codes.Canceled, "canceled").Err()
+	})
+
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	assert.Contains(t, scrape(t, m), `ntpservice_grpc_requests_total{code="Canceled",method="/siemens.iedge.dmapi.ntp.v1.NtpService/WatchStatus"} 1`)
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+	"ntpservice/internal/serviceconfig"
+	"ntpservice/internal/servicemanager"
+	"ntpservice/utils/executor"
+)
+
+// Backend is the time daemon whose configuration file is written by the NtpConfigurator.
+// The status is reported in the model of the NTP control protocol, whatever the daemon uses to report it.
+type Backend interface {
+	// Name returns the name of the daemon, e.g. ntpsec.
+	Name() string
+	// ConfPath returns the path of the configuration file with the server and pool lines.
+	ConfPath() string
+	// ReplaceServers returns the configuration file with the servers of config instead of the current ones.
+	ReplaceServers(conf []byte, config *v1.Ntp) []byte
+	// ReadServers returns the servers of the configuration file.
+	ReadServers(conf []byte) *v1.Ntp
+	Stop(ctx context.Context) error
+	Start(ctx context.Context) error
+	Restart(ctx context.Context) error
+	// Active reports whether the daemon is running.
+	Active(ctx context.Context) bool
+	// Step sets the system time once from the configured servers, the daemon must be stopped.
+	Step(ctx context.Context) error
+	// Peers returns the sources of the daemon as associations.
+	Peers(ctx context.Context) ([]ntpcontrol.Peer, error)
+	// SystemVariables returns the system variables of the daemon, backends may return more than the named ones.
+	SystemVariables(ctx context.Context, names ...string) (ntpcontrol.Variables, error)
+	// Supports reports whether the backend can write the feature to its configuration file.
+	Supports(feature Feature) bool
+}
+
+// optionalConf is implemented by backends whose configuration file is a drop-in which is created on the first write,
+// a missing drop-in is read as empty.
+type optionalConf interface {
+	OptionalConf() bool
+}
+
+// entryValidator is implemented by backends which can not write every option of a server entry.
+type entryValidator interface {
+	// ValidateEntry returns ErrNotSupported if an option of the entry can not be written.
+	ValidateEntry(entry *v1.NtpServerEntry) error
+}
+
+// Feature is an optional part of the configuration which not every backend can write.
+type Feature string
+
+// Optional features of the configuration.
+const (
+	FeatureKeys        Feature = "symmetric keys"
+	FeatureNtsCaBundle Feature = "nts ca bundle"
+	FeatureServing     Feature = "serving"
+	FeatureOrphan      Feature = "orphan mode"
+	FeatureRefclocks   Feature = "reference clocks"
+	FeatureNts         Feature = "nts servers"
+	FeatureFallback    Feature = "fallback servers"
+	FeatureLeapFile    Feature = "leap seconds file"
+	FeatureLocalClock  Feature = "local clock fallback"
+)
+
+// Default timeouts of the commands, an earlier deadline of the request is kept.
+const (
+	queryTimeout = 5 * time.Second                  // chronyc and timedatectl
+	stepTimeout  = serviceconfig.DefaultStepTimeout // ntpd -gq and chronyd -q do not end without reachable servers
+)
+
+// Names of the backends accepted by NewBackend.
+const (
+	NtpSecBackendName    = "ntpsec"
+	ChronyBackendName    = "chrony"
+	TimesyncdBackendName = "timesyncd"
+	AutoBackendName      = "auto"
+)
+
+// ErrNotSupported is returned when the backend can not write a feature.
+var ErrNotSupported = errors.New("not supported by the time daemon")
+
+// ErrUnknownBackend is returned for a backend name which is not known.
+var ErrUnknownBackend = errors.New("unknown time daemon backend")
+
+// NewBackend returns the backend with the name, an empty name or auto detects the installed daemon.
+func NewBackend(name string, ex executor.Executor, services servicemanager.ServiceManager) (Backend, error) {
+	switch name {
+	case NtpSecBackendName:
+		return NewNtpSecBackend(ex, services), nil
+	case ChronyBackendName:
+		return NewChronyBackend(ex, services), nil
+	case TimesyncdBackendName:
+		return NewTimesyncdBackend(ex, services), nil
+	case "", AutoBackendName:
+		return detectBackend(ex, services, ntpSecConfigPath, chronyConfigPath, timesyncdMainConfigPath), nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, name)
+}
+
+// detectBackend selects the first daemon whose configuration file exists in the order ntpsec, chrony and timesyncd,
+// ntpsec if none exists.
+func detectBackend(ex executor.Executor, services servicemanager.ServiceManager, ntpSecConf string, chronyConf string, timesyncdConf string) Backend {
+	if _, err := os.Stat(ntpSecConf); err == nil {
+		return NewNtpSecBackend(ex, services)
+	}
+	if _, err := os.Stat(chronyConf); err == nil {
+		return NewChronyBackend(ex, services)
+	}
+	if _, err := os.Stat(timesyncdConf); err == nil {
+		return NewTimesyncdBackend(ex, services)
+	}
+	return NewNtpSecBackend(ex, services)
+}
+
+// Daemon overrides the service name and the step timeout of a backend, the zero values keep the defaults.
+type Daemon struct {
+	Service     string
+	StepTimeout time.Duration
+}
+
+// daemonBackend is implemented by the backends embedding Daemon.
+type daemonBackend interface {
+	setDaemon(daemon Daemon)
+}
+
+func (d *Daemon) setDaemon(daemon Daemon) {
+	*d = daemon
+}
+
+// service returns the configured service, the default of the backend without one.
+func (d Daemon) service(defaultService string) string {
+	if d.Service != "" {
+		return d.Service
+	}
+	return defaultService
+}
+
+// step returns the step command with the configured timeout.
+func (d Daemon) step(command executor.Command) executor.Command {
+	if d.StepTimeout > 0 {
+		command.Timeout = d.StepTimeout
+	}
+	return command
+}
+
+// require returns ErrNotSupported if the backend can not write the feature.
+func (n *NtpConfigurator) require(feature Feature) error {
+	if !n.Backend.Supports(feature) {
+		return fmt.Errorf("%w: %s can not be configured with %s", ErrNotSupported, feature, n.Backend.Name())
+	}
+	return nil
+}
+
+// runCommand runs the command and logs a failure.
+func runCommand(ctx context.Context, ex executor.Executor, command executor.Command) ([]byte, error) {
+	out, err := ex.Run(ctx, command)
+	if err != nil {
+		log.Println(CommanderError, command, err)
+	}
+	return out, err
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/servicemanager/servicemanagertest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewBackend(t *testing.T) {
+	backend, err := NewBackend(NtpSecBackendName, tExecutor{}, servicemanagertest.New())
+	assert.NoError(t, err)
+	assert.Equal(t, ntpSecConfigPath, backend.ConfPath())
+
+	backend, err = NewBackend(ChronyBackendName, tExecutor{}, servicemanagertest.New())
+	assert.NoError(t, err)
+	assert.Equal(t, chronyConfigPath, backend.ConfPath())
+
+	backend, err = NewBackend(TimesyncdBackendName, tExecutor{}, servicemanagertest.New())
+	assert.NoError(t, err)
+	assert.Equal(t, timesyncdConfigPath, backend.ConfPath())
+
+	_, err = NewBackend("openntpd", tExecutor{}, servicemanagertest.New())
+	assert.ErrorIs(t, err, ErrUnknownBackend)
+}
+
+func Test_detectBackend(t *testing.T) {
+	dir := t.TempDir()
+	ntpSecConf := filepath.Join(dir, "ntp.conf")
+	chronyConf := filepath.Join(dir, "chrony.conf")
+	timesyncdConf := filepath.Join(dir, "timesyncd.conf")
+
+	assert.Equal(t, NtpSecBackendName, detectBackend(tExecutor{}, servicemanagertest.New(), ntpSecConf, chronyConf, timesyncdConf).Name())
+
+	assert.NoError(t, os.WriteFile(timesyncdConf, []byte("[Time]\n"), 0644))
+	assert.Equal(t, TimesyncdBackendName, detectBackend(tExecutor{}, servicemanagertest.New(), ntpSecConf, chronyConf, timesyncdConf).Name())
+
+	assert.NoError(t, os.WriteFile(chronyConf, []byte("pool 2.debian.pool.ntp.org iburst\n"), 0644))
+	assert.Equal(t, ChronyBackendName, detectBackend(tExecutor{}, servicemanagertest.New(), ntpSecConf, chronyConf, timesyncdConf).Name())
+
+	assert.NoError(t, os.WriteFile(ntpSecConf, []byte("pool 2.debian.pool.ntp.org iburst\n"), 0644))
+	assert.Equal(t, NtpSecBackendName, detectBackend(tExecutor{}, servicemanagertest.New(), ntpSecConf, chronyConf, timesyncdConf).Name())
+}
+
+func Test_NotSupportedFeatures(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "pool 2.debian.pool.ntp.org iburst\n")
+	tN.Backend = NewChronyBackend(tExecutor{}, servicemanagertest.New())
+
+	assert.ErrorIs(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 1, Type: v1.KeyType_MD5, Secret: "plant-secret"}), ErrNotSupported)
+	assert.ErrorIs(t, tN.ValidateKeyReferences(&v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "10.0.0.1", KeyId: 1}}}), ErrNotSupported)
+	assert.NoError(t, tN.ValidateKeyReferences(&v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "10.0.0.1"}}}))
+	assert.ErrorIs(t, tN.SetNtsCaBundle(context.Background(), ""), ErrNotSupported)
+	assert.ErrorIs(t, tN.SetServing(context.Background(), &v1.ServingConfig{Enabled: true}), ErrNotSupported)
+	assert.ErrorIs(t, tN.SetOrphanMode(context.Background(), &v1.OrphanConfig{Stratum: 10}), ErrNotSupported)
+	assert.ErrorIs(t, tN.SetRefclocks(context.Background(), &v1.Refclocks{}), ErrNotSupported)
+
+	content, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "pool 2.debian.pool.ntp.org iburst\n", string(content), "Did not get expected result. The configuration must not change")
+}
@@ -0,0 +1,259 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+	"ntpservice/internal/servicemanager"
+	"ntpservice/utils/executor"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const chronyConfigPath = "/etc/chrony/chrony.conf"
+
+// ChronyService is the name of the chrony service.
+const ChronyService = "chrony"
+
+// StepChronyCmd sets the clock once like `ntpd -gq`, the step timeout is used for the same reason as in UpdateSystemTimeCmd.
+var StepChronyCmd = executor.NewCommand(stepTimeout, "chronyd", "-q")
+
+// The chronyc reports in CSV format with numeric addresses.
+var chronySourcesCmd = executor.NewCommand(queryTimeout, "chronyc", "-n", "-c", "sources")
+var chronyTrackingCmd = executor.NewCommand(queryTimeout, "chronyc", "-n", "-c", "tracking")
+
+// Columns of the CSV reports of chronyc.
+const (
+	sourcesColumns  = 10
+	trackingColumns = 14
+)
+
+// chronyNtsPortOption is the server option of chrony with the port of the NTS-KE server, ntpsec takes it from the address.
+const chronyNtsPortOption = "ntsport"
+
+// chronyNeverReceived is the last rx value of a source without any sample.
+const chronyNeverReceived = 4294967295
+
+// chronySelections maps the state column of chronyc sources to the selection state of an association.
+var chronySelections = map[string]uint8{
+	"*": ntpcontrol.SelectionSystemPeer,
+	"+": ntpcontrol.SelectionCandidate,
+	"-": ntpcontrol.SelectionOutlier,
+	"x": ntpcontrol.SelectionFalsetick,
+	"~": ntpcontrol.SelectionExcess,
+	"?": ntpcontrol.SelectionReject,
+}
+
+// chronyLeaps maps the leap status of chronyc tracking to the leap indicator.
+var chronyLeaps = map[string]string{
+	"Normal":           "0",
+	"Insert second":    "1",
+	"Delete second":    "2",
+	"Not synchronised": "3",
+}
+
+// errChronyReport is returned when the output of chronyc can not be parsed.
+var errChronyReport = errors.New("invalid chronyc report")
+
+// ChronyBackend configures chrony, the status is read with chronyc.
+type ChronyBackend struct {
+	Exec     executor.Executor
+	Services servicemanager.ServiceManager
+	Daemon
+}
+
+// NewChronyBackend returns the chrony backend.
+func NewChronyBackend(ex executor.Executor, services servicemanager.ServiceManager) *ChronyBackend {
+	return &ChronyBackend{Exec: ex, Services: services}
+}
+
+func (b *ChronyBackend) Name() string {
+	return ChronyBackendName
+}
+
+func (b *ChronyBackend) ConfPath() string {
+	return chronyConfigPath
+}
+
+// ReplaceServers replaces the server and pool lines, the entries are written in chrony syntax.
+func (b *ChronyBackend) ReplaceServers(conf []byte, config *v1.Ntp) []byte {
+	return replaceServerLines(conf, config, renderChronyEntry)
+}
+
+// ReadServers returns the server and pool lines.
+func (b *ChronyBackend) ReadServers(conf []byte) *v1.Ntp {
+	return parseServerLines(conf, parseChronyEntry)
+}
+
+// ValidateEntry rejects preempt, chrony has no option to remove an association which is not useful. Keys are
+// rejected with the symmetric keys feature.
+func (b *ChronyBackend) ValidateEntry(entry *v1.NtpServerEntry) error {
+	if entry.GetPreempt() {
+		return fmt.Errorf("%w: preempt of %s can not be configured with %s", ErrNotSupported, entry.GetAddress(), b.Name())
+	}
+	return nil
+}
+
+func (b *ChronyBackend) Stop(ctx context.Context) error {
+	return b.Services.Stop(ctx, b.service(ChronyService))
+}
+
+func (b *ChronyBackend) Start(ctx context.Context) error {
+	return b.Services.Start(ctx, b.service(ChronyService))
+}
+
+func (b *ChronyBackend) Restart(ctx context.Context) error {
+	return b.Services.Restart(ctx, b.service(ChronyService))
+}
+
+func (b *ChronyBackend) Active(ctx context.Context) bool {
+	return b.Services.Active(ctx, b.service(ChronyService))
+}
+
+// Step runs `chronyd -q`.
+func (b *ChronyBackend) Step(ctx context.Context) error {
+	_, err := runCommand(ctx, b.Exec, b.step(StepChronyCmd))
+	return err
+}
+
+// Peers returns the sources of chronyc sources, pools are resolved by chrony into configured sources.
+func (b *ChronyBackend) Peers(ctx context.Context) ([]ntpcontrol.Peer, error) {
+	out, err := b.Exec.Run(ctx, chronySourcesCmd)
+	if err != nil {
+		return nil, err
+	}
+	return parseChronySources(string(out), time.Now())
+}
+
+// SystemVariables returns the variables of chronyc tracking, the names are ignored.
+func (b *ChronyBackend) SystemVariables(ctx context.Context, names ...string) (ntpcontrol.Variables, error) {
+	out, err := b.Exec.Run(ctx, chronyTrackingCmd)
+	if err != nil {
+		return nil, err
+	}
+	return parseChronyTracking(string(out))
+}
+
+// Supports reports true for the nts option of the server lines and the local clock fallback, the other features are
+// written in ntpsec syntax.
+func (b *ChronyBackend) Supports(feature Feature) bool {
+	return feature == FeatureNts || feature == FeatureLocalClock
+}
+
+// renderChronyEntry converts the entry into a chrony `server` or `pool` line, the NTS-KE port of the address is written
+// as ntsport option.
+func renderChronyEntry(entry *v1.NtpServerEntry) string {
+	host, port, err := net.SplitHostPort(entry.GetAddress())
+	if !entry.GetNts() || err != nil {
+		return RenderServerEntry(entry)
+	}
+	entry = proto.Clone(entry).(*v1.NtpServerEntry)
+	entry.Address = host
+	return RenderServerEntry(entry) + " " + chronyNtsPortOption + " " + port
+}
+
+// parseChronyEntry parses a chrony `server` or `pool` line, the ntsport option is added to the address.
+func parseChronyEntry(line string) (*v1.NtpServerEntry, error) {
+	entry, err := ParseServerEntry(line)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(strings.SplitN(line, "#", 2)[0])
+	for i := 2; i+1 < len(fields); i++ {
+		if fields[i] == chronyNtsPortOption {
+			entry.Address = net.JoinHostPort(entry.GetAddress(), fields[i+1])
+		}
+	}
+	return entry, nil
+}
+
+// parseChronySources converts the lines of `chronyc -c sources` into associations with the variables ntpd would send,
+// e.g. `^,*,192.168.1.1,2,6,377,35,-0.000012,-0.000013,0.000240`.
+func parseChronySources(out string, now time.Time) ([]ntpcontrol.Peer, error) {
+	var peers []ntpcontrol.Peer
+	for i, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(strings.TrimSpace(line), ",")
+		if len(fields) < sourcesColumns {
+			return nil, fmt.Errorf("%w: %q has less than %d columns", errChronyReport, line, sourcesColumns)
+		}
+		selection, ok := chronySelections[fields[1]]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown state %q of %s", errChronyReport, fields[1], fields[2])
+		}
+		reach, err := strconv.ParseUint(fields[5], 8, 8)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid reach %q of %s", errChronyReport, fields[5], fields[2])
+		}
+		hostMode := strconv.Itoa(ntpcontrol.ModeClient)
+		if fields[0] == "=" {
+			hostMode = strconv.Itoa(ntpcontrol.ModeActive)
+		}
+		variables := ntpcontrol.Variables{
+			"srcadr":  fields[2],
+			"stratum": fields[3],
+			"hpoll":   fields[4],
+			"reach":   strconv.FormatUint(reach, 10),
+			"hmode":   hostMode,
+			"offset":  secondsToMilliseconds(fields[7]),
+			"jitter":  secondsToMilliseconds(fields[9]),
+		}
+		if lastRx, err := strconv.ParseUint(fields[6], 10, 32); err == nil && lastRx != chronyNeverReceived {
+			variables["rec"] = ntpcontrol.FormatTime(now.Add(-time.Duration(lastRx) * time.Second))
+		}
+		status := ntpcontrol.PeerConfigured | uint16(selection)<<8
+		if reach != 0 {
+			status |= ntpcontrol.PeerReachable
+		}
+		peers = append(peers, ntpcontrol.Peer{AssociationID: uint16(i + 1), Status: status, Variables: variables})
+	}
+	return peers, nil
+}
+
+// parseChronyTracking converts the line of `chronyc -c tracking` into the system variables ntpd would send,
+// e.g. `C0A80101,192.168.1.1,3,1700000000.123,0.000001,-0.000002,0.000003,-1.234,0.001,0.010,0.012,0.001,64.2,Normal`.
+func parseChronyTracking(out string) (ntpcontrol.Variables, error) {
+	fields := strings.Split(strings.TrimSpace(out), ",")
+	if len(fields) < trackingColumns {
+		return nil, fmt.Errorf("%w: %q has less than %d columns", errChronyReport, out, trackingColumns)
+	}
+	leap, ok := chronyLeaps[fields[13]]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown leap status %q", errChronyReport, fields[13])
+	}
+	return ntpcontrol.Variables{
+		"refid":      fields[1],
+		"stratum":    fields[2],
+		"offset":     secondsToMilliseconds(fields[5]),
+		"frequency":  fields[7],
+		"sys_jitter": secondsToMilliseconds(fields[6]),
+		"clk_wander": fields[9],
+		"rootdelay":  secondsToMilliseconds(fields[10]),
+		"rootdisp":   secondsToMilliseconds(fields[11]),
+		"leap":       leap,
+	}, nil
+}
+
+// secondsToMilliseconds converts a value of chronyc into the unit ntpd uses, invalid values become empty.
+func secondsToMilliseconds(seconds string) string {
+	value, err := strconv.ParseFloat(seconds, 64)
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatFloat(value*1000, 'f', -1, 64)
+}
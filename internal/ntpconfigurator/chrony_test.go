@@ -0,0 +1,171 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+	"ntpservice/internal/servicemanager"
+	"ntpservice/internal/servicemanager/servicemanagertest"
+	"ntpservice/utils/executor"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const tChronySources = "^,*,192.168.1.1,2,6,377,35,-0.000012500,-0.000013000,0.000240000\n" +
+	"^,+,192.168.1.2,3,6,17,4294967295,0.001000000,0.001000000,0.002000000\n" +
+	"=,?,192.168.1.21,0,6,0,4294967295,0.000000000,0.000000000,0.000000000\n"
+
+const tChronyTracking = "C0A80101,192.168.1.1,3,1700000000.123,0.000001,-0.000002,0.000003,-1.234,0.001,0.010,0.012500,0.001000,64.2,Normal\n"
+
+// tChronyExecutor answers the chronyc commands and records all commands.
+type tChronyExecutor struct {
+	tFailingExecutor
+}
+
+func (o *tChronyExecutor) Run(ctx context.Context, command executor.Command) ([]byte, error) {
+	if _, err := o.tFailingExecutor.Run(ctx, command); err != nil {
+		return nil, err
+	}
+	switch command.String() {
+	case chronySourcesCmd.String():
+		return []byte(tChronySources), nil
+	case chronyTrackingCmd.String():
+		return []byte(tChronyTracking), nil
+	}
+	return []byte{}, nil
+}
+
+func Test_parseChronySources(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	peers, err := parseChronySources(tChronySources, now)
+
+	assert.NoError(t, err)
+	assert.Len(t, peers, 3)
+	peer := peers[0]
+	assert.Equal(t, ntpcontrol.SelectionSystemPeer, peer.Selection())
+	assert.True(t, peer.Configured())
+	assert.True(t, peer.Reachable())
+	assert.Equal(t, "192.168.1.1", peer.Remote())
+	assert.Equal(t, int64(0377), peer.Reach())
+	assert.Equal(t, int64(64), peer.Poll())
+	assert.Equal(t, int64(35), peer.When(now))
+	assert.InDelta(t, -0.0125, peer.Variables.Float("offset"), 1e-9)
+	assert.InDelta(t, 0.24, peer.Variables.Float("jitter"), 1e-9)
+	assert.Equal(t, "u", peer.Type())
+	assert.Equal(t, ntpcontrol.SelectionCandidate, peers[1].Selection())
+	assert.Equal(t, int64(-1), peers[1].When(now))
+	assert.Equal(t, "s", peers[2].Type())
+	assert.False(t, peers[2].Reachable())
+
+	for _, out := range []string{"^,*,192.168.1.1", "^,!,192.168.1.1,2,6,377,35,0,0,0", "^,*,192.168.1.1,2,6,999,35,0,0,0"} {
+		_, err := parseChronySources(out, now)
+		assert.ErrorIs(t, err, errChronyReport, "Did not get expected result for %q", out)
+	}
+}
+
+func Test_parseChronyTracking(t *testing.T) {
+	system, err := parseChronyTracking(tChronyTracking)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), system.Int("stratum"))
+	assert.Equal(t, "192.168.1.1", system.String("refid"))
+	assert.InDelta(t, 12.5, system.Float("rootdelay"), 1e-9)
+	assert.InDelta(t, 0.003, system.Float("sys_jitter"), 1e-9)
+	assert.InDelta(t, 0.01, system.Float("clk_wander"), 1e-9)
+	assert.Equal(t, int64(0), system.Int("leap"))
+
+	_, err = parseChronyTracking("C0A80101,192.168.1.1,3")
+	assert.ErrorIs(t, err, errChronyReport)
+	system, err = parseChronyTracking("00000000,,0,0.0,0,0,0,0,0,0,0,0,0,Not synchronised")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), system.Int("leap"))
+}
+
+func Test_ChronyBackend_GetSyncStatus(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "pool 2.debian.pool.ntp.org iburst\n")
+	tN.Backend = NewChronyBackend(&tChronyExecutor{tFailingExecutor{failing: map[string]bool{}}}, servicemanagertest.New(ChronyService))
+
+	status, err := tN.GetSyncStatus(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, status.IsNtpServiceRunning)
+	assert.True(t, status.IsSynced)
+	assert.Len(t, status.PeerDetails, 3)
+	assert.Equal(t, "*192.168.1.1", status.PeerDetails[0].RemoteServer)
+	assert.Nil(t, status.Serving)
+	assert.Equal(t, uint32(3), status.SystemVariables.GetStratum())
+	assert.InDelta(t, -1.234, status.SystemVariables.GetFrequency(), 1e-6)
+	system, err := tN.Backend.SystemVariables(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.1.1", system.String("refid"))
+}
+
+func Test_ChronyBackend_WriteConfiguration(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "driftfile /var/lib/chrony/chrony.drift\npool 2.debian.pool.ntp.org iburst\n")
+	ut := &tChronyExecutor{tFailingExecutor{failing: map[string]bool{}}}
+	services := servicemanagertest.New(ChronyService)
+	tN.Backend = NewChronyBackend(ut, services)
+
+	err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "192.168.1.1", Iburst: true}}, SyncTimeoutMs: 1000})
+
+	assert.NoError(t, err)
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "driftfile /var/lib/chrony/chrony.drift\nserver 192.168.1.1 iburst\n", string(conf))
+	assert.Equal(t, []string{"stop chrony", "start chrony", "status chrony"}, services.Calls())
+	assert.Equal(t, []executor.Command{StepChronyCmd, chronySourcesCmd}, ut.commands)
+
+	services.Fail(servicemanager.ActionStart, ChronyService)
+	err = tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	assert.Equal(t, StepStart, applyErr.Step)
+	assert.True(t, applyErr.RolledBack)
+	calls := services.Calls()
+	assert.Equal(t, "restart chrony", calls[len(calls)-1])
+}
+
+func Test_ChronyBackend_ServerOptions(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "driftfile /var/lib/chrony/chrony.drift\npool 2.debian.pool.ntp.org iburst\n")
+	tN.Backend = NewChronyBackend(&tChronyExecutor{tFailingExecutor{failing: map[string]bool{}}}, servicemanagertest.New(ChronyService))
+	config := &v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{
+		{Address: "nts.example.com:4460", Nts: true, Iburst: true},
+		{Address: "[2001:db8::1]:4461", Nts: true},
+		{Address: "192.168.1.1", Prefer: true, Minpoll: 4},
+	}}
+
+	assert.NoError(t, tN.ValidateBackend(config))
+	assert.NoError(t, tN.WriteConfiguration(context.Background(), config))
+
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "driftfile /var/lib/chrony/chrony.drift\n"+
+		"server nts.example.com iburst nts ntsport 4460\n"+
+		"server 2001:db8::1 nts ntsport 4461\n"+
+		"server 192.168.1.1 prefer minpoll 4\n", string(conf))
+	read, err := tN.GetCurrentNtpServers()
+	assert.NoError(t, err)
+	for i, entry := range config.NtpServerEntries {
+		assert.True(t, proto.Equal(entry, read.NtpServerEntries[i]), "Did not get expected result. Wanted: %v, got: %v", entry, read.NtpServerEntries[i])
+	}
+
+	preempt := []*v1.Ntp{
+		{NtpServerEntries: []*v1.NtpServerEntry{{Address: "2.debian.pool.ntp.org", Kind: v1.NtpEntryKind_POOL, Preempt: true}}},
+		{NtpServer: []string{"192.168.1.1 iburst preempt"}},
+	}
+	for _, config := range preempt {
+		assert.ErrorIs(t, tN.ValidateBackend(config), ErrNotSupported, "Did not get expected result for %v", config)
+	}
+	assert.NoError(t, prepareNtpConfiguratorWithKeys(t, "").ValidateBackend(preempt[0]), "Did not get expected result. ntpsec writes preempt")
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around a change, as diff -u.
+const diffContext = 3
+
+// diffLine is a line of the edit script, op is ' ', '-' or '+'.
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// unifiedDiff returns the differences of the lines in the unified format of diff -u, empty if they are equal.
+func unifiedDiff(fromName string, toName string, from []string, to []string) string {
+	script := editScript(from, to)
+	builder := strings.Builder{}
+	for start := 0; start < len(script); {
+		// Find the next change and the end of its hunk, changes closer than twice the context are joined.
+		first := start
+		for first < len(script) && script[first].op == ' ' {
+			first++
+		}
+		if first == len(script) {
+			break
+		}
+		last := first
+		for i := first; i < len(script); i++ {
+			if script[i].op != ' ' {
+				last = i
+			} else if i-last > 2*diffContext {
+				break
+			}
+		}
+		begin := max(first-diffContext, start)
+		end := min(last+diffContext+1, len(script))
+		if builder.Len() == 0 {
+			builder.WriteString("--- " + fromName + "\n+++ " + toName + "\n")
+		}
+		writeHunk(&builder, script, begin, end)
+		start = end
+	}
+	return builder.String()
+}
+
+// writeHunk writes the lines script[begin:end] with their hunk header.
+func writeHunk(builder *strings.Builder, script []diffLine, begin int, end int) {
+	fromLine, toLine := 1, 1
+	for _, line := range script[:begin] {
+		if line.op != '+' {
+			fromLine++
+		}
+		if line.op != '-' {
+			toLine++
+		}
+	}
+	fromCount, toCount := 0, 0
+	for _, line := range script[begin:end] {
+		if line.op != '+' {
+			fromCount++
+		}
+		if line.op != '-' {
+			toCount++
+		}
+	}
+	// An empty range starts at the line before it, as diff -u prints it.
+	if fromCount == 0 {
+		fromLine--
+	}
+	if toCount == 0 {
+		toLine--
+	}
+	fmt.Fprintf(builder, "@@ -%s +%s @@\n", hunkRange(fromLine, fromCount), hunkRange(toLine, toCount))
+	for _, line := range script[begin:end] {
+		builder.WriteString(string(line.op) + line.text + "\n")
+	}
+}
+
+func hunkRange(line int, count int) string {
+	if count == 1 {
+		return fmt.Sprint(line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+// editScript returns the lines of both inputs with the removed and added ones marked, based on the longest common
+// subsequence. Configuration files are small enough for the quadratic table.
+func editScript(from []string, to []string) []diffLine {
+	common := make([][]int, len(from)+1)
+	for i := range common {
+		common[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+	var script []diffLine
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			script = append(script, diffLine{' ', from[i]})
+			i, j = i+1, j+1
+		case common[i+1][j] >= common[i][j+1]:
+			script = append(script, diffLine{'-', from[i]})
+			i++
+		default:
+			script = append(script, diffLine{'+', to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		script = append(script, diffLine{'-', from[i]})
+	}
+	for ; j < len(to); j++ {
+		script = append(script, diffLine{'+', to[j]})
+	}
+	return script
+}
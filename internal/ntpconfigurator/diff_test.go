@@ -0,0 +1,41 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_unifiedDiff(t *testing.T) {
+	from := strings.Split("a b c d e f g h i j k l m n", " ")
+	to := strings.Split("a B c d e f g h i j k l n o", " ")
+
+	diff := unifiedDiff("old", "new", from, to)
+
+	assert.Equal(t, "--- old\n+++ new\n"+
+		"@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n e\n"+
+		"@@ -10,5 +10,5 @@\n j\n k\n l\n-m\n n\n+o\n", diff)
+}
+
+func Test_unifiedDiff_EqualAndEmpty(t *testing.T) {
+	assert.Empty(t, unifiedDiff("old", "new", []string{"a"}, []string{"a"}))
+	assert.Empty(t, unifiedDiff("old", "new", nil, nil))
+	assert.Equal(t, "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n", unifiedDiff("old", "new", nil, []string{"a", "b"}))
+	assert.Equal(t, "--- old\n+++ new\n@@ -1 +0,0 @@\n-a\n", unifiedDiff("old", "new", []string{"a"}, nil))
+}
+
+func Test_unifiedDiff_JoinsCloseChanges(t *testing.T) {
+	from := strings.Split("a b c d e f g h", " ")
+	to := strings.Split("A b c d e f g H", " ")
+
+	diff := unifiedDiff("old", "new", from, to)
+
+	assert.Equal(t, 1, strings.Count(diff, "@@ -"), "Did not get expected result. Changes 6 lines apart share a hunk")
+}
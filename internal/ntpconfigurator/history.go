@@ -0,0 +1,256 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// DefaultHistoryLimit is the number of revisions kept in the history.
+const DefaultHistoryLimit = 20
+
+// Files of a revision, <id>.conf holds the ntp.conf and <id>.json the ConfigRevision.
+const (
+	revisionConfSuffix = ".conf"
+	revisionMetaSuffix = ".json"
+)
+
+// ErrRevisionNotFound is returned when a revision is not kept in the history.
+var ErrRevisionNotFound = errors.New("configuration revision not found")
+
+// historyMutex serializes the modifications of the history.
+var historyMutex sync.Mutex
+
+// recordRevision adds the attempted ntp.conf with the result of its apply to the history and removes the oldest
+// revisions above the limit. Failures are logged only, they must not fail the apply.
+func (n *NtpConfigurator) recordRevision(conf []byte, applyErr error, restoredFrom uint64) {
+	historyMutex.Lock()
+	defer historyMutex.Unlock()
+	ids, err := n.revisionIds()
+	if err != nil {
+		log.Println("Cannot read the configuration history:", err.Error())
+		return
+	}
+	revision := &v1.ConfigRevision{
+		Id:           1,
+		Time:         time.Now().Format(time.RFC3339),
+		Servers:      serverDirectives(conf),
+		Applied:      applyErr == nil,
+		RestoredFrom: restoredFrom,
+	}
+	if len(ids) > 0 {
+		revision.Id = ids[len(ids)-1] + 1
+	}
+	var applyError *ApplyError
+	if errors.As(applyErr, &applyError) {
+		revision.FailedStep = applyError.Step
+	}
+	if applyErr != nil {
+		revision.Result = applyErr.Error()
+	}
+	n.addRevision(ids, revision, conf)
+}
+
+// recordOriginal adds the ntp.conf found before the first change as revision 1 of an empty history, so that the first
+// change can be restored as well. The caller holds ntpConfMutex.
+func (n *NtpConfigurator) recordOriginal(conf []byte) {
+	historyMutex.Lock()
+	defer historyMutex.Unlock()
+	ids, err := n.revisionIds()
+	if err != nil {
+		log.Println("Cannot read the configuration history:", err.Error())
+		return
+	}
+	if len(ids) > 0 {
+		return
+	}
+	n.addRevision(ids, &v1.ConfigRevision{
+		Id:      1,
+		Time:    time.Now().Format(time.RFC3339),
+		Servers: serverDirectives(conf),
+		Applied: true,
+	}, conf)
+}
+
+// addRevision writes the revision after the kept ids and removes the oldest revisions above the limit.
+// The caller holds historyMutex.
+func (n *NtpConfigurator) addRevision(ids []uint64, revision *v1.ConfigRevision, conf []byte) {
+	meta, err := protojson.Marshal(revision)
+	if err != nil {
+		log.Println("Cannot encode the configuration revision:", err.Error())
+		return
+	}
+	if err := os.MkdirAll(n.HistoryPath, 0755); err != nil {
+		log.Println("Cannot create the configuration history:", err.Error())
+		return
+	}
+	// The ntp.conf is written first, a revision without it is not listed.
+	if err := n.Files.WriteFileAtomic(n.revisionPath(revision.Id, revisionConfSuffix), conf, ntpConfPermissions); err != nil {
+		log.Println("Cannot write the configuration revision:", err.Error())
+		return
+	}
+	if err := n.Files.WriteFileAtomic(n.revisionPath(revision.Id, revisionMetaSuffix), meta, ntpConfPermissions); err != nil {
+		log.Println("Cannot write the configuration revision:", err.Error())
+		return
+	}
+	ids = append(ids, revision.Id)
+	for len(ids) > n.HistoryLimit {
+		for _, suffix := range []string{revisionMetaSuffix, revisionConfSuffix} {
+			if err := os.Remove(n.revisionPath(ids[0], suffix)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				log.Println("Cannot remove the configuration revision:", err.Error())
+			}
+		}
+		ids = ids[1:]
+	}
+}
+
+// ListConfigHistory returns the kept revisions, newest first.
+func (n *NtpConfigurator) ListConfigHistory() ([]*v1.ConfigRevision, error) {
+	historyMutex.Lock()
+	defer historyMutex.Unlock()
+	ids, err := n.revisionIds()
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]*v1.ConfigRevision, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		revision, err := n.readRevision(ids[i])
+		if err != nil {
+			log.Println("Skipping configuration revision:", err.Error())
+			continue
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// DiffConfig returns the unified diff between the ntp.conf of two revisions, revision 0 as to is the current ntp.conf.
+func (n *NtpConfigurator) DiffConfig(from uint64, to uint64) (string, error) {
+	fromConf, err := n.readRevisionConf(from)
+	if err != nil {
+		return "", err
+	}
+	toName := "current"
+	var toConf []byte
+	if to == 0 {
+		toConf, err = n.Files.ReadFile(n.NtpConfPath)
+	} else {
+		toName = revisionName(to)
+		toConf, err = n.readRevisionConf(to)
+	}
+	if err != nil {
+		return "", err
+	}
+	return unifiedDiff(revisionName(from), toName, splitLines(fromConf), splitLines(toConf)), nil
+}
+
+// RestoreConfiguration applies the ntp.conf of a revision the same way as WriteConfiguration, the restore is
+// recorded as a new revision.
+func (n *NtpConfigurator) RestoreConfiguration(ctx context.Context, id uint64, syncTimeoutMs uint32) error {
+	if err := ValidateSyncTimeout(syncTimeoutMs); err != nil {
+		return err
+	}
+	conf, err := n.readRevisionConf(id)
+	if err != nil {
+		return err
+	}
+	write := func() error {
+		return n.Files.WriteFileAtomic(n.NtpConfPath, conf, ntpConfPermissions)
+	}
+	attempted, err := n.applyConfiguration(ctx, write, time.Duration(syncTimeoutMs)*time.Millisecond)
+	if attempted != nil {
+		n.recordRevision(attempted, err, id)
+	}
+	return err
+}
+
+// revisionIds returns the ids of the kept revisions in ascending order.
+func (n *NtpConfigurator) revisionIds() ([]uint64, error) {
+	entries, err := os.ReadDir(n.HistoryPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ids []uint64
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), revisionMetaSuffix)
+		if !ok {
+			continue
+		}
+		if id, err := strconv.ParseUint(name, 10, 64); err == nil && id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+func (n *NtpConfigurator) readRevision(id uint64) (*v1.ConfigRevision, error) {
+	meta, err := n.Files.ReadFile(n.revisionPath(id, revisionMetaSuffix))
+	if err != nil {
+		return nil, err
+	}
+	revision := &v1.ConfigRevision{}
+	if err := protojson.Unmarshal(meta, revision); err != nil {
+		return nil, fmt.Errorf("%s: %w", revisionName(id), err)
+	}
+	return revision, nil
+}
+
+func (n *NtpConfigurator) readRevisionConf(id uint64) ([]byte, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrRevisionNotFound, id)
+	}
+	conf, err := n.Files.ReadFile(n.revisionPath(id, revisionConfSuffix))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %d", ErrRevisionNotFound, id)
+	}
+	return conf, err
+}
+
+func (n *NtpConfigurator) revisionPath(id uint64, suffix string) string {
+	return filepath.Join(n.HistoryPath, strconv.FormatUint(id, 10)+suffix)
+}
+
+func revisionName(id uint64) string {
+	return "revision " + strconv.FormatUint(id, 10)
+}
+
+// serverDirectives returns the server and pool lines of an ntp.conf.
+func serverDirectives(conf []byte) []string {
+	var servers []string
+	for _, line := range splitLines(conf) {
+		fields := strings.Fields(line)
+		if len(fields) > 1 && (fields[0] == serverDirective || fields[0] == poolDirective) {
+			servers = append(servers, strings.Join(fields, " "))
+		}
+	}
+	return servers
+}
+
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/servicemanager"
+	"ntpservice/utils/executor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConfigHistory(t *testing.T) {
+	tN, _, services := prepareTransaction(t)
+
+	assert.NoError(t, tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}}))
+	services.Fail(servicemanager.ActionStart, NtpSecService)
+	assert.Error(t, tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.2"}}))
+
+	revisions, err := tN.ListConfigHistory()
+
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 3)
+	assert.Equal(t, uint64(3), revisions[0].Id, "Did not get expected result. The newest revision must be first")
+	assert.False(t, revisions[0].Applied)
+	assert.Equal(t, StepStart, revisions[0].FailedStep)
+	assert.Equal(t, []string{"server 10.0.0.2"}, revisions[0].Servers)
+	assert.True(t, revisions[1].Applied)
+	assert.Empty(t, revisions[1].Result)
+	assert.Equal(t, []string{"server 10.0.0.1"}, revisions[1].Servers)
+	assert.NotEmpty(t, revisions[1].Time)
+	assert.True(t, revisions[2].Applied, "Did not get expected result. The original ntp.conf must be revision 1")
+	assert.Equal(t, []string{"server 192.168.1.1 iburst"}, revisions[2].Servers)
+
+	diff, err := tN.DiffConfig(2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "--- revision 2\n+++ revision 3\n@@ -1,2 +1,2 @@\n driftfile /var/lib/ntpsec/ntp.drift\n-server 10.0.0.1\n+server 10.0.0.2\n", diff)
+	diff, err = tN.DiffConfig(2, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, diff, "Did not get expected result. The failed revision 3 was rolled back to revision 2")
+}
+
+func Test_ConfigHistoryLimit(t *testing.T) {
+	tN, _, _ := prepareTransaction(t)
+	tN.HistoryLimit = 2
+
+	for _, server := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		assert.NoError(t, tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{server}}))
+	}
+
+	revisions, err := tN.ListConfigHistory()
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, uint64(4), revisions[0].Id)
+	assert.Equal(t, uint64(3), revisions[1].Id)
+	_, err = tN.DiffConfig(2, 0)
+	assert.ErrorIs(t, err, ErrRevisionNotFound)
+	entries, _ := os.ReadDir(tN.HistoryPath)
+	assert.Len(t, entries, 4)
+}
+
+func Test_RestoreConfiguration(t *testing.T) {
+	tN, ut, services := prepareTransaction(t)
+	assert.NoError(t, tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}}))
+	assert.NoError(t, tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.2"}}))
+	ut.commands = nil
+	services.Reset()
+
+	assert.NoError(t, tN.RestoreConfiguration(context.Background(), 2, 0))
+
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "driftfile /var/lib/ntpsec/ntp.drift\nserver 10.0.0.1\n", string(conf))
+	assert.Equal(t, []string{"stop ntpsec", "start ntpsec", "status ntpsec"}, services.Calls())
+	assert.Equal(t, []executor.Command{UpdateSystemTimeCmd}, ut.commands)
+	revisions, _ := tN.ListConfigHistory()
+	assert.Equal(t, uint64(4), revisions[0].Id)
+	assert.Equal(t, uint64(2), revisions[0].RestoredFrom)
+
+	// A failed restore is rolled back like a failed SetNtpServer.
+	services.Fail(servicemanager.ActionStatus, NtpSecService)
+	err := tN.RestoreConfiguration(context.Background(), 3, 0)
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	conf, _ = os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "driftfile /var/lib/ntpsec/ntp.drift\nserver 10.0.0.1\n", string(conf))
+
+	assert.ErrorIs(t, tN.RestoreConfiguration(context.Background(), 42, 0), ErrRevisionNotFound)
+	assert.ErrorIs(t, tN.RestoreConfiguration(context.Background(), 0, 0), ErrRevisionNotFound)
+}
+
+func Test_ConfigHistoryRestoresOriginal(t *testing.T) {
+	tN, _, _ := prepareTransaction(t)
+	assert.NoError(t, tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}}))
+
+	assert.NoError(t, tN.RestoreConfiguration(context.Background(), 1, 0))
+
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, tPreviousConf, string(conf), "Did not get expected result. The first change must be undone")
+}
+
+func Test_ConfigHistoryRecordsEveryChange(t *testing.T) {
+	tN, _, services := prepareTransaction(t)
+	assert.NoError(t, tN.SetServing(context.Background(), &v1.ServingConfig{Enabled: true}))
+	assert.NoError(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 6, Secret: "peer-secret"}))
+	assert.NoError(t, tN.SetOrphanMode(context.Background(), &v1.OrphanConfig{Stratum: 10, Peers: []*v1.NtpServerEntry{{Address: "sibling.local", KeyId: 6}}}))
+	services.Fail(servicemanager.ActionRestart, NtpSecService)
+	assert.Error(t, tN.SetRefclocks(context.Background(), &v1.Refclocks{Refclocks: []*v1.RefclockEntry{{Driver: v1.RefclockDriver_PPS}}}))
+
+	revisions, err := tN.ListConfigHistory()
+
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 5, "Did not get expected result. The original and every change must be recorded")
+	assert.False(t, revisions[0].Applied)
+	assert.Equal(t, StepRestart, revisions[0].FailedStep)
+	assert.Equal(t, []string{"server 192.168.1.1 iburst"}, revisions[4].Servers)
+	diff, err := tN.DiffConfig(4, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, diff, "Did not get expected result. The orphan mode is the current ntp.conf")
+	orphan, err := os.ReadFile(tN.revisionPath(4, revisionConfSuffix))
+	assert.NoError(t, err)
+	assert.Contains(t, string(orphan), "peer sibling.local key 6")
+}
@@ -0,0 +1,350 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+)
+
+const ntpKeysPermissions = 0600
+const keysDirective = "keys"
+const trustedKeyDirective = "trustedkey"
+const controlKeyDirective = "controlkey"
+const keyOption = "key"
+const ntpKeysHeader = "# Symmetric keys managed by dm-ntp, do not edit."
+
+// Limits of the key secrets, see ntp.keys(5).
+const (
+	maxAsciiSecretLength = 20
+	aesSecretHexLength   = 32
+	maxHexSecretLength   = 128
+)
+
+// keyTypeNames maps the key type to its name in ntp.keys.
+var keyTypeNames = map[v1.KeyType]string{
+	v1.KeyType_MD5:        "MD5",
+	v1.KeyType_SHA1:       "SHA1",
+	v1.KeyType_AES128CMAC: "AES",
+}
+
+// keyTypeAliases maps the names accepted by ntpsec in ntp.keys to the key type.
+var keyTypeAliases = map[string]v1.KeyType{
+	"MD5":          v1.KeyType_MD5,
+	"M":            v1.KeyType_MD5,
+	"SHA1":         v1.KeyType_SHA1,
+	"SHA-1":        v1.KeyType_SHA1,
+	"AES":          v1.KeyType_AES128CMAC,
+	"AES128CMAC":   v1.KeyType_AES128CMAC,
+	"AES-128-CMAC": v1.KeyType_AES128CMAC,
+}
+
+var (
+	// ErrInvalidKey is returned when a symmetric key can not be written to ntp.keys.
+	ErrInvalidKey = errors.New("invalid symmetric key")
+	// ErrKeyExists is returned when a key with the same id already exists.
+	ErrKeyExists = errors.New("symmetric key already exists")
+	// ErrKeyNotFound is returned when no key with the id exists.
+	ErrKeyNotFound = errors.New("symmetric key not found")
+	// ErrKeyInUse is returned when a key which is still used by ntp.conf is deleted.
+	ErrKeyInUse = errors.New("symmetric key is used by a server")
+)
+
+// ntpKeysMutex serializes the modifications of ntp.keys.
+var ntpKeysMutex sync.Mutex
+
+// keyFile is the content of ntp.keys. Lines which are no key of a managed type, e.g. SHA256 keys, are kept verbatim
+// and written back unchanged, the service does not change key material it can not represent.
+type keyFile struct {
+	keys         map[uint32]*v1.SymmetricKey
+	unmanaged    []string        // lines which are no managed key, without the header
+	unmanagedIds map[uint32]bool // ids of the keys in the unmanaged lines
+}
+
+// exists reports whether ntp.keys contains a key with the id, managed or not.
+func (f *keyFile) exists(keyId uint32) bool {
+	_, ok := f.keys[keyId]
+	return ok || f.unmanagedIds[keyId]
+}
+
+// ValidateKey checks that the key can be written to ntp.keys.
+func ValidateKey(key *v1.SymmetricKey) error {
+	if key == nil {
+		return fmt.Errorf("%w: key is empty", ErrInvalidKey)
+	}
+	if key.GetKeyId() == 0 || key.GetKeyId() > maxKeyId {
+		return fmt.Errorf("%w: key id %d is out of range 1-%d", ErrInvalidKey, key.GetKeyId(), maxKeyId)
+	}
+	if _, ok := keyTypeNames[key.GetType()]; !ok {
+		return fmt.Errorf("%w: unknown type %d of key %d", ErrInvalidKey, key.GetType(), key.GetKeyId())
+	}
+	secret := key.GetSecret()
+	if key.GetType() == v1.KeyType_AES128CMAC {
+		if _, err := hex.DecodeString(secret); err != nil || len(secret) != aesSecretHexLength {
+			return fmt.Errorf("%w: secret of AES128CMAC key %d must be %d hex digits", ErrInvalidKey, key.GetKeyId(), aesSecretHexLength)
+		}
+		return nil
+	}
+	if len(secret) > maxAsciiSecretLength {
+		if _, err := hex.DecodeString(secret); err != nil || len(secret) > maxHexSecretLength {
+			return fmt.Errorf("%w: secret of key %d longer than %d characters must be hex of at most %d digits", ErrInvalidKey, key.GetKeyId(), maxAsciiSecretLength, maxHexSecretLength)
+		}
+		return nil
+	}
+	if secret == "" {
+		return fmt.Errorf("%w: secret of key %d is empty", ErrInvalidKey, key.GetKeyId())
+	}
+	for _, c := range secret {
+		if c <= ' ' || c > '~' || c == '#' {
+			return fmt.Errorf("%w: secret of key %d must be printable ASCII without spaces and '#'", ErrInvalidKey, key.GetKeyId())
+		}
+	}
+	return nil
+}
+
+// CreateKey adds the key to ntp.keys and trusts it.
+func (n *NtpConfigurator) CreateKey(ctx context.Context, key *v1.SymmetricKey) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	return n.updateKeys(ctx, func(file *keyFile) error {
+		if file.exists(key.GetKeyId()) {
+			return fmt.Errorf("%w: key id %d", ErrKeyExists, key.GetKeyId())
+		}
+		file.keys[key.GetKeyId()] = key
+		return nil
+	})
+}
+
+// RotateKey replaces the type and secret of an existing key.
+func (n *NtpConfigurator) RotateKey(ctx context.Context, key *v1.SymmetricKey) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	return n.updateKeys(ctx, func(file *keyFile) error {
+		if _, ok := file.keys[key.GetKeyId()]; !ok {
+			return fmt.Errorf("%w: key id %d", ErrKeyNotFound, key.GetKeyId())
+		}
+		file.keys[key.GetKeyId()] = key
+		return nil
+	})
+}
+
+// DeleteKey removes the key from ntp.keys, keys used by a line of ntp.conf, e.g. a server or peer, can not be deleted.
+func (n *NtpConfigurator) DeleteKey(ctx context.Context, keyId uint32) error {
+	return n.updateKeys(ctx, func(file *keyFile) error {
+		if _, ok := file.keys[keyId]; !ok {
+			return fmt.Errorf("%w: key id %d", ErrKeyNotFound, keyId)
+		}
+		// ntp.conf is locked while update runs, a concurrent change can not start to use the key after the check.
+		conf, err := n.readConf()
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if line, ok := keyReference(conf, keyId); ok {
+			return fmt.Errorf("%w: key id %d is used by %q", ErrKeyInUse, keyId, line)
+		}
+		delete(file.keys, keyId)
+		return nil
+	})
+}
+
+// keyReference returns the first line of ntp.conf with a key option of the key id, e.g. server, pool or peer lines,
+// or a controlkey directive.
+func keyReference(conf []byte, keyId uint32) (string, bool) {
+	id := strconv.FormatUint(uint64(keyId), 10)
+	for _, line := range splitLines(conf) {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		for i := 0; i+1 < len(fields); i++ {
+			reference := (i > 0 && fields[i] == keyOption) || (i == 0 && fields[i] == controlKeyDirective)
+			if reference && fields[i+1] == id {
+				return strings.Join(fields, " "), true
+			}
+		}
+	}
+	return "", false
+}
+
+// ListKeys returns the managed keys of ntp.keys sorted by id, the secrets are removed.
+func (n *NtpConfigurator) ListKeys() ([]*v1.SymmetricKey, error) {
+	file, err := n.readKeys()
+	if err != nil {
+		return nil, err
+	}
+	var list []*v1.SymmetricKey
+	for _, key := range sortedKeys(file.keys) {
+		list = append(list, &v1.SymmetricKey{KeyId: key.GetKeyId(), Type: key.GetType()})
+	}
+	return list, nil
+}
+
+// ValidateKeyReferences checks that every key id used by the server entries exists in ntp.keys.
+func (n *NtpConfigurator) ValidateKeyReferences(config *v1.Ntp) error {
+	var file *keyFile
+	for _, entry := range config.GetNtpServerEntries() {
+		if entry.GetKeyId() == 0 {
+			continue
+		}
+		if file == nil {
+			if err := n.require(FeatureKeys); err != nil {
+				return err
+			}
+			var err error
+			if file, err = n.readKeys(); err != nil {
+				return err
+			}
+		}
+		if !file.exists(entry.GetKeyId()) {
+			return fmt.Errorf("%w: key id %d of %s", ErrKeyNotFound, entry.GetKeyId(), entry.GetAddress())
+		}
+	}
+	return nil
+}
+
+// updateKeys applies update to the keys of ntp.keys, writes them back, points the keys and trustedkey directives
+// of ntp.conf to them and restarts ntpsec. update runs in the transaction with ntp.conf locked, its error is returned
+// as is. If ntpsec does not restart the previous files are restored and an *ApplyError is returned.
+func (n *NtpConfigurator) updateKeys(ctx context.Context, update func(file *keyFile) error) error {
+	if err := n.require(FeatureKeys); err != nil {
+		return err
+	}
+	ntpKeysMutex.Lock()
+	defer ntpKeysMutex.Unlock()
+	file, err := n.readKeys()
+	if err != nil {
+		return err
+	}
+	write := func() error {
+		if err := update(file); err != nil {
+			return &preconditionError{err: err}
+		}
+		if err := n.writeKeys(file); err != nil {
+			return err
+		}
+		return n.replaceKeyDirectives(file)
+	}
+	return n.applyChange(ctx, write, n.KeysPath)
+}
+
+// readKeys parses ntp.keys, a missing file contains no keys.
+func (n *NtpConfigurator) readKeys() (*keyFile, error) {
+	file := &keyFile{keys: make(map[uint32]*v1.SymmetricKey), unmanagedIds: make(map[uint32]bool)}
+	input, err := n.Files.ReadFile(n.KeysPath)
+	if os.IsNotExist(err) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, raw := range strings.Split(strings.TrimSuffix(string(input), "\n"), "\n") {
+		line := raw
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			if strings.TrimSpace(raw) != "" && raw != ntpKeysHeader {
+				file.unmanaged = append(file.unmanaged, raw)
+			}
+			continue
+		}
+		keyId, err := strconv.ParseUint(fields[0], 10, 32)
+		if err == nil && len(fields) >= 3 {
+			if keyType, ok := keyTypeAliases[strings.ToUpper(fields[1])]; ok {
+				file.keys[uint32(keyId)] = &v1.SymmetricKey{KeyId: uint32(keyId), Type: keyType, Secret: fields[2]}
+				continue
+			}
+		}
+		if err == nil {
+			file.unmanagedIds[uint32(keyId)] = true
+		}
+		log.Println("Keeping unsupported key", fields[0], "of", n.KeysPath, "unchanged")
+		file.unmanaged = append(file.unmanaged, raw)
+	}
+	return file, nil
+}
+
+func (n *NtpConfigurator) writeKeys(file *keyFile) error {
+	builder := strings.Builder{}
+	builder.WriteString(ntpKeysHeader + "\n")
+	for _, line := range file.unmanaged {
+		builder.WriteString(line + "\n")
+	}
+	for _, key := range sortedKeys(file.keys) {
+		builder.WriteString(fmt.Sprintf("%d %s %s\n", key.GetKeyId(), keyTypeNames[key.GetType()], key.GetSecret()))
+	}
+	// The secrets are only readable by root, also if an existing ntp.keys was readable by others.
+	return n.Files.WriteFileAtomicWithMode(n.KeysPath, []byte(builder.String()), ntpKeysPermissions)
+}
+
+// replaceKeyDirectives writes `keys` and `trustedkey` with all managed key ids before the first server or pool line,
+// both are removed if ntp.keys has no keys. The ids of unmanaged keys stay trusted if they were trusted before.
+func (n *NtpConfigurator) replaceKeyDirectives(file *keyFile) error {
+	return n.rewriteNtpConf(func(lines []string) []string {
+		var kept, trustedUnmanaged []string
+		for _, line := range lines {
+			fields := strings.Fields(line)
+			if len(fields) > 0 && fields[0] == trustedKeyDirective {
+				trustedUnmanaged = append(trustedUnmanaged, unmanagedTrustedKeys(line, file)...)
+			}
+			if len(fields) > 0 && (fields[0] == keysDirective || fields[0] == trustedKeyDirective) {
+				continue
+			}
+			kept = append(kept, line)
+		}
+		if len(file.keys) == 0 && len(file.unmanagedIds) == 0 {
+			return kept
+		}
+		directives := []string{keysDirective + " " + n.KeysPath}
+		trusted := []string{trustedKeyDirective}
+		for _, key := range sortedKeys(file.keys) {
+			trusted = append(trusted, strconv.FormatUint(uint64(key.GetKeyId()), 10))
+		}
+		if trusted = append(trusted, trustedUnmanaged...); len(trusted) > 1 {
+			directives = append(directives, strings.Join(trusted, " "))
+		}
+		return insertBeforeServers(kept, directives...)
+	})
+}
+
+// unmanagedTrustedKeys returns the arguments of a trustedkey line which are no managed key: the ids of unmanaged keys
+// and arguments which are no key id, e.g. the range syntax of ntpsec.
+func unmanagedTrustedKeys(line string, file *keyFile) []string {
+	if i := strings.Index(line, "#"); i >= 0 {
+		line = line[:i]
+	}
+	var kept []string
+	for _, argument := range strings.Fields(line)[1:] {
+		keyId, err := strconv.ParseUint(argument, 10, 32)
+		if err != nil || file.unmanagedIds[uint32(keyId)] {
+			kept = append(kept, argument)
+		}
+	}
+	return kept
+}
+
+func sortedKeys(keys map[uint32]*v1.SymmetricKey) []*v1.SymmetricKey {
+	var sorted []*v1.SymmetricKey
+	for _, key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].GetKeyId() < sorted[j].GetKeyId() })
+	return sorted
+}
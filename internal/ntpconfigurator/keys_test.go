@@ -0,0 +1,189 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tAesSecret = "0123456789abcdef0123456789abcdef"
+
+func prepareNtpConfiguratorWithKeys(t *testing.T, conf string) *NtpConfigurator {
+	tN := prepareNtpConfigurator()
+	dir := t.TempDir()
+	tN.NtpConfPath = filepath.Join(dir, "ntp.conf")
+	tN.KeysPath = filepath.Join(dir, "ntp.keys")
+	tN.HistoryPath = filepath.Join(dir, "history")
+	assert.NoError(t, os.WriteFile(tN.NtpConfPath, []byte(conf), 0644))
+	return tN
+}
+
+func Test_ValidateKey(t *testing.T) {
+	assert.NoError(t, ValidateKey(&v1.SymmetricKey{KeyId: 1, Type: v1.KeyType_MD5, Secret: "plant-secret"}))
+	assert.NoError(t, ValidateKey(&v1.SymmetricKey{KeyId: 65535, Type: v1.KeyType_SHA1, Secret: "0123456789abcdef0123456789abcdef01234567"}))
+	assert.NoError(t, ValidateKey(&v1.SymmetricKey{KeyId: 2, Type: v1.KeyType_AES128CMAC, Secret: tAesSecret}))
+
+	invalid := []*v1.SymmetricKey{
+		nil,
+		{KeyId: 0, Secret: "secret"},
+		{KeyId: 65536, Secret: "secret"},
+		{KeyId: 1, Type: v1.KeyType(9), Secret: "secret"},
+		{KeyId: 1, Secret: ""},
+		{KeyId: 1, Secret: "with space"},
+		{KeyId: 1, Secret: "with#hash"},
+		{KeyId: 1, Secret: "this-is-too-long-for-ascii"},
+		{KeyId: 1, Type: v1.KeyType_AES128CMAC, Secret: "short"},
+	}
+	for _, key := range invalid {
+		assert.ErrorIs(t, ValidateKey(key), ErrInvalidKey, "%v", key)
+	}
+}
+
+func Test_ValidateKey_ErrorDoesNotContainSecret(t *testing.T) {
+	err := ValidateKey(&v1.SymmetricKey{KeyId: 1, Secret: "leaked secret"})
+
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "leaked")
+}
+
+func Test_CreateRotateAndDeleteKeys(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "driftfile /var/lib/ntpsec/ntp.drift\nserver a.example.com\n")
+
+	assert.NoError(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 7, Type: v1.KeyType_SHA1, Secret: "first"}))
+	assert.NoError(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 3, Type: v1.KeyType_AES128CMAC, Secret: tAesSecret}))
+	assert.ErrorIs(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 7, Secret: "again"}), ErrKeyExists)
+	assert.NoError(t, tN.RotateKey(context.Background(), &v1.SymmetricKey{KeyId: 7, Type: v1.KeyType_MD5, Secret: "second"}))
+	assert.ErrorIs(t, tN.RotateKey(context.Background(), &v1.SymmetricKey{KeyId: 8, Secret: "second"}), ErrKeyNotFound)
+
+	keys, err := os.ReadFile(tN.KeysPath)
+	assert.NoError(t, err)
+	assert.Equal(t, ntpKeysHeader+"\n3 AES "+tAesSecret+"\n7 MD5 second\n", string(keys))
+	info, err := os.Stat(tN.KeysPath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	conf, err := os.ReadFile(tN.NtpConfPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "driftfile /var/lib/ntpsec/ntp.drift\nkeys "+tN.KeysPath+"\ntrustedkey 3 7\nserver a.example.com\n", string(conf))
+
+	assert.NoError(t, tN.DeleteKey(context.Background(), 3))
+	assert.NoError(t, tN.DeleteKey(context.Background(), 7))
+	assert.ErrorIs(t, tN.DeleteKey(context.Background(), 7), ErrKeyNotFound)
+
+	conf, err = os.ReadFile(tN.NtpConfPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "driftfile /var/lib/ntpsec/ntp.drift\nserver a.example.com\n", string(conf))
+}
+
+func Test_CreateKey_ExistingKeysFileBecomesPrivate(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "server a.example.com\n")
+	assert.NoError(t, os.WriteFile(tN.KeysPath, []byte("1 MD5 old-secret\n"), 0644))
+	assert.NoError(t, os.Chmod(tN.KeysPath, 0644))
+
+	assert.NoError(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 2, Secret: "new-secret"}))
+
+	info, err := os.Stat(tN.KeysPath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func Test_DeleteKey_InUse(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "server a.example.com key 5\n")
+	assert.NoError(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 5, Secret: "secret"}))
+
+	err := tN.DeleteKey(context.Background(), 5)
+
+	assert.ErrorIs(t, err, ErrKeyInUse)
+	keys, listErr := tN.ListKeys()
+	assert.NoError(t, listErr)
+	assert.Len(t, keys, 1)
+}
+
+func Test_DeleteKey_InUseByPeer(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "server a.example.com key 5\n")
+	assert.NoError(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 6, Secret: "peer-secret"}))
+	assert.NoError(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 7, Secret: "control"}))
+	assert.NoError(t, tN.SetOrphanMode(context.Background(), &v1.OrphanConfig{Stratum: 10, Peers: []*v1.NtpServerEntry{{Address: "sibling.local", KeyId: 6}}}))
+	conf, err := os.ReadFile(tN.NtpConfPath)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(tN.NtpConfPath, append(conf, "controlkey 7\n"...), 0644))
+
+	assert.ErrorIs(t, tN.DeleteKey(context.Background(), 6), ErrKeyInUse)
+	assert.ErrorIs(t, tN.DeleteKey(context.Background(), 7), ErrKeyInUse)
+	keys, err := tN.ListKeys()
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
+
+func Test_keyReference(t *testing.T) {
+	conf := []byte("# server old.example.com key 3\npeer sibling.local key 4\nrefclock shm unit 0 refid GPS\ncontrolkey 8\n")
+
+	line, ok := keyReference(conf, 4)
+	assert.True(t, ok)
+	assert.Equal(t, "peer sibling.local key 4", line)
+	_, ok = keyReference(conf, 8)
+	assert.True(t, ok)
+	_, ok = keyReference(conf, 3)
+	assert.False(t, ok, "Did not get expected result. A commented line does not use the key")
+	_, ok = keyReference(conf, 0)
+	assert.False(t, ok)
+}
+
+func Test_ListKeys_WithoutSecrets(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "server a.example.com\n")
+	assert.NoError(t, os.WriteFile(tN.KeysPath, []byte("# comment\n2 SHA1 sha-secret\n1 md5 md5-secret # inline\nbroken\n9 RMD160 other\n"), 0600))
+
+	keys, err := tN.ListKeys()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*v1.SymmetricKey{{KeyId: 1, Type: v1.KeyType_MD5}, {KeyId: 2, Type: v1.KeyType_SHA1}}, keys)
+}
+
+func Test_CreateAndDeleteKey_KeepUnmanagedKeys(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "keys /etc/ntpsec/ntp.keys\ntrustedkey 1 9\nserver a.example.com key 9\n")
+	sha256 := "9 SHA256 0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef # plant key"
+	assert.NoError(t, os.WriteFile(tN.KeysPath, []byte("1 MD5 md5-secret\n"+sha256+"\nbroken line\n"), 0600))
+
+	assert.NoError(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 5, Secret: "secret"}))
+	assert.ErrorIs(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 9, Secret: "secret"}), ErrKeyExists)
+	assert.NoError(t, tN.ValidateKeyReferences(&v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "a.example.com", KeyId: 9}}}))
+	assert.NoError(t, tN.DeleteKey(context.Background(), 1))
+
+	keys, err := os.ReadFile(tN.KeysPath)
+	assert.NoError(t, err)
+	assert.Equal(t, ntpKeysHeader+"\n"+sha256+"\nbroken line\n5 MD5 secret\n", string(keys), "Did not get expected result. Unmanaged lines must be kept verbatim")
+	conf, err := os.ReadFile(tN.NtpConfPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "keys "+tN.KeysPath+"\ntrustedkey 5 9\nserver a.example.com key 9\n", string(conf))
+}
+
+func Test_ValidateKeyReferences(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "")
+	assert.NoError(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 5, Secret: "secret"}))
+
+	assert.NoError(t, tN.ValidateKeyReferences(&v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "a", KeyId: 5}, {Address: "b"}}}))
+	assert.ErrorIs(t, tN.ValidateKeyReferences(&v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "a", KeyId: 6}}}), ErrKeyNotFound)
+}
+
+func Test_GetCurrentNtpServers_DoesNotReturnSecrets(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "server a.example.com\n")
+	assert.NoError(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 5, Secret: "topsecret"}))
+	assert.NoError(t, tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "a.example.com", KeyId: 5}}}))
+
+	servers, err := tN.GetCurrentNtpServers()
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(5), servers.NtpServerEntries[0].KeyId)
+	assert.False(t, strings.Contains(servers.String(), "topsecret"), "Did not get expected result. Secret returned in %v", servers)
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/sntp"
+)
+
+const leapfileDirective = "leapfile"
+
+// LeapWarningPeriod is the time before the expiry of the leap seconds file from which a new one should be uploaded.
+const LeapWarningPeriod = 30 * 24 * time.Hour
+
+// Tags of the special comment lines of leap-seconds.list.
+const (
+	leapUpdatedTag = "#$"
+	leapExpiresTag = "#@"
+	leapHashTag    = "#h"
+)
+
+// leapHashWords is the number of 32 bit words of the SHA-1 hash in the #h line.
+const leapHashWords = 5
+
+// ErrInvalidLeapFile is returned when the uploaded leap seconds file can not be parsed, its hash does not match or
+// it is expired.
+var ErrInvalidLeapFile = errors.New("invalid leap seconds file")
+
+// Leap is an entry of the leap seconds file, the offset between TAI and UTC valid from its time on.
+type Leap struct {
+	Time      time.Time
+	TaiOffset int
+}
+
+// LeapSeconds is the content of a leap seconds file in the format of the IERS leap-seconds.list.
+type LeapSeconds struct {
+	Updated time.Time
+	Expires time.Time
+	Leaps   []Leap
+}
+
+// ParseLeapSeconds parses a leap-seconds.list and verifies its hash, the SHA-1 of the digits of the update and expiry
+// times and of the data lines.
+func ParseLeapSeconds(content string) (*LeapSeconds, error) {
+	leapSeconds := &LeapSeconds{}
+	hash := sha1.New()
+	var expected string
+	for number, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, leapUpdatedTag), strings.HasPrefix(line, leapExpiresTag):
+			value := strings.TrimSpace(line[len(leapUpdatedTag):])
+			seconds, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid time %q in line %d", ErrInvalidLeapFile, value, number+1)
+			}
+			if strings.HasPrefix(line, leapUpdatedTag) {
+				leapSeconds.Updated = ntpSecondsToTime(seconds)
+			} else {
+				leapSeconds.Expires = ntpSecondsToTime(seconds)
+			}
+			hash.Write([]byte(value))
+		case strings.HasPrefix(line, leapHashTag):
+			words := strings.Fields(line[len(leapHashTag):])
+			if len(words) != leapHashWords {
+				return nil, fmt.Errorf("%w: hash in line %d has not %d words", ErrInvalidLeapFile, number+1, leapHashWords)
+			}
+			for _, word := range words {
+				value, err := strconv.ParseUint(word, 16, 32)
+				if err != nil {
+					return nil, fmt.Errorf("%w: invalid hash word %q in line %d", ErrInvalidLeapFile, word, number+1)
+				}
+				expected += fmt.Sprintf("%08x", value)
+			}
+		case line == "" || strings.HasPrefix(line, "#"):
+		default:
+			data, _, _ := strings.Cut(line, "#")
+			fields := strings.Fields(data)
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("%w: line %d has no time and offset", ErrInvalidLeapFile, number+1)
+			}
+			seconds, err := strconv.ParseUint(fields[0], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid time %q in line %d", ErrInvalidLeapFile, fields[0], number+1)
+			}
+			offset, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid offset %q in line %d", ErrInvalidLeapFile, fields[1], number+1)
+			}
+			leap := Leap{Time: ntpSecondsToTime(seconds), TaiOffset: offset}
+			if count := len(leapSeconds.Leaps); count > 0 && !leap.Time.After(leapSeconds.Leaps[count-1].Time) {
+				return nil, fmt.Errorf("%w: line %d is not after the previous entry", ErrInvalidLeapFile, number+1)
+			}
+			leapSeconds.Leaps = append(leapSeconds.Leaps, leap)
+			hash.Write([]byte(strings.Join(fields, "")))
+		}
+	}
+	if leapSeconds.Expires.IsZero() {
+		return nil, fmt.Errorf("%w: expiry time %s is missing", ErrInvalidLeapFile, leapExpiresTag)
+	}
+	if len(leapSeconds.Leaps) == 0 {
+		return nil, fmt.Errorf("%w: no leap seconds", ErrInvalidLeapFile)
+	}
+	if expected == "" {
+		return nil, fmt.Errorf("%w: hash %s is missing", ErrInvalidLeapFile, leapHashTag)
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != expected {
+		return nil, fmt.Errorf("%w: hash %s does not match the content %s", ErrInvalidLeapFile, expected, actual)
+	}
+	return leapSeconds, nil
+}
+
+// TaiOffset returns the offset between TAI and UTC at the time, 0 before the first entry.
+func (l *LeapSeconds) TaiOffset(t time.Time) int {
+	offset := 0
+	for _, leap := range l.Leaps {
+		if leap.Time.After(t) {
+			break
+		}
+		offset = leap.TaiOffset
+	}
+	return offset
+}
+
+// NextLeap returns the first leap second after the time, false if the file announces none.
+func (l *LeapSeconds) NextLeap(t time.Time) (Leap, bool) {
+	for _, leap := range l.Leaps {
+		if leap.Time.After(t) {
+			return leap, true
+		}
+	}
+	return Leap{}, false
+}
+
+// ValidateLeapSeconds checks that the leap seconds file is valid and not expired at the time.
+func ValidateLeapSeconds(content string, now time.Time) error {
+	leapSeconds, err := ParseLeapSeconds(content)
+	if err != nil {
+		return err
+	}
+	if !leapSeconds.Expires.After(now) {
+		return fmt.Errorf("%w: expired on %s", ErrInvalidLeapFile, leapSeconds.Expires.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// SetLeapSeconds writes the leap seconds file and points the leapfile directive of ntp.conf to it,
+// then ntpsec is restarted. If ntpsec does not restart the previous files are restored and an *ApplyError is returned.
+func (n *NtpConfigurator) SetLeapSeconds(ctx context.Context, content string) error {
+	if err := n.require(FeatureLeapFile); err != nil {
+		return err
+	}
+	if err := ValidateLeapSeconds(content, time.Now()); err != nil {
+		return err
+	}
+	write := func() error {
+		if err := n.Files.WriteFileAtomic(n.LeapPath, []byte(content), ntpConfPermissions); err != nil {
+			return err
+		}
+		return n.rewriteNtpConf(func(lines []string) []string {
+			var kept []string
+			for _, line := range lines {
+				if fields := strings.Fields(line); len(fields) == 0 || fields[0] != leapfileDirective {
+					kept = append(kept, line)
+				}
+			}
+			return insertBeforeServers(kept, leapfileDirective+" "+n.LeapPath)
+		})
+	}
+	return n.applyChange(ctx, write, n.LeapPath)
+}
+
+// GetLeapSeconds returns the leap seconds file of the leapfile directive, empty if none is configured.
+func (n *NtpConfigurator) GetLeapSeconds() (string, error) {
+	path, err := n.leapFilePath()
+	if err != nil || path == "" {
+		return "", err
+	}
+	content, err := n.Files.ReadFile(path)
+	return string(content), err
+}
+
+// leapFilePath returns the path of the leapfile directive of ntp.conf, empty if there is none.
+func (n *NtpConfigurator) leapFilePath() (string, error) {
+	input, err := n.Files.ReadFile(n.NtpConfPath)
+	if err != nil {
+		return "", err
+	}
+	path := ""
+	for _, line := range splitLines(input) {
+		if fields := strings.Fields(line); len(fields) > 1 && fields[0] == leapfileDirective {
+			path = fields[1]
+		}
+	}
+	return path, nil
+}
+
+// checkLeap reports the leap seconds file and the leap indicator of the time daemon, nil if the backend does not
+// read a leap seconds file.
+func (n *NtpConfigurator) checkLeap(system *v1.SystemVariables, now time.Time) *v1.LeapStatus {
+	if !n.Backend.Supports(FeatureLeapFile) {
+		return nil
+	}
+	leap := &v1.LeapStatus{LeapIndicator: system.GetLeap()}
+	path, err := n.leapFilePath()
+	if err != nil {
+		log.Println("Cannot read the leapfile directive:", err.Error())
+		return leap
+	}
+	leap.File = path
+	if path == "" {
+		return leap
+	}
+	content, err := n.Files.ReadFile(path)
+	if err != nil {
+		leap.Error = err.Error()
+		return leap
+	}
+	leapSeconds, err := ParseLeapSeconds(string(content))
+	if err != nil {
+		leap.Error = err.Error()
+		return leap
+	}
+	leap.Updated = leapSeconds.Updated.Format(time.RFC3339)
+	leap.Expires = leapSeconds.Expires.Format(time.RFC3339)
+	leap.Expired = !leapSeconds.Expires.After(now)
+	leap.ExpiresSoon = leapSeconds.Expires.Before(now.Add(LeapWarningPeriod))
+	leap.TaiOffset = int32(leapSeconds.TaiOffset(now))
+	if next, ok := leapSeconds.NextLeap(now); ok {
+		leap.PendingLeap = next.Time.Format(time.RFC3339)
+	}
+	return leap
+}
+
+// WarnLeapExpiry logs a warning if the leap seconds file is expired or expires within the warning period.
+func (n *NtpConfigurator) WarnLeapExpiry(now time.Time) {
+	leap := n.checkLeap(nil, now)
+	switch {
+	case leap == nil || leap.GetFile() == "":
+	case leap.GetError() != "":
+		log.Println("WARNING: leap seconds file", leap.GetFile(), "is not usable:", leap.GetError())
+	case leap.GetExpired():
+		log.Println("WARNING: leap seconds file", leap.GetFile(), "expired on", leap.GetExpires()+", upload a new one")
+	case leap.GetExpiresSoon():
+		log.Println("WARNING: leap seconds file", leap.GetFile(), "expires on", leap.GetExpires()+", upload a new one")
+	}
+}
+
+// ntpSecondsToTime converts seconds since the NTP epoch 1900 as used in leap-seconds.list.
+func ntpSecondsToTime(seconds uint64) time.Time {
+	return sntp.TimestampToTime(seconds << 32).UTC()
+}
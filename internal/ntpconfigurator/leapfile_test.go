@@ -0,0 +1,160 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/servicemanager/servicemanagertest"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// tLeapSeconds announces a leap second on 1 Jul 2035 and expires on 28 Dec 2035.
+const tLeapSeconds = "#\tleap-seconds.list for the tests\n" +
+	"#$\t3960835200\n" +
+	"#@\t4291401600\n" +
+	"#\n" +
+	"3644697600\t36\t# 1 Jul 2015\n" +
+	"3692217600\t37\t# 1 Jan 2017\n" +
+	"4275849600\t38\t# 1 Jul 2035\n" +
+	"#h\t3529d0d3 99609bff 4c546ada 6aaeda40 45eeae77\n"
+
+func prepareNtpConfiguratorWithLeapFile(t *testing.T, conf string) *NtpConfigurator {
+	tN := prepareNtpConfigurator()
+	dir := t.TempDir()
+	tN.NtpConfPath = filepath.Join(dir, "ntp.conf")
+	tN.LeapPath = filepath.Join(dir, "leap-seconds.list")
+	assert.NoError(t, os.WriteFile(tN.NtpConfPath, []byte(conf), 0644))
+	return tN
+}
+
+func Test_ParseLeapSeconds(t *testing.T) {
+	leapSeconds, err := ParseLeapSeconds(tLeapSeconds)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.July, 7, 0, 0, 0, 0, time.UTC), leapSeconds.Updated)
+	assert.Equal(t, time.Date(2035, time.December, 28, 0, 0, 0, 0, time.UTC), leapSeconds.Expires)
+	assert.Len(t, leapSeconds.Leaps, 3)
+	assert.Equal(t, Leap{Time: time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), TaiOffset: 37}, leapSeconds.Leaps[1])
+
+	now := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 37, leapSeconds.TaiOffset(now))
+	assert.Equal(t, 0, leapSeconds.TaiOffset(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	next, ok := leapSeconds.NextLeap(now)
+	assert.True(t, ok)
+	assert.Equal(t, 38, next.TaiOffset)
+	_, ok = leapSeconds.NextLeap(time.Date(2035, time.July, 1, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
+
+func Test_ParseLeapSeconds_Invalid(t *testing.T) {
+	invalid := map[string]string{
+		"hash mismatch":   strings.Replace(tLeapSeconds, "\t38\t", "\t39\t", 1),
+		"hash missing":    strings.Replace(tLeapSeconds, "#h", "#", 1),
+		"short hash":      strings.Replace(tLeapSeconds, " 45eeae77", "", 1),
+		"expiry missing":  strings.Replace(tLeapSeconds, "#@", "#", 1),
+		"invalid expiry":  strings.Replace(tLeapSeconds, "4291401600", "never", 1),
+		"no leap seconds": "#$\t3960835200\n#@\t4291401600\n#h\t0 0 0 0 0\n",
+		"unordered": "#$\t3960835200\n#@\t4291401600\n3692217600\t37\n3644697600\t36\n" +
+			"#h\t0 0 0 0 0\n",
+		"missing offset": strings.Replace(tLeapSeconds, "\t38\t", "\t", 1),
+	}
+	for name, content := range invalid {
+		_, err := ParseLeapSeconds(content)
+		assert.ErrorIs(t, err, ErrInvalidLeapFile, "Did not get expected result for %s", name)
+	}
+}
+
+func Test_ValidateLeapSeconds(t *testing.T) {
+	assert.NoError(t, ValidateLeapSeconds(tLeapSeconds, time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)))
+	assert.ErrorIs(t, ValidateLeapSeconds(tLeapSeconds, time.Date(2036, time.January, 1, 0, 0, 0, 0, time.UTC)), ErrInvalidLeapFile)
+}
+
+func Test_SetAndGetLeapSeconds(t *testing.T) {
+	tN := prepareNtpConfiguratorWithLeapFile(t, "driftfile /var/lib/ntpsec/ntp.drift\nleapfile /usr/share/zoneinfo/leap-seconds.list\nserver 192.168.1.1 iburst\n")
+	services := servicemanagertest.New(NtpSecService)
+	tN.Backend = NewNtpSecBackend(tExecutor{}, services)
+
+	assert.NoError(t, tN.SetLeapSeconds(context.Background(), tLeapSeconds))
+
+	content, err := os.ReadFile(tN.NtpConfPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "driftfile /var/lib/ntpsec/ntp.drift\nleapfile "+tN.LeapPath+"\nserver 192.168.1.1 iburst\n", string(content))
+	assert.Equal(t, []string{"restart ntpsec", "status ntpsec"}, services.Calls())
+	leapSeconds, err := tN.GetLeapSeconds()
+	assert.NoError(t, err)
+	assert.Equal(t, tLeapSeconds, leapSeconds)
+}
+
+func Test_SetLeapSeconds_Invalid(t *testing.T) {
+	conf := "leapfile /usr/share/zoneinfo/leap-seconds.list\nserver 192.168.1.1 iburst\n"
+	tN := prepareNtpConfiguratorWithLeapFile(t, conf)
+
+	err := tN.SetLeapSeconds(context.Background(), strings.Replace(tLeapSeconds, "\t38\t", "\t39\t", 1))
+
+	assert.ErrorIs(t, err, ErrInvalidLeapFile)
+	content, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, conf, string(content))
+	_, statErr := os.Stat(tN.LeapPath)
+	assert.True(t, os.IsNotExist(statErr), "Did not get expected result. Wanted no leap seconds file, got: %v", statErr)
+}
+
+func Test_SetLeapSeconds_NotSupported(t *testing.T) {
+	tN := prepareNtpConfiguratorWithLeapFile(t, "server 192.168.1.1 iburst\n")
+	tN.Backend = NewChronyBackend(tExecutor{}, servicemanagertest.New(ChronyService))
+
+	assert.ErrorIs(t, tN.SetLeapSeconds(context.Background(), tLeapSeconds), ErrNotSupported)
+}
+
+func Test_GetLeapSeconds_NoLeapfile(t *testing.T) {
+	tN := prepareNtpConfiguratorWithLeapFile(t, "server 192.168.1.1 iburst\n")
+
+	leapSeconds, err := tN.GetLeapSeconds()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", leapSeconds)
+}
+
+func Test_checkLeap(t *testing.T) {
+	tN := prepareNtpConfiguratorWithLeapFile(t, "server 192.168.1.1 iburst\n")
+	system := &v1.SystemVariables{Leap: 1}
+	now := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+	leap := tN.checkLeap(system, now)
+	expected := &v1.LeapStatus{LeapIndicator: 1}
+	assert.True(t, proto.Equal(expected, leap), "Did not get expected result. Wanted: %v, got: %v", expected, leap)
+
+	assert.NoError(t, os.WriteFile(tN.NtpConfPath, []byte("leapfile "+tN.LeapPath+"\n"), 0644))
+	assert.NoError(t, os.WriteFile(tN.LeapPath, []byte(tLeapSeconds), 0644))
+	leap = tN.checkLeap(system, now)
+	expected = &v1.LeapStatus{File: tN.LeapPath, Updated: "2025-07-07T00:00:00Z", Expires: "2035-12-28T00:00:00Z",
+		TaiOffset: 37, PendingLeap: "2035-07-01T00:00:00Z", LeapIndicator: 1}
+	assert.True(t, proto.Equal(expected, leap), "Did not get expected result. Wanted: %v, got: %v", expected, leap)
+
+	leap = tN.checkLeap(system, time.Date(2035, time.December, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, leap.ExpiresSoon)
+	assert.False(t, leap.Expired)
+	assert.Equal(t, int32(38), leap.TaiOffset)
+	assert.Equal(t, "", leap.PendingLeap)
+	leap = tN.checkLeap(system, time.Date(2036, time.January, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, leap.Expired)
+
+	assert.NoError(t, os.WriteFile(tN.LeapPath, []byte("garbage"), 0644))
+	leap = tN.checkLeap(system, now)
+	assert.Contains(t, leap.Error, ErrInvalidLeapFile.Error())
+
+	tN.Backend = NewChronyBackend(tExecutor{}, servicemanagertest.New(ChronyService))
+	assert.Nil(t, tN.checkLeap(system, now))
+}
@@ -7,229 +7,364 @@
 package ntpconfigurator
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"log"
 	"os"
-	"os/exec"
-	"strconv"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+	"ntpservice/internal/serviceconfig"
+	"ntpservice/internal/servicemanager"
+	"ntpservice/utils/executor"
+	"ntpservice/utils/files"
 )
 
-// Utils struct
-type Utils interface {
-	Commander(command string) ([]byte, error)
-}
-
-// OsUtils struct
-type OsUtils struct{}
-
-// Commander do shell command.
-func (o OsUtils) Commander(command string) ([]byte, error) {
-	out, err := exec.Command(shell, "-c", command).Output()
-	return out, err
+// ControlClient reads the associations of ntpd with the NTP control protocol.
+type ControlClient interface {
+	Peers(ctx context.Context) ([]ntpcontrol.Peer, error)
+	ReadVariables(ctx context.Context, associationID uint16, names ...string) (ntpcontrol.Variables, error)
 }
 
 // NtpConfigurator struct
 type NtpConfigurator struct {
-	Ut Utils
-	ConfigPath string
+	Backend      Backend
+	Files        files.FileUtil
+	ConfigPath   string
+	NtpConfPath  string
+	NtsCaPath    string
+	LeapPath     string
+	KeysPath     string
+	HistoryPath  string
+	HistoryLimit int
 }
 
-const shell = "bash"
-const ntpSecConfigPath = "/etc/ntpsec/ntp.conf"
-const NtpLastConfigPath = "/etc/iedk/lastntpconfigdate.rec"
+const ntpSecConfigPath = serviceconfig.DefaultNtpSecConfPath
+const NtpLastConfigPath = serviceconfig.DefaultLastConfigPath
+
+// LastConfigTimeLayout is the layout of the local time written to NtpLastConfigPath.
+const LastConfigTimeLayout = "2006.01.02 15:04:05"
 const DefaultResourcePermissions = 0666
-const ntpSecCheckRunning = "/usr/bin/systemctl is-active --quiet ntpsec"
-const ntpCheckPeers = "ntpq -pn"
-const StartNtpSecService = "/usr/bin/systemctl start ntpsec.service"
-const StopNtpSecService = "/usr/bin/systemctl stop ntpsec.service"
+
+// ntpConfPermissions are the permissions of a newly created ntp.conf and CA bundle.
+const ntpConfPermissions = 0644
+
+// confDirPermissions are the permissions of a created drop-in directory.
+const confDirPermissions = 0755
+
+// NtpSecService is the name of the ntpsec service.
+const NtpSecService = "ntpsec"
 
 // UpdateSystemTimeCmd If the servers are not reachable `ntpd -gq` will never end,
-// this will block ntpservice indefinitely, `timeout` used to prevent this behavior
-const UpdateSystemTimeCmd = "timeout 20 ntpd -gq"
+// this will block ntpservice indefinitely, the step timeout is used to prevent this behavior
+var UpdateSystemTimeCmd = executor.NewCommand(stepTimeout, "ntpd", "-gq")
+
 const CommanderError = "Command(): Error command failed!"
 
+// ntpConfMutex serializes the modifications of ntp.conf.
+var ntpConfMutex sync.Mutex
 
 // NewNtpConfigurator It returns a value of type *NtpConfigurator.
-func NewNtpConfigurator(utVal Utils) *NtpConfigurator {
+// The time daemon is selected with daemon.backend of the service configuration, without it the installed one is
+// detected, paths.ntpConf is taken as the ntp.conf of ntpsec for the detection.
+// The paths of the service configuration replace the defaults, its daemon settings are applied to the backend.
+func NewNtpConfigurator(ex executor.Executor, config *serviceconfig.Config) *NtpConfigurator {
+	services := servicemanager.FromEnv(ex)
+	var backend Backend
+	switch config.Daemon.Backend {
+	case "", AutoBackendName:
+		backend = detectBackend(ex, services, config.NtpSecConfPath(), chronyConfigPath, timesyncdMainConfigPath)
+	default:
+		var err error
+		if backend, err = NewBackend(config.Daemon.Backend, ex, services); err != nil {
+			log.Println("Detecting the time daemon:", err.Error())
+			backend = detectBackend(ex, services, config.NtpSecConfPath(), chronyConfigPath, timesyncdMainConfigPath)
+		}
+	}
+	log.Println("Time daemon backend:", backend.Name())
+	if daemon, ok := backend.(daemonBackend); ok {
+		daemon.setDaemon(Daemon{Service: config.Daemon.Unit, StepTimeout: config.Daemon.StepTimeout})
+	}
+	ntpConfPath := backend.ConfPath()
+	if config.Paths.NtpConf != "" {
+		ntpConfPath = config.Paths.NtpConf
+	}
 	var ntpconfigurator = NtpConfigurator{
-		Ut:         utVal,
-		ConfigPath: NtpLastConfigPath,
+		Backend:      backend,
+		Files:        &files.OsFileUtils{FileSystemOperations: &files.OsFileSystemOperations{}},
+		ConfigPath:   config.Paths.LastConfig,
+		NtpConfPath:  ntpConfPath,
+		NtsCaPath:    config.Paths.NtsCaBundle,
+		LeapPath:     config.Paths.LeapSeconds,
+		KeysPath:     config.Paths.NtpKeys,
+		HistoryPath:  config.Paths.History,
+		HistoryLimit: DefaultHistoryLimit,
 	}
 	return &ntpconfigurator
 }
 
-// ReplaceCurrentNtpServersOrPools Deletes the lines starting with pool and server prefixes in /etc/ntpsec/ntp.conf file and all blank lines in the file.
-func (n *NtpConfigurator) ReplaceCurrentNtpServersOrPools(serverList []string) {
-	file, err := os.Open(ntpSecConfigPath)
+// ReplaceCurrentNtpServersOrPools replaces the servers in the configuration file of the time daemon.
+// For ntp.conf the lines starting with pool and server prefixes are deleted, the plain ntpServer addresses are written
+// first, followed by the typed ntpServerEntries rendered with their options.
+func (n *NtpConfigurator) ReplaceCurrentNtpServersOrPools(config *v1.Ntp) error {
+	ntpConfMutex.Lock()
+	defer ntpConfMutex.Unlock()
+	return n.replaceServers(config)
+}
+
+// replaceServers is ReplaceCurrentNtpServersOrPools for callers holding ntpConfMutex.
+func (n *NtpConfigurator) replaceServers(config *v1.Ntp) error {
+	conf, err := n.readConf()
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-	defer file.Close()
-	builder := strings.Builder{}
+	if conf == nil {
+		if err := os.MkdirAll(filepath.Dir(n.NtpConfPath), confDirPermissions); err != nil {
+			return err
+		}
+	}
+	// Changes are rewritten to the configuration file, e.g. /etc/ntpsec/ntp.conf.
+	return n.Files.WriteFileAtomic(n.NtpConfPath, n.Backend.ReplaceServers(conf, config), ntpConfPermissions)
+}
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if !strings.HasPrefix(line, "pool") && !strings.HasPrefix(line, "server") {
+// readConf reads the configuration file, nil if the drop-in of a backend with an optional configuration is missing.
+func (n *NtpConfigurator) readConf() ([]byte, error) {
+	conf, err := n.Files.ReadFile(n.NtpConfPath)
+	if os.IsNotExist(err) {
+		if optional, ok := n.Backend.(optionalConf); ok && optional.OptionalConf() {
+			return nil, nil
+		}
+	}
+	return conf, err
+}
+
+// replaceServerLines returns the ntp.conf with the server and pool lines of config instead of the current ones.
+func replaceServerLines(conf []byte, config *v1.Ntp, render func(entry *v1.NtpServerEntry) string) []byte {
+	builder := strings.Builder{}
+	for _, line := range splitLines(conf) {
+		// reference clocks in the classic server 127.127.t.u syntax are no network servers and are kept.
+		if isLegacyRefclock(strings.Fields(line)) || (!strings.HasPrefix(line, "pool") && !strings.HasPrefix(line, "server")) {
 			builder.WriteString(line)
 			builder.WriteString("\n")
 		}
 	}
-	for _, val := range serverList {
-		builder.WriteString("server " + val + "\n")
+	for _, line := range serverLines(config, render) {
+		builder.WriteString(line + "\n")
 	}
-	output := builder.String()
+	return []byte(builder.String())
+}
 
-	// Changes are rewritten to /etc/ntpsec/ntp.conf file.
-	err = os.WriteFile(ntpSecConfigPath, []byte(output), 0644)
-	if err != nil {
-		log.Fatalln(err)
+// serverLines returns the server lines of the configuration, a typed entry replaces a plain address with the same
+// address and a plain address is written once. The address of a plain value is its first field, so the result of
+// GetNtpServer can be sent back unchanged.
+func serverLines(config *v1.Ntp, render func(entry *v1.NtpServerEntry) string) []string {
+	var lines []string
+	seen := make(map[string]bool)
+	for _, entry := range config.GetNtpServerEntries() {
+		seen[entry.GetAddress()] = true
+	}
+	for _, val := range config.GetNtpServer() {
+		fields := strings.Fields(val)
+		if len(fields) == 0 || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		lines = append(lines, serverDirective+" "+strings.Join(fields, " "))
+	}
+	for _, entry := range config.GetNtpServerEntries() {
+		lines = append(lines, render(entry))
 	}
+	return lines
 }
 
-// WriteConfiguration The configurations sent by the client are tested and written to /etc/ntpsec/ntp.conf file. Then the ntp service is restarted.
-func (n *NtpConfigurator) WriteConfiguration(serverList []string) error {
-	n.ReplaceCurrentNtpServersOrPools(serverList)
-
-	err := UpdateSystemTime(n.Ut)
+// rewriteNtpConf replaces the lines of ntp.conf with the lines returned by edit, it is called by the write of
+// applyChange which holds ntpConfMutex.
+func (n *NtpConfigurator) rewriteNtpConf(edit func(lines []string) []string) error {
+	input, err := n.Files.ReadFile(n.NtpConfPath)
 	if err != nil {
-		log.Printf("Could not update system time! : %s", err.Error())
-	} else {
-		log.Printf("Command(`%s`) execution is successfull and system time updated", UpdateSystemTimeCmd)
+		return err
 	}
-	return nil
+	lines := edit(strings.Split(strings.TrimSuffix(string(input), "\n"), "\n"))
+	return n.Files.WriteFileAtomic(n.NtpConfPath, []byte(strings.Join(lines, "\n")+"\n"), ntpConfPermissions)
+}
+
+// insertBeforeServers inserts the directives before the first server or pool line, they are appended if there is none.
+func insertBeforeServers(lines []string, directives ...string) []string {
+	position := len(lines)
+	for i, line := range lines {
+		if strings.HasPrefix(line, serverDirective) || strings.HasPrefix(line, poolDirective) {
+			position = i
+			break
+		}
+	}
+	return append(lines[:position:position], append(directives, lines[position:]...)...)
 }
 
-func UpdateSystemTime(cmdUtils Utils) error {
-	if _, err := cmdUtils.Commander(StopNtpSecService); err != nil {
-		log.Println(CommanderError, StopNtpSecService, err)
+// WriteConfiguration The configurations sent by the client are tested and written to /etc/ntpsec/ntp.conf file. Then the ntp service is restarted.
+// If a step after the validation fails an *ApplyError is returned and the previous ntp.conf is restored.
+// Every written ntp.conf is recorded in the configuration history with the result of the apply.
+// The request ctx bounds the apply until the time daemon is stopped.
+func (n *NtpConfigurator) WriteConfiguration(ctx context.Context, config *v1.Ntp) error {
+	if err := ValidateNtpServers(config); err != nil {
 		return err
 	}
-	if _, err := cmdUtils.Commander(UpdateSystemTimeCmd); err != nil {
-		log.Println(CommanderError, UpdateSystemTimeCmd, err)
+	if err := n.ValidateBackend(config); err != nil {
 		return err
 	}
-	if _, err := cmdUtils.Commander(StartNtpSecService); err != nil {
-		log.Println(CommanderError, StartNtpSecService, err)
+	if err := n.ValidateKeyReferences(config); err != nil {
 		return err
 	}
-	return nil
+	write := func() error {
+		return n.replaceServers(config)
+	}
+	attempted, err := n.applyConfiguration(ctx, write, time.Duration(config.GetSyncTimeoutMs())*time.Millisecond)
+	if attempted != nil {
+		n.recordRevision(attempted, err, 0)
+	}
+	return err
 }
 
-// GetCurrentNtpServers Lines starting with the server prefix in the /etc/ntpsec/ntp.conf file are sent to the client.
-func (n *NtpConfigurator) GetCurrentNtpServers() ([]string, error) {
-	var ntpServers []string
-	// The contents of /etc/ntpsec/ntp.conf file in the device are read.
-	input, err := os.ReadFile(ntpSecConfigPath)
-	if err != nil {
-		log.Fatalln(err)
+// ValidateNtpServers checks the typed entries and the sync timeout of the configuration, peers are only set with
+// SetOrphanMode.
+func ValidateNtpServers(config *v1.Ntp) error {
+	if err := ValidateSyncTimeout(config.GetSyncTimeoutMs()); err != nil {
+		return err
 	}
-	lines := strings.Split(string(input), "\n")
-	for i, line := range lines {
-		// only lines with a server prefix are taken.
-		if strings.HasPrefix(line, "server") {
-			ntpServers = append(ntpServers, strings.TrimLeft(lines[i], "server "))
+	for _, entry := range config.GetNtpServerEntries() {
+		if err := ValidateServerEntry(entry); err != nil {
+			return err
+		}
+		if entry.GetKind() == v1.NtpEntryKind_PEER {
+			return fmt.Errorf("%w: peer %s must be set with SetOrphanMode", ErrInvalidServerEntry, entry.GetAddress())
 		}
 	}
-	return ntpServers, err
+	for _, address := range config.GetFallbackNtpServer() {
+		if address == "" || strings.ContainsAny(address, " \t\r\n#") {
+			return fmt.Errorf("%w: fallback address %q is not a single host name or ip address", ErrInvalidServerEntry, address)
+		}
+	}
+	return nil
 }
 
-// GetNtpStatus is used for checking Ntp running, peers and last configuration times.
-func (n *NtpConfigurator) GetNtpStatus() (*v1.Status, error) {
+// ValidateBackend checks that the time daemon can be configured with the options of the configuration.
+func (n *NtpConfigurator) ValidateBackend(config *v1.Ntp) error {
+	if len(config.GetFallbackNtpServer()) > 0 {
+		if err := n.require(FeatureFallback); err != nil {
+			return err
+		}
+	}
+	for _, entry := range config.GetNtpServerEntries() {
+		if entry.GetNts() {
+			if err := n.require(FeatureNts); err != nil {
+				return err
+			}
+		}
+	}
+	validator, ok := n.Backend.(entryValidator)
+	if !ok {
+		return nil
+	}
+	for _, entry := range config.GetNtpServerEntries() {
+		if err := validator.ValidateEntry(entry); err != nil {
+			return err
+		}
+	}
+	// plain addresses may carry options, they are written as they are.
+	for _, val := range config.GetNtpServer() {
+		entry, err := ParseServerEntry(serverDirective + " " + val)
+		if err != nil {
+			continue
+		}
+		if err := validator.ValidateEntry(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	status := &v1.Status{}
-	var err error
+// UpdateSystemTime stops ntpsec, sets the system time once with `ntpd -gq` and starts ntpsec again.
+func UpdateSystemTime(ctx context.Context, ex executor.Executor, services servicemanager.ServiceManager, daemon Daemon) error {
+	service := daemon.service(NtpSecService)
+	if err := services.Stop(ctx, service); err != nil {
+		return err
+	}
+	command := daemon.step(UpdateSystemTimeCmd)
+	if _, err := ex.Run(ctx, command); err != nil {
+		log.Println(CommanderError, command, err)
+		return err
+	}
+	return services.Start(ctx, service)
+}
 
-	status.IsNtpServiceRunning, err = n.checkRunning(ntpSecCheckRunning)
-	status.PeerDetails, err = n.checkSynced()
-	status.IsSynced, status.LastSyncTime, err = n.getSyncedTime(status.PeerDetails)
-	status.LastConfigurationTime, err = n.checkLastConfiguredOn()
-	return status, err
+// GetCurrentNtpServers Lines starting with the server or pool prefix in the /etc/ntpsec/ntp.conf file are sent to the client.
+// Server lines are returned as plain address without options, server and pool lines are returned as typed entries.
+func (n *NtpConfigurator) GetCurrentNtpServers() (*v1.Ntp, error) {
+	ntpServers, err := n.readNtpConf()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return ntpServers, err
 }
 
-// ntpStatusCheckRunning Check ntp service is running or not with command 'systemctl is-active --quiet ntp'
-func (n *NtpConfigurator) checkRunning(ntpCheckRunning string) (bool, error) {
-	var IsNtpServiceRunning = true
-	command := ntpCheckRunning
-	_, err := n.Ut.Commander(command)
+// readNtpConf reads the servers of the configuration file of the time daemon.
+func (n *NtpConfigurator) readNtpConf() (*v1.Ntp, error) {
+	// The contents of the configuration file in the device are read, e.g. /etc/ntpsec/ntp.conf.
+	conf, err := n.readConf()
 	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
-			IsNtpServiceRunning = false
-			log.Println("systemctl exit code is ", exitError.ExitCode())
+		return &v1.Ntp{}, err
+	}
+	return n.Backend.ReadServers(conf), nil
+}
+
+// parseServerLines returns the server and pool lines of an ntp.conf.
+func parseServerLines(conf []byte, parse func(line string) (*v1.NtpServerEntry, error)) *v1.Ntp {
+	ntpServers := &v1.Ntp{}
+	for _, line := range splitLines(conf) {
+		// only lines with a server or pool prefix are taken.
+		fields := strings.Fields(line)
+		if len(fields) < 2 || (fields[0] != serverDirective && fields[0] != poolDirective) || isLegacyRefclock(fields) {
+			continue
+		}
+		if fields[0] == serverDirective {
+			ntpServers.NtpServer = append(ntpServers.NtpServer, fields[1])
+		}
+		entry, parseErr := parse(line)
+		if parseErr != nil {
+			log.Println("Skipping server entry:", parseErr.Error())
+			continue
 		}
+		ntpServers.NtpServerEntries = append(ntpServers.NtpServerEntries, entry)
 	}
-	log.Println("IsNtpServiceRunning-->", IsNtpServiceRunning)
-	return IsNtpServiceRunning, nil
+	return ntpServers
 }
 
-// ntpStatusCheckSynced Check all ntp remote server peerings conditions with sync time with command 'ntpq -pn'.
-func (n *NtpConfigurator) checkSynced() ([]*v1.PeerDetails, error) {
+// GetSyncStatus is used for checking Ntp running, peers, the NTS state and last configuration times, it is cheap
+// enough to be polled.
+func (n *NtpConfigurator) GetSyncStatus(ctx context.Context) (*v1.Status, error) {
 
-	var out []byte
+	status := &v1.Status{}
 	var err error
-	var PeerDetails []*v1.PeerDetails
-	command := ntpCheckPeers
-	out, err = n.Ut.Commander(command)
-	if err != nil {
-		log.Println(CommanderError, command, err)
-		return PeerDetails, nil
-	}
-	log.Println("Command(): ", command, "-> out:\n", string(out))
-	scanner := bufio.NewScanner(strings.NewReader(string(out)))
-	scanner.Split(bufio.ScanLines)
-	totalLineCount := 0
-	for scanner.Scan() {
-		totalLineCount++
-	}
-	peersCount := totalLineCount - 2
-	if peersCount > 0 {
-		log.Println("Number of peers--> ", peersCount)
-		scanner := bufio.NewScanner(strings.NewReader(string(out)))
-		var PeerDetailsDummy *v1.PeerDetails
-		PeerDetailsDummy = &v1.PeerDetails{} // Sets the pointer
-		index := 0
-		for scanner.Scan() {
-			index++
-			if index < 3 {
-				continue
-			}
-			address := strings.Fields(scanner.Text())
-			PeerDetailsDummy, _ = n.parseNtpPeer(address)
-			PeerDetails = append(PeerDetails, PeerDetailsDummy)
 
+	status.IsNtpServiceRunning = n.Backend.Active(ctx)
+	peers := n.readPeers(ctx)
+	status.PeerDetails = toPeerDetails(peers, time.Now())
+	status.IsSynced, status.LastSyncTime, err = n.getSyncedTime(status.PeerDetails)
+	status.LastConfigurationTime, err = n.checkLastConfiguredOn()
+	status.Pools = n.checkPools(peers)
+	status.Nts = n.checkNts(peers)
+	if n.Backend.Supports(FeatureServing) {
+		if serving, servingErr := n.GetServing(); servingErr == nil {
+			status.Serving = serving
 		}
-	} else {
-		peersCount = 0
 	}
-	return PeerDetails, nil
-}
-
-// parseNtpPeer adjust all peer line elements
-func (n *NtpConfigurator) parseNtpPeer(peer []string) (*v1.PeerDetails, error) {
-	PeerDetailsDummy := &v1.PeerDetails{}
-	var intValue int64
-	var floatValue float64
-	if len(peer) > 2 {
-		PeerDetailsDummy.RemoteServer = peer[0]
-		PeerDetailsDummy.ReferenceID = peer[1]
-		PeerDetailsDummy.Stratum = peer[2]
-		PeerDetailsDummy.Type = peer[3]
-		PeerDetailsDummy.When, _ = n.getNtpPeerWhenValue(peer)
-		intValue, _ = strconv.ParseInt(peer[5], 10, 32)
-		PeerDetailsDummy.Poll = int32(intValue)
-		PeerDetailsDummy.Reach = peer[6]
-		floatValue, _ = strconv.ParseFloat(peer[7], 8)
-		PeerDetailsDummy.Delay = float32(floatValue)
-		floatValue, _ = strconv.ParseFloat(peer[8], 8)
-		PeerDetailsDummy.Offset = float32(floatValue)
-		floatValue, _ = strconv.ParseFloat(peer[9], 8)
-		PeerDetailsDummy.Jitter = float32(floatValue)
-	}
-	return PeerDetailsDummy, nil
+	status.Orphan = n.checkOrphan(ctx)
+	status.SystemVariables = n.readSystemVariables(ctx)
+	status.Leap = n.checkLeap(status.SystemVariables, time.Now())
+	return status, err
 }
 
 // ntpStatusGetSyncedTime check when parameters to set synced and lastsynced time.
@@ -239,7 +374,7 @@ func (n *NtpConfigurator) getSyncedTime(PeerDetails []*v1.PeerDetails) (bool, st
 	var LastSyncTime = " "
 	if len(PeerDetails) > 0 {
 		for i := 0; i < len(PeerDetails); i++ {
-			if PeerDetails[i].When > 0 && PeerDetails[i].RemoteServer[:1] == "*" {
+			if PeerDetails[i].When > 0 && strings.HasPrefix(PeerDetails[i].RemoteServer, "*") {
 				IsSynced = true
 				t := time.Now()
 				newT := t.Add(time.Duration(-PeerDetails[i].When) * time.Second)
@@ -247,23 +382,9 @@ func (n *NtpConfigurator) getSyncedTime(PeerDetails []*v1.PeerDetails) (bool, st
 			}
 		}
 	}
-	log.Println("IsSynced-->", IsSynced)
-	log.Println("LastSyncTime-->", LastSyncTime)
 	return IsSynced, LastSyncTime, nil
 }
 
-// GetNtpPeerWhenValue , check character '*' in peer line.
-func (n *NtpConfigurator) getNtpPeerWhenValue(PeerLine []string) (int32, error) {
-	var intValue int64
-	var whenValue int32
-	whenValue = 0
-	if PeerLine[4] != " -" {
-		intValue, _ = strconv.ParseInt(PeerLine[4], 10, 32)
-		whenValue = int32(intValue)
-	}
-	return whenValue, nil
-}
-
 // ntpStatusCheckLastConfiguredOn get first ntp setting time from file.
 func (n *NtpConfigurator) checkLastConfiguredOn() (string, error) {
 	_, err := os.Stat(n.ConfigPath)
@@ -271,16 +392,15 @@ func (n *NtpConfigurator) checkLastConfiguredOn() (string, error) {
 		if os.IsNotExist(err) {
 			return "", nil
 		}
-        log.Println("Stat returns error:", err.Error())
+		log.Println("Stat returns error:", err.Error())
 		return "", err
 	}
 
-	data, err := os.ReadFile(n.ConfigPath)
+	data, err := n.Files.ReadFile(n.ConfigPath)
 	if err != nil {
 		log.Println("ReadFile returns error:", err.Error())
 		return "", err
 	}
 
-	log.Println("LastConfigurationTime-->", string(data))
 	return string(data), nil
 }
@@ -7,71 +7,67 @@
 package ntpconfigurator
 
 import (
+	"context"
 	"errors"
 	"log"
-	"os"
 	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/serviceconfig"
+	"ntpservice/internal/servicemanager"
+	"ntpservice/internal/servicemanager/servicemanagertest"
+	"ntpservice/utils/executor"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
-type tOsUtils struct{}
+type tExecutor struct{}
 
-type tOsUtilsStatus struct{}
+type tStatusExecutor struct{}
 
-func (o tOsUtils) Commander(command string) ([]byte, error) {
+func (o tExecutor) Run(ctx context.Context, command executor.Command) ([]byte, error) {
 	//out, err := exec.Command(Shell, "-c", command).Output()
 	dummyStr := []byte("commander called")
 	return dummyStr, nil
 }
 
-func (o tOsUtilsStatus) Commander(command string) ([]byte, error) {
+func (o tStatusExecutor) Run(ctx context.Context, command executor.Command) ([]byte, error) {
 	var retval string
 	var err error
-	if command == "systemctl is-active --quiet ntpsec" {
+	if command.String() == "/usr/bin/systemctl is-active --quiet ntpsec" {
 		retval = " "
 		err = errors.New("\nError system is active")
-	} else if command == "systemctl is-active --quiet ntpXYZ" {
+	} else if command.String() == "/usr/bin/systemctl is-active --quiet ntpXYZ" {
 		retval = " "
 		err = errors.New("\nError system is active")
-
-	} else if command == "ntpq -pn" {
-		retval = "      remote           refid      st t when poll reach   delay   offset  jitter" +
-			"==============================================================================" +
-			"0.debian.pool.n .POOL.          16 p    -   64    0    0.000    0.000   0.000" +
-			"*193.30.121.7    131.188.3.223    2 u   35  256  377   64.087   40.219  13.950" +
-			"+198.251.86.68   82.64.45.50      2 u   62  256  377   74.710   29.980  21.180"
-		err = nil
-	} else if command == "mkdir -p /etc/ntpsec && touch /etc/ntpsec/ntp.conf" {
-		err = errors.New("\nError system is active")
 	}
 
 	return []byte(retval), err
 }
 
 func prepareNtpConfigurator() *NtpConfigurator {
-	var tUt Utils = tOsUtils{}
-	tN := NewNtpConfigurator(tUt)
+	var tUt executor.Executor = tExecutor{}
+	tN := NewNtpConfigurator(tUt, serviceconfig.Default())
+	tN.Backend = NewNtpSecBackend(tUt, servicemanagertest.New(NtpSecService))
+	tN.NtpConfPath = ntpSecConfigPath
 
 	return tN
 }
 
 func Test_WriteConfiguration_WithValidArgument(t *testing.T) {
-	tServerList := []string{"99.tr.pool.ntp.org"}
-
-	tN := prepareNtpConfigurator()
+	tServerList := &v1.Ntp{NtpServer: []string{"99.tr.pool.ntp.org"}}
 
-	err := exec.Command("bash", "-c", "mkdir -p /etc/ntpsec && touch /etc/ntpsec/ntp.conf").Run()
-	if err != nil {
-		//assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
-	}
-	err2 := tN.WriteConfiguration(tServerList)
+	tN := prepareNtpConfiguratorWithKeys(t, "driftfile /var/lib/ntpsec/ntp.drift\n")
 
-	assert.Nil(t, err2, "Did not get expected result. Wanted: Nil, got: %q", err)
+	err := tN.WriteConfiguration(context.Background(), tServerList)
 
+	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "driftfile /var/lib/ntpsec/ntp.drift\nserver 99.tr.pool.ntp.org\n", string(conf))
 }
 
 func Test_GetCurrentNtpServers(t *testing.T) {
@@ -86,74 +82,33 @@ func Test_GetCurrentNtpServers(t *testing.T) {
 
 }
 
-func Test_GetNtpStatus(t *testing.T) {
-	var tUt Utils = tOsUtilsStatus{}
-	tN := NewNtpConfigurator(tUt)
-	_, err2 := tN.GetNtpStatus()
+func Test_GetSyncStatus(t *testing.T) {
+	var tUt executor.Executor = tStatusExecutor{}
+	tN := NewNtpConfigurator(tUt, serviceconfig.Default())
+	tN.Backend = &NtpSecBackend{Exec: tUt, Services: servicemanagertest.New(NtpSecService), Control: startFakeNtpd(t)}
+	status, err2 := tN.GetSyncStatus(context.Background())
 	assert.Nil(t, err2, "Did not get expected result. Wanted: Nil, got: %q", err2)
-
-}
-
-func Test_ntpStatusCheckSynced(t *testing.T) {
-	var tUt Utils = tOsUtilsStatus{}
-	tN := NewNtpConfigurator(tUt)
-	_, err := tN.checkSynced()
-	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
-
-}
-
-func Test_parseNtpPeer(t *testing.T) {
-	var tUt Utils = tOsUtilsStatus{}
-	tN := NewNtpConfigurator(tUt)
-	scanner := "*193.30.121.7    131.188.3.223    2 u   12  256  377   64.087   40.219  13.950"
-	address := strings.Fields(scanner)
-	PeerDetailsDummy, err := tN.parseNtpPeer(address)
-	log.Println("WhenValue-->", PeerDetailsDummy)
-	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
-
-}
-
-func Test_getNtpPeerWhenValueNonzero(t *testing.T) {
-	var tUt Utils = tOsUtilsStatus{}
-	tN := NewNtpConfigurator(tUt)
-	scanner := "*193.30.121.7    131.188.3.223    2 u   35  256  377   64.087   40.219  13.950"
-	address := strings.Fields(scanner)
-	WhenValue, err := tN.getNtpPeerWhenValue(address)
-	log.Println("WhenValue-->", WhenValue)
-	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
-}
-
-func Test_getNtpPeerWhenValueZero(t *testing.T) {
-	var tUt Utils = tOsUtilsStatus{}
-	tN := NewNtpConfigurator(tUt)
-	scanner := "*193.30.121.7    131.188.3.223    2 u   -  256  377   64.087   40.219  13.950"
-	address := strings.Fields(scanner)
-	WhenValue, err := tN.getNtpPeerWhenValue(address)
-	log.Println("WhenValue-->", WhenValue)
-	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
+	assert.Len(t, status.PeerDetails, 3)
+	assert.True(t, status.IsSynced)
 }
 
 func Test_ntpStatusCheckRunning_WithValid(t *testing.T) {
-	var tUt Utils = tOsUtilsStatus{}
-	tN := NewNtpConfigurator(tUt)
-	var ntpCheckRunning = "systemctl is-active --quiet ntp"
-	_, err := tN.checkRunning(ntpCheckRunning)
-	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
+	var tUt executor.Executor = tStatusExecutor{}
+	isActive := (&servicemanager.Systemd{Exec: tUt}).Active(context.Background(), "ntp")
+	assert.True(t, isActive, "Did not get expected result. Wanted: true, got: %v", isActive)
 
 }
 
 func Test_ntpStatusCheckRunning_WithError(t *testing.T) {
-	var tUt Utils = tOsUtilsStatus{}
-	tN := NewNtpConfigurator(tUt)
-	var ntpCheckRunning = "systemctl is-active --quiet ntpXYZ"
-	_, err := tN.checkRunning(ntpCheckRunning)
-	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
+	var tUt executor.Executor = tStatusExecutor{}
+	isActive := (&servicemanager.Systemd{Exec: tUt}).Active(context.Background(), "ntpXYZ")
+	assert.False(t, isActive, "Did not get expected result. Wanted: false, got: %v", isActive)
 
 }
 
 func Test_ntpStatusGetSyncedTime_WithValidParam(t *testing.T) {
-	var tUt Utils = tOsUtilsStatus{}
-	tN := NewNtpConfigurator(tUt)
+	var tUt executor.Executor = tStatusExecutor{}
+	tN := NewNtpConfigurator(tUt, serviceconfig.Default())
 	var PeerDetails []*v1.PeerDetails
 	var PeerDetailsDummy *v1.PeerDetails
 	PeerDetailsDummy = &v1.PeerDetails{} // Sets the pointer
@@ -178,8 +133,8 @@ func Test_ntpStatusGetSyncedTime_WithValidParam(t *testing.T) {
 }
 
 func Test_ntpStatusGetSyncedTime_WithZeroParam(t *testing.T) {
-	var tUt Utils = tOsUtilsStatus{}
-	tN := NewNtpConfigurator(tUt)
+	var tUt executor.Executor = tStatusExecutor{}
+	tN := NewNtpConfigurator(tUt, serviceconfig.Default())
 	var PeerDetails []*v1.PeerDetails
 	var PeerDetailsDummy *v1.PeerDetails
 	PeerDetailsDummy = &v1.PeerDetails{} // Sets the pointer
@@ -204,25 +159,25 @@ func Test_ntpStatusGetSyncedTime_WithZeroParam(t *testing.T) {
 }
 
 func Test_ntpStatusCheckLastConfiguredOn(t *testing.T) {
-	var tUt Utils = tOsUtilsStatus{}
-	tN := NewNtpConfigurator(tUt)
+	var tUt executor.Executor = tStatusExecutor{}
+	tN := NewNtpConfigurator(tUt, serviceconfig.Default())
 	_, err := tN.checkLastConfiguredOn()
 	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
 }
 
 func Test_checkLastConfiguredOn_FileDoesNotExist(t *testing.T) {
-	var tUt Utils = tOsUtilsStatus{}
-	tN := NewNtpConfigurator(tUt)
-	
+	var tUt executor.Executor = tStatusExecutor{}
+	tN := NewNtpConfigurator(tUt, serviceconfig.Default())
+
 	result, err := tN.checkLastConfiguredOn()
-	
+
 	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %v", err)
 	assert.Equal(t, "", result, "Expected empty string when file doesn't exist, got: %q", result)
 }
 
 func Test_checkLastConfiguredOn_Logs(t *testing.T) {
-	var tUt Utils = tOsUtilsStatus{}
-	tN := NewNtpConfigurator(tUt)
+	var tUt executor.Executor = tStatusExecutor{}
+	tN := NewNtpConfigurator(tUt, serviceconfig.Default())
 	tempDir := t.TempDir()
 	tN.ConfigPath = tempDir
 
@@ -234,6 +189,40 @@ func Test_checkLastConfiguredOn_Logs(t *testing.T) {
 
 	logContent := logOutput.String()
 	errorCount := strings.Count(logContent, "ReadFile returns error")
-	
+
 	assert.Equal(t, 1, errorCount, "Did not get expected result. Wanted error to be logged 1 time (once per call), but it was logged %d times", errorCount)
 }
+
+func Test_NewNtpConfigurator_ServiceConfig(t *testing.T) {
+	config := serviceconfig.Default()
+	config.Paths.NtpConf = "/data/chrony/chrony.conf"
+	config.Paths.LastConfig = "/data/lastntpconfigdate.rec"
+	config.Daemon = serviceconfig.Daemon{Backend: ChronyBackendName, Unit: "chronyd", StepTimeout: 45 * time.Second}
+
+	tN := NewNtpConfigurator(tExecutor{}, config)
+
+	assert.Equal(t, "/data/chrony/chrony.conf", tN.NtpConfPath)
+	assert.Equal(t, "/data/lastntpconfigdate.rec", tN.ConfigPath)
+	assert.Equal(t, Daemon{Service: "chronyd", StepTimeout: 45 * time.Second}, tN.Backend.(*ChronyBackend).Daemon)
+	assert.Equal(t, serviceconfig.DefaultNtpKeysPath, tN.KeysPath)
+	assert.Equal(t, serviceconfig.DefaultHistoryPath, tN.HistoryPath)
+}
+
+func Test_NewNtpConfigurator_DetectsConfiguredNtpConf(t *testing.T) {
+	config := serviceconfig.Default()
+	config.Paths.NtpConf = filepath.Join(t.TempDir(), "ntp.conf")
+	config.Paths.NtpKeys = "/data/ntpsec/ntp.keys"
+	config.Paths.LeapSeconds = "/data/ntpsec/leap-seconds.list"
+	config.Paths.NtsCaBundle = "/data/ntpsec/nts-ca.pem"
+	config.Paths.History = "/data/history"
+	assert.NoError(t, os.WriteFile(config.Paths.NtpConf, []byte("pool 2.debian.pool.ntp.org iburst\n"), 0644))
+
+	tN := NewNtpConfigurator(tExecutor{}, config)
+
+	assert.Equal(t, NtpSecBackendName, tN.Backend.Name())
+	assert.Equal(t, config.Paths.NtpConf, tN.NtpConfPath)
+	assert.Equal(t, "/data/ntpsec/ntp.keys", tN.KeysPath)
+	assert.Equal(t, "/data/ntpsec/leap-seconds.list", tN.LeapPath)
+	assert.Equal(t, "/data/ntpsec/nts-ca.pem", tN.NtsCaPath)
+	assert.Equal(t, "/data/history", tN.HistoryPath)
+}
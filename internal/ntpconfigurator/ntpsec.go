@@ -0,0 +1,83 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+	"ntpservice/internal/servicemanager"
+	"ntpservice/utils/executor"
+)
+
+// NtpSecBackend configures ntpsec, the status is read from ntpd with the NTP control protocol.
+type NtpSecBackend struct {
+	Exec     executor.Executor
+	Services servicemanager.ServiceManager
+	Control  ControlClient
+	Daemon
+}
+
+// NewNtpSecBackend returns the ntpsec backend reading the status from the local ntpd.
+func NewNtpSecBackend(ex executor.Executor, services servicemanager.ServiceManager) *NtpSecBackend {
+	return &NtpSecBackend{Exec: ex, Services: services, Control: ntpcontrol.NewClient(ntpcontrol.DefaultAddress)}
+}
+
+func (b *NtpSecBackend) Name() string {
+	return NtpSecBackendName
+}
+
+func (b *NtpSecBackend) ConfPath() string {
+	return ntpSecConfigPath
+}
+
+// ReplaceServers replaces the server and pool lines.
+func (b *NtpSecBackend) ReplaceServers(conf []byte, config *v1.Ntp) []byte {
+	return replaceServerLines(conf, config, RenderServerEntry)
+}
+
+// ReadServers returns the server and pool lines.
+func (b *NtpSecBackend) ReadServers(conf []byte) *v1.Ntp {
+	return parseServerLines(conf, ParseServerEntry)
+}
+
+func (b *NtpSecBackend) Stop(ctx context.Context) error {
+	return b.Services.Stop(ctx, b.service(NtpSecService))
+}
+
+func (b *NtpSecBackend) Start(ctx context.Context) error {
+	return b.Services.Start(ctx, b.service(NtpSecService))
+}
+
+func (b *NtpSecBackend) Restart(ctx context.Context) error {
+	return b.Services.Restart(ctx, b.service(NtpSecService))
+}
+
+func (b *NtpSecBackend) Active(ctx context.Context) bool {
+	return b.Services.Active(ctx, b.service(NtpSecService))
+}
+
+// Step runs `ntpd -gq`.
+func (b *NtpSecBackend) Step(ctx context.Context) error {
+	_, err := runCommand(ctx, b.Exec, b.step(UpdateSystemTimeCmd))
+	return err
+}
+
+func (b *NtpSecBackend) Peers(ctx context.Context) ([]ntpcontrol.Peer, error) {
+	return b.Control.Peers(ctx)
+}
+
+func (b *NtpSecBackend) SystemVariables(ctx context.Context, names ...string) (ntpcontrol.Variables, error) {
+	return b.Control.ReadVariables(ctx, 0, names...)
+}
+
+// Supports reports true except for the fallback servers and the local clock, the configuration features were written
+// for ntpsec. ntpsec has no local clock driver, orphan mode replaces it.
+func (b *NtpSecBackend) Supports(feature Feature) bool {
+	return feature != FeatureFallback && feature != FeatureLocalClock
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+)
+
+const ntsDirective = "nts"
+const ntsCaOption = "ca"
+
+// ntsCookiesVariable is the peer variable with the number of NTS cookies ntpd holds for the association. ntpsec
+// reports it with the peer variables of an association (`ntpq -c "rv <associd> ntscookies"`), ntpd versions that do
+// not report it leave the NTS state unknown.
+const ntsCookiesVariable = "ntscookies"
+
+// errNoAssociation is reported when ntpd has no association for a configured NTS server.
+var errNoAssociation = errors.New("ntpd has no association for the server")
+
+// errNtsKeIncomplete is reported when ntpd has not completed the NTS key establishment with a server.
+var errNtsKeIncomplete = errors.New("ntpd has not completed the nts key establishment")
+
+// errNtsStateUnknown is reported when ntpd does not report the ntscookies variable for the association.
+var errNtsStateUnknown = errors.New("ntpd does not report the nts state of the association")
+
+// ErrInvalidCaBundle is returned when the uploaded NTS CA bundle does not contain valid PEM certificates.
+var ErrInvalidCaBundle = errors.New("invalid nts ca bundle")
+
+// ValidateCaBundle checks that the bundle consists of PEM encoded certificates only. An empty bundle is valid.
+func ValidateCaBundle(bundle string) error {
+	rest := []byte(bundle)
+	for len(strings.TrimSpace(string(rest))) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return fmt.Errorf("%w: bundle contains data which is not PEM encoded", ErrInvalidCaBundle)
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("%w: unexpected PEM block %q", ErrInvalidCaBundle, block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidCaBundle, err)
+		}
+	}
+	return nil
+}
+
+// SetNtsCaBundle writes the CA bundle and points the `nts ca` directive of ntp.conf to it, then ntpsec is restarted.
+// An empty bundle removes the file and the directive, ntpsec uses the system trust store again.
+// If ntpsec does not restart the previous files are restored and an *ApplyError is returned.
+func (n *NtpConfigurator) SetNtsCaBundle(ctx context.Context, bundle string) error {
+	if err := n.require(FeatureNtsCaBundle); err != nil {
+		return err
+	}
+	if err := ValidateCaBundle(bundle); err != nil {
+		return err
+	}
+	write := func() error {
+		caPath := ""
+		if strings.TrimSpace(bundle) == "" {
+			if err := os.Remove(n.NtsCaPath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		} else {
+			if err := n.Files.WriteFileAtomic(n.NtsCaPath, []byte(bundle), ntpConfPermissions); err != nil {
+				return err
+			}
+			caPath = n.NtsCaPath
+		}
+		return n.replaceNtsCaDirective(caPath)
+	}
+	return n.applyChange(ctx, write, n.NtsCaPath)
+}
+
+// GetNtsCaBundle returns the CA bundle used for NTS, empty if the system trust store is used.
+func (n *NtpConfigurator) GetNtsCaBundle() (string, error) {
+	bundle, err := n.Files.ReadFile(n.NtsCaPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return string(bundle), err
+}
+
+// replaceNtsCaDirective removes the ca option from the nts lines of ntp.conf and, if caPath is not empty,
+// writes `nts ca caPath` before the first server or pool line.
+func (n *NtpConfigurator) replaceNtsCaDirective(caPath string) error {
+	return n.rewriteNtpConf(func(lines []string) []string {
+		var kept []string
+		for _, line := range lines {
+			fields := strings.Fields(line)
+			if len(fields) == 0 || fields[0] != ntsDirective {
+				kept = append(kept, line)
+				continue
+			}
+			var options []string
+			for i := 1; i < len(fields); i++ {
+				if fields[i] == ntsCaOption {
+					i++
+					continue
+				}
+				options = append(options, fields[i])
+			}
+			if len(options) > 0 {
+				kept = append(kept, strings.Join(append([]string{ntsDirective}, options...), " "))
+			}
+		}
+		if caPath == "" {
+			return kept
+		}
+		return insertBeforeServers(kept, strings.Join([]string{ntsDirective, ntsCaOption, caPath}, " "))
+	})
+}
+
+// checkNts returns the NTS state ntpd holds for every server configured with the nts option, read from the peer
+// variables of its associations. ntpd only knows the address of an NTS server after the key establishment, without
+// the ntscookies variable the state is unknown and keSucceeded stays false.
+func (n *NtpConfigurator) checkNts(peers []ntpcontrol.Peer) []*v1.NtsStatus {
+	config, err := n.readNtpConf()
+	if err != nil {
+		log.Println("Cannot read configured nts servers:", err.Error())
+		return nil
+	}
+	var statuses []*v1.NtsStatus
+	for _, entry := range config.GetNtpServerEntries() {
+		if !entry.GetNts() {
+			continue
+		}
+		status := &v1.NtsStatus{Server: entry.GetAddress()}
+		peer, ok := ntsAssociation(peers, entry.GetAddress())
+		switch {
+		case !ok:
+			status.Error = errNoAssociation.Error()
+		case !hasAddress(peer):
+			status.Error = errNtsKeIncomplete.Error()
+		case !hasVariable(peer, ntsCookiesVariable):
+			status.Error = errNtsStateUnknown.Error()
+		default:
+			status.KeSucceeded = true
+			status.Cookies = int32(peer.Variables.Int(ntsCookiesVariable))
+			status.NtpServer = peer.Variables.String("srcadr")
+			if port := peer.Variables.String("srcport"); port != "" {
+				status.NtpServer = net.JoinHostPort(status.NtpServer, port)
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// ntsAssociation returns the association of ntpd for the configured address, ntpsec reports it as srchost.
+func ntsAssociation(peers []ntpcontrol.Peer, address string) (ntpcontrol.Peer, bool) {
+	for _, peer := range peers {
+		if peer.Variables.String("srchost") == address || peer.Variables.String("srcadr") == address {
+			return peer, true
+		}
+	}
+	return ntpcontrol.Peer{}, false
+}
+
+// hasVariable reports whether ntpd reported the variable for the association.
+func hasVariable(peer ntpcontrol.Peer, name string) bool {
+	_, ok := peer.Variables[name]
+	return ok
+}
+
+// hasAddress reports whether ntpd knows the address of the association.
+func hasAddress(peer ntpcontrol.Peer) bool {
+	address, err := netip.ParseAddr(peer.Variables.String("srcadr"))
+	return err == nil && !address.IsUnspecified()
+}
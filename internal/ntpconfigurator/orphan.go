@@ -0,0 +1,236 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const tosDirective = "tos"
+const orphanOption = "orphan"
+const orphanWaitOption = "orphanwait"
+
+// The local clock fallback of chrony, `local stratum <stratum>`.
+const localDirective = "local"
+const localStratumOption = "stratum"
+
+// defaultLocalStratum is the stratum chrony serves its own clock at if the local directive has no stratum option.
+const defaultLocalStratum = 10
+
+// maxOrphanStratum is the highest orphan stratum, 16 means unsynchronized.
+const maxOrphanStratum = 15
+
+// ErrInvalidOrphanMode is returned when the orphan mode can not be written to ntp.conf.
+var ErrInvalidOrphanMode = errors.New("invalid orphan mode")
+
+// ValidateOrphanMode checks that the orphan mode and its peers can be written to ntp.conf.
+func ValidateOrphanMode(orphan *v1.OrphanConfig) error {
+	if orphan.GetStratum() > maxOrphanStratum {
+		return fmt.Errorf("%w: stratum %d is out of range 1-%d", ErrInvalidOrphanMode, orphan.GetStratum(), maxOrphanStratum)
+	}
+	if orphan.GetStratum() == 0 && orphan.GetOrphanWait() != 0 {
+		return fmt.Errorf("%w: orphanWait requires a stratum", ErrInvalidOrphanMode)
+	}
+	if orphan.GetStratum() == 0 && orphan.GetLocalClock() {
+		return fmt.Errorf("%w: localClock requires a stratum", ErrInvalidOrphanMode)
+	}
+	addresses := make(map[string]bool)
+	for _, peer := range peerEntries(orphan) {
+		if err := ValidateServerEntry(peer); err != nil {
+			return err
+		}
+		if addresses[peer.GetAddress()] {
+			return fmt.Errorf("%w: peer %s is given twice", ErrInvalidOrphanMode, peer.GetAddress())
+		}
+		addresses[peer.GetAddress()] = true
+	}
+	return nil
+}
+
+// SetOrphanMode replaces the orphan options of the tos lines and the peer lines of ntp.conf and restarts ntpsec.
+// Backends with the local clock fallback instead replace the local line with the stratum of the orphan mode.
+// If the time daemon does not restart the previous configuration is restored and an *ApplyError is returned.
+func (n *NtpConfigurator) SetOrphanMode(ctx context.Context, orphan *v1.OrphanConfig) error {
+	if err := n.requireOrphanMode(orphan); err != nil {
+		return err
+	}
+	if err := ValidateOrphanMode(orphan); err != nil {
+		return err
+	}
+	if n.Backend.Supports(FeatureLocalClock) {
+		return n.applyChange(ctx, func() error {
+			return n.rewriteNtpConf(func(lines []string) []string {
+				return replaceLocalClock(lines, orphan)
+			})
+		})
+	}
+	peers := peerEntries(orphan)
+	if err := n.ValidateKeyReferences(&v1.Ntp{NtpServerEntries: peers}); err != nil {
+		return err
+	}
+	return n.applyChange(ctx, func() error {
+		return n.rewriteNtpConf(func(lines []string) []string {
+			var kept []string
+			for _, line := range lines {
+				fields := strings.Fields(line)
+				if len(fields) > 0 && fields[0] == peerDirective {
+					continue
+				}
+				if len(fields) > 0 && fields[0] == tosDirective {
+					if fields = withoutOrphanOptions(fields); len(fields) == 1 {
+						continue
+					}
+					line = strings.Join(fields, " ")
+				}
+				kept = append(kept, line)
+			}
+			var directives []string
+			if orphan.GetStratum() != 0 {
+				tos := []string{tosDirective, orphanOption, strconv.FormatUint(uint64(orphan.GetStratum()), 10)}
+				if orphan.GetOrphanWait() != 0 {
+					tos = append(tos, orphanWaitOption, strconv.FormatUint(uint64(orphan.GetOrphanWait()), 10))
+				}
+				directives = append(directives, strings.Join(tos, " "))
+			}
+			for _, peer := range peers {
+				directives = append(directives, RenderServerEntry(peer))
+			}
+			return insertBeforeServers(kept, directives...)
+		})
+	})
+}
+
+// GetOrphanMode reads the orphan options of the tos lines, the peer lines and the local line of the configuration.
+func (n *NtpConfigurator) GetOrphanMode() (*v1.OrphanConfig, error) {
+	input, err := n.Files.ReadFile(n.NtpConfPath)
+	if err != nil {
+		return nil, err
+	}
+	orphan := &v1.OrphanConfig{}
+	for _, line := range splitLines(input) {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) > 1 && fields[0] == peerDirective:
+			peer, err := ParseServerEntry(line)
+			if err != nil {
+				log.Println("Skipping peer entry:", err.Error())
+				continue
+			}
+			orphan.Peers = append(orphan.Peers, peer)
+		case len(fields) > 0 && fields[0] == localDirective:
+			orphan.LocalClock = true
+			orphan.Stratum = defaultLocalStratum
+			for i := 1; i+1 < len(fields); i++ {
+				if fields[i] != localStratumOption {
+					continue
+				}
+				if value, err := strconv.ParseUint(fields[i+1], 10, 32); err == nil {
+					orphan.Stratum = uint32(value)
+				}
+			}
+		case len(fields) > 1 && fields[0] == tosDirective:
+			for i := 1; i+1 < len(fields); i += 2 {
+				value, err := strconv.ParseUint(fields[i+1], 10, 32)
+				if err != nil {
+					continue
+				}
+				switch fields[i] {
+				case orphanOption:
+					orphan.Stratum = uint32(value)
+				case orphanWaitOption:
+					orphan.OrphanWait = uint32(value)
+				}
+			}
+		}
+	}
+	return orphan, nil
+}
+
+// checkOrphan reports whether ntpd runs in orphan mode, its stratum is the configured orphan stratum or above.
+func (n *NtpConfigurator) checkOrphan(ctx context.Context) bool {
+	orphan, err := n.GetOrphanMode()
+	if err != nil || orphan.GetStratum() == 0 {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, controlTimeout)
+	defer cancel()
+	system, err := n.Backend.SystemVariables(ctx, "stratum")
+	if err != nil {
+		log.Println("Cannot read system variables from", n.Backend.Name()+":", err.Error())
+		return false
+	}
+	stratum := system.Int("stratum")
+	return stratum >= int64(orphan.GetStratum()) && stratum <= maxOrphanStratum
+}
+
+// requireOrphanMode returns ErrNotSupported if the backend can not write the orphan mode. Backends with the local clock
+// fallback only write the stratum of the local clock, the orphan options and peers require orphan mode.
+func (n *NtpConfigurator) requireOrphanMode(orphan *v1.OrphanConfig) error {
+	if orphan.GetLocalClock() {
+		if err := n.require(FeatureLocalClock); err != nil {
+			return err
+		}
+	}
+	localClockOnly := orphan.GetOrphanWait() == 0 && len(orphan.GetPeers()) == 0 && (orphan.GetStratum() == 0 || orphan.GetLocalClock())
+	if !n.Backend.Supports(FeatureLocalClock) || !localClockOnly {
+		return n.require(FeatureOrphan)
+	}
+	return nil
+}
+
+// replaceLocalClock replaces the local lines with `local stratum <stratum>` if the local clock is enabled.
+func replaceLocalClock(lines []string, orphan *v1.OrphanConfig) []string {
+	var kept []string
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == localDirective {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if !orphan.GetLocalClock() {
+		return kept
+	}
+	return insertBeforeServers(kept, strings.Join([]string{localDirective, localStratumOption, strconv.FormatUint(uint64(orphan.GetStratum()), 10)}, " "))
+}
+
+// peerEntries returns the peers of the orphan mode with the peer kind.
+func peerEntries(orphan *v1.OrphanConfig) []*v1.NtpServerEntry {
+	var peers []*v1.NtpServerEntry
+	for _, peer := range orphan.GetPeers() {
+		if peer == nil {
+			peers = append(peers, nil)
+			continue
+		}
+		peer = proto.Clone(peer).(*v1.NtpServerEntry)
+		peer.Kind = v1.NtpEntryKind_PEER
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+// withoutOrphanOptions removes the orphan and orphanwait options with their values from a tos line.
+func withoutOrphanOptions(fields []string) []string {
+	kept := fields[:1:1]
+	for i := 1; i < len(fields); i++ {
+		if (fields[i] == orphanOption || fields[i] == orphanWaitOption) && i+1 < len(fields) {
+			i++
+			continue
+		}
+		kept = append(kept, fields[i])
+	}
+	return kept
+}
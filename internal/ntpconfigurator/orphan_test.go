@@ -0,0 +1,181 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+	"ntpservice/internal/ntpcontrol/ntpcontroltest"
+	"ntpservice/internal/servicemanager/servicemanagertest"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const tOrphanConf = "driftfile /var/lib/ntpsec/ntp.drift\n" +
+	"tos minclock 3 orphan 12 orphanwait 300\n" +
+	"peer 192.168.1.21\n" +
+	"server 192.168.1.1 iburst\n"
+
+func Test_ValidateOrphanMode(t *testing.T) {
+	assert.NoError(t, ValidateOrphanMode(&v1.OrphanConfig{}))
+	assert.NoError(t, ValidateOrphanMode(&v1.OrphanConfig{Stratum: 10, OrphanWait: 60,
+		Peers: []*v1.NtpServerEntry{{Address: "192.168.1.21"}, {Address: "cell-b.local", KeyId: 1}}}))
+
+	invalid := []*v1.OrphanConfig{
+		{Stratum: 16},
+		{OrphanWait: 60},
+		{Stratum: 10, Peers: []*v1.NtpServerEntry{{Address: "192.168.1.21"}, {Address: "192.168.1.21"}}},
+		{LocalClock: true},
+	}
+	for _, orphan := range invalid {
+		assert.ErrorIs(t, ValidateOrphanMode(orphan), ErrInvalidOrphanMode, "Did not get expected result for %v", orphan)
+	}
+	assert.ErrorIs(t, ValidateOrphanMode(&v1.OrphanConfig{Stratum: 10, Peers: []*v1.NtpServerEntry{{Address: "192.168.1.21", Nts: true}}}),
+		ErrInvalidServerEntry)
+}
+
+func Test_SetOrphanMode(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, tOrphanConf)
+	orphan := &v1.OrphanConfig{Stratum: 10, Peers: []*v1.NtpServerEntry{{Address: "192.168.1.22", Iburst: true}}}
+
+	assert.NoError(t, tN.SetOrphanMode(context.Background(), orphan))
+
+	content, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "driftfile /var/lib/ntpsec/ntp.drift\n"+
+		"tos minclock 3\n"+
+		"tos orphan 10\n"+
+		"peer 192.168.1.22 iburst\n"+
+		"server 192.168.1.1 iburst\n", string(content))
+	read, err := tN.GetOrphanMode()
+	assert.NoError(t, err)
+	orphan.Peers[0].Kind = v1.NtpEntryKind_PEER
+	assert.True(t, proto.Equal(orphan, read), "Did not get expected result. Wanted: %v, got: %v", orphan, read)
+}
+
+func Test_SetOrphanMode_Disable(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "tos orphan 12\npeer 192.168.1.21\nserver 192.168.1.1 iburst\n")
+
+	assert.NoError(t, tN.SetOrphanMode(context.Background(), &v1.OrphanConfig{}))
+
+	content, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "server 192.168.1.1 iburst\n", string(content))
+}
+
+func Test_SetOrphanMode_UnknownKey(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, tOrphanConf)
+
+	err := tN.SetOrphanMode(context.Background(), &v1.OrphanConfig{Stratum: 10, Peers: []*v1.NtpServerEntry{{Address: "192.168.1.22", KeyId: 42}}})
+
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	content, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, tOrphanConf, string(content))
+}
+
+func Test_GetOrphanMode(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, tOrphanConf)
+
+	orphan, err := tN.GetOrphanMode()
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(12), orphan.GetStratum())
+	assert.Equal(t, uint32(300), orphan.GetOrphanWait())
+	assert.Len(t, orphan.GetPeers(), 1)
+	assert.Equal(t, v1.NtpEntryKind_PEER, orphan.GetPeers()[0].GetKind())
+}
+
+func Test_SetOrphanMode_LocalClock(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "driftfile /var/lib/chrony/chrony.drift\nlocal stratum 8 orphan\npool 2.debian.pool.ntp.org iburst\n")
+	services := servicemanagertest.New(ChronyService)
+	tN.Backend = NewChronyBackend(&tChronyExecutor{tFailingExecutor{failing: map[string]bool{}}}, services)
+	orphan := &v1.OrphanConfig{Stratum: 10, LocalClock: true}
+
+	assert.NoError(t, tN.SetOrphanMode(context.Background(), orphan))
+
+	content, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "driftfile /var/lib/chrony/chrony.drift\nlocal stratum 10\npool 2.debian.pool.ntp.org iburst\n", string(content))
+	assert.Equal(t, []string{"restart chrony", "status chrony"}, services.Calls())
+	read, err := tN.GetOrphanMode()
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(orphan, read), "Did not get expected result. Wanted: %v, got: %v", orphan, read)
+
+	assert.NoError(t, tN.SetOrphanMode(context.Background(), &v1.OrphanConfig{}))
+	content, _ = os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "driftfile /var/lib/chrony/chrony.drift\npool 2.debian.pool.ntp.org iburst\n", string(content))
+
+	// chrony has no orphan mode, only the stratum of the local clock is written.
+	unsupported := []*v1.OrphanConfig{
+		{Stratum: 10},
+		{Stratum: 10, LocalClock: true, OrphanWait: 60},
+		{Stratum: 10, LocalClock: true, Peers: []*v1.NtpServerEntry{{Address: "192.168.1.21"}}},
+	}
+	for _, orphan := range unsupported {
+		assert.ErrorIs(t, tN.SetOrphanMode(context.Background(), orphan), ErrNotSupported, "Did not get expected result for %v", orphan)
+	}
+}
+
+func Test_SetOrphanMode_LocalClockNotSupported(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, tOrphanConf)
+
+	err := tN.SetOrphanMode(context.Background(), &v1.OrphanConfig{Stratum: 10, LocalClock: true})
+
+	assert.ErrorIs(t, err, ErrNotSupported)
+	content, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, tOrphanConf, string(content))
+}
+
+func Test_GetOrphanMode_LocalClockDefaultStratum(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "local orphan\nserver 192.168.1.1 iburst\n")
+
+	orphan, err := tN.GetOrphanMode()
+
+	assert.NoError(t, err)
+	assert.True(t, orphan.GetLocalClock())
+	assert.Equal(t, uint32(defaultLocalStratum), orphan.GetStratum())
+	encoded, err := proto.Marshal(orphan)
+	assert.NoError(t, err)
+	decoded := &v1.OrphanConfig{}
+	assert.NoError(t, proto.Unmarshal(encoded, decoded))
+	assert.True(t, decoded.GetLocalClock())
+}
+
+func Test_ValidateNtpServers_RejectsPeer(t *testing.T) {
+	config := &v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "192.168.1.21", Kind: v1.NtpEntryKind_PEER}}}
+
+	assert.ErrorIs(t, ValidateNtpServers(config), ErrInvalidServerEntry)
+}
+
+func Test_checkOrphan(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		stratum string
+		want    bool
+	}{
+		{"orphan stratum reached", tOrphanConf, "12", true},
+		{"synced upstream", tOrphanConf, "3", false},
+		{"unsynchronized", tOrphanConf, "16", false},
+		{"orphan mode disabled", "server 192.168.1.1 iburst\n", "12", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tN := prepareNtpConfiguratorWithKeys(t, tt.conf)
+			server, err := ntpcontroltest.NewServer(ntpcontrol.Variables{"stratum": tt.stratum, "refid": "127.0.0.1"},
+				tAssociations(time.Now()), ntpcontroltest.Options{})
+			assert.NoError(t, err)
+			t.Cleanup(func() { server.Close() })
+			tN.Backend = &NtpSecBackend{Exec: tExecutor{}, Control: ntpcontrol.NewClient(server.Addr)}
+
+			assert.Equal(t, tt.want, tN.checkOrphan(context.Background()), "Did not get expected result for stratum %s", tt.stratum)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+)
+
+const controlTimeout = 3 * time.Second
+
+// readPeers reads the associations of ntpd, nil if ntpd does not answer within the control timeout or the deadline of ctx.
+func (n *NtpConfigurator) readPeers(ctx context.Context) []ntpcontrol.Peer {
+	ctx, cancel := context.WithTimeout(ctx, controlTimeout)
+	defer cancel()
+	peers, err := n.Backend.Peers(ctx)
+	if err != nil {
+		log.Println("Cannot read peers from", n.Backend.Name()+":", err.Error())
+		return nil
+	}
+	return peers
+}
+
+// toPeerDetails converts the associations into the peer details, the ntpq -pn columns are filled as ntpq prints them.
+func toPeerDetails(peers []ntpcontrol.Peer, now time.Time) []*v1.PeerDetails {
+	var details []*v1.PeerDetails
+	for _, peer := range peers {
+		remote := peer.Remote()
+		if tally := peer.Tally(); tally != ' ' {
+			remote = string(tally) + remote
+		}
+		when := peer.When(now)
+		if when < 0 {
+			when = 0
+		}
+		variables := peer.Variables
+		details = append(details, &v1.PeerDetails{
+			RemoteServer:   remote,
+			ReferenceID:    peer.DisplayReferenceID(),
+			Stratum:        variables.String("stratum"),
+			Type:           peer.Type(),
+			Poll:           int32(peer.Poll()),
+			When:           int32(when),
+			Reach:          strconv.FormatInt(peer.Reach(), 8),
+			Delay:          float32(variables.Float("delay")),
+			Offset:         float32(variables.Float("offset")),
+			Jitter:         float32(variables.Float("jitter")),
+			AssociationId:  uint32(peer.AssociationID),
+			Address:        peer.Remote(),
+			Port:           int32(variables.Int("srcport")),
+			LocalAddress:   variables.String("dstadr"),
+			Selection:      peer.SelectionName(),
+			Configured:     peer.Configured(),
+			Authenticated:  peer.Authenticated(),
+			RootDelay:      float32(variables.Float("rootdelay")),
+			RootDispersion: float32(variables.Float("rootdisp")),
+			Dispersion:     float32(variables.Float("dispersion")),
+			LeapIndicator:  int32(peer.Leap()),
+			Precision:      int32(variables.Int("precision")),
+			HostPoll:       int32(variables.Int("hpoll")),
+			PeerPoll:       int32(variables.Int("ppoll")),
+			Flash:          uint32(variables.Int("flash")),
+			Refclock:       toRefclockStatus(peer.Clock),
+		})
+	}
+	return details
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ntpservice/internal/ntpcontrol"
+	"ntpservice/internal/ntpcontrol/ntpcontroltest"
+	"ntpservice/internal/servicemanager/servicemanagertest"
+	"ntpservice/utils/executor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tAssociations are the associations of `ntpq -pn`:
+//
+//	     remote           refid      st t when poll reach   delay   offset  jitter
+//	==============================================================================
+//	 0.debian.pool.n .POOL.          16 p    -   64    0    0.000    0.000   0.000
+//	*193.30.121.7    131.188.3.223    2 u   35  256  377   64.087   40.219  13.950
+//	+198.251.86.68   82.64.45.50      2 u   62  256  377   74.710   29.980  21.180
+func tAssociations(now time.Time) []ntpcontroltest.Association {
+	return []ntpcontroltest.Association{
+		{ID: 1, Status: 0x8811, Variables: ntpcontrol.Variables{
+			"srcadr": "0.0.0.0", "srchost": "0.debian.pool.ntp.org", "refid": "POOL", "stratum": "16", "hmode": "3",
+			"hpoll": "6", "ppoll": "6", "reach": "0x0", "leap": "11"}},
+		{ID: 2, Status: 0x161a, Variables: ntpcontrol.Variables{
+			"srcadr": "193.30.121.7", "srcport": "123", "dstadr": "10.0.0.5", "refid": "131.188.3.223", "stratum": "2",
+			"hmode": "3", "hpoll": "8", "ppoll": "8", "reach": "0xff", "delay": "64.087", "offset": "40.219",
+			"jitter": "13.950", "rootdelay": "1.5", "rootdisp": "20.25", "dispersion": "3.5", "leap": "00",
+			"precision": "-23", "flash": "0x0", "rec": ntpTimestamp(now.Add(-35 * time.Second))}},
+		{ID: 3, Status: 0x1414, Variables: ntpcontrol.Variables{
+			"srcadr": "198.251.86.68", "refid": "82.64.45.50", "stratum": "2", "hmode": "3", "hpoll": "8",
+			"reach": "0xff", "delay": "74.710", "offset": "29.980", "jitter": "21.180",
+			"rec": ntpTimestamp(now.Add(-62 * time.Second))}},
+	}
+}
+
+func ntpTimestamp(t time.Time) string {
+	return fmt.Sprintf("0x%08x.00000000", uint32(t.Unix()+2208988800))
+}
+
+func startFakeNtpd(t *testing.T) *ntpcontrol.Client {
+	server, err := ntpcontroltest.NewServer(ntpcontrol.Variables{}, tAssociations(time.Now()), ntpcontroltest.Options{})
+	assert.NoError(t, err)
+	t.Cleanup(func() { server.Close() })
+	return ntpcontrol.NewClient(server.Addr)
+}
+
+func Test_readPeers(t *testing.T) {
+	tN := prepareNtpConfigurator()
+	tN.Backend = &NtpSecBackend{Exec: tExecutor{}, Control: startFakeNtpd(t)}
+
+	peers := tN.readPeers(context.Background())
+
+	assert.Len(t, peers, 3)
+}
+
+func Test_readPeers_UnreadableAssociation(t *testing.T) {
+	associations := tAssociations(time.Now())
+	associations[1].Unreadable = true
+	server, err := ntpcontroltest.NewServer(ntpcontrol.Variables{}, associations, ntpcontroltest.Options{})
+	assert.NoError(t, err)
+	t.Cleanup(func() { server.Close() })
+	tN := prepareNtpConfigurator()
+	tN.Backend = &NtpSecBackend{Exec: tExecutor{}, Control: ntpcontrol.NewClient(server.Addr)}
+
+	peers := tN.readPeers(context.Background())
+
+	assert.Len(t, peers, 3, "Did not get expected result. A failed read variables must not drop the other peers")
+	assert.Empty(t, peers[1].Variables)
+	assert.Equal(t, "198.251.86.68", peers[2].Remote())
+}
+
+func Test_readPeers_NtpdNotRunning(t *testing.T) {
+	server, err := ntpcontroltest.NewServer(nil, nil, ntpcontroltest.Options{})
+	assert.NoError(t, err)
+	server.Close()
+	tN := prepareNtpConfigurator()
+	tN.Backend = &NtpSecBackend{Exec: tExecutor{}, Control: ntpcontrol.NewClient(server.Addr)}
+
+	assert.Nil(t, tN.readPeers(context.Background()))
+}
+
+// tHangingExecutor runs every command until the context is done, like a hanging chronyc.
+type tHangingExecutor struct{}
+
+func (o tHangingExecutor) Run(ctx context.Context, command executor.Command) ([]byte, error) {
+	<-ctx.Done()
+	return nil, &executor.Error{Command: command, ExitCode: -1, Err: ctx.Err()}
+}
+
+func Test_readPeers_RequestDeadline(t *testing.T) {
+	tN := prepareNtpConfigurator()
+	tN.Backend = NewChronyBackend(tHangingExecutor{}, servicemanagertest.New())
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+
+	assert.Nil(t, tN.readPeers(ctx))
+	assert.Less(t, time.Since(start), controlTimeout, "Did not get expected result. The deadline of the request must be kept")
+}
+
+func Test_toPeerDetails(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	var peers []ntpcontrol.Peer
+	for _, association := range tAssociations(now) {
+		peers = append(peers, ntpcontrol.Peer{AssociationID: association.ID, Status: association.Status, Variables: association.Variables})
+	}
+
+	details := toPeerDetails(peers, now)
+
+	assert.Len(t, details, 3)
+	assert.Equal(t, "0.debian.pool.ntp.org", details[0].RemoteServer)
+	assert.Equal(t, ".POOL.", details[0].ReferenceID)
+	assert.Equal(t, "16", details[0].Stratum)
+	assert.Equal(t, "p", details[0].Type)
+	assert.Equal(t, int32(0), details[0].When)
+	assert.Equal(t, int32(64), details[0].Poll)
+	assert.Equal(t, "0", details[0].Reach)
+	assert.Equal(t, int32(3), details[0].LeapIndicator)
+	assert.True(t, details[0].Configured)
+
+	peer := details[1]
+	assert.Equal(t, "*193.30.121.7", peer.RemoteServer)
+	assert.Equal(t, "193.30.121.7", peer.Address)
+	assert.Equal(t, "131.188.3.223", peer.ReferenceID)
+	assert.Equal(t, "2", peer.Stratum)
+	assert.Equal(t, "u", peer.Type)
+	assert.Equal(t, int32(35), peer.When)
+	assert.Equal(t, int32(256), peer.Poll)
+	assert.Equal(t, "377", peer.Reach)
+	assert.Equal(t, float32(64.087), peer.Delay)
+	assert.Equal(t, float32(40.219), peer.Offset)
+	assert.Equal(t, float32(13.950), peer.Jitter)
+	assert.Equal(t, uint32(2), peer.AssociationId)
+	assert.Equal(t, int32(123), peer.Port)
+	assert.Equal(t, "10.0.0.5", peer.LocalAddress)
+	assert.Equal(t, "sys.peer", peer.Selection)
+	assert.False(t, peer.Configured)
+	assert.Equal(t, float32(1.5), peer.RootDelay)
+	assert.Equal(t, float32(20.25), peer.RootDispersion)
+	assert.Equal(t, float32(3.5), peer.Dispersion)
+	assert.Equal(t, int32(-23), peer.Precision)
+	assert.Equal(t, int32(8), peer.HostPoll)
+
+	assert.Equal(t, "+198.251.86.68", details[2].RemoteServer)
+	assert.Equal(t, "candidate", details[2].Selection)
+	assert.Equal(t, int32(62), details[2].When)
+
+	synced, _, err := prepareNtpConfigurator().getSyncedTime(details)
+	assert.NoError(t, err)
+	assert.True(t, synced)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"log"
+	"net"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+)
+
+const poolLookupTimeout = 2 * time.Second
+
+// lookupHost resolves the pool names, replaced in tests.
+var lookupHost = func(host string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), poolLookupTimeout)
+	defer cancel()
+	return net.DefaultResolver.LookupHost(ctx, host)
+}
+
+// checkPools returns the configured pools with the associations of peers ntpsec spawned from them.
+// ntpsec does not record the pool an association was spawned from, so with more than one pool the
+// members are matched against the current DNS records of each pool.
+func (n *NtpConfigurator) checkPools(peers []ntpcontrol.Peer) []*v1.PoolStatus {
+	config, err := n.readNtpConf()
+	if err != nil {
+		log.Println("Cannot read configured pools:", err.Error())
+		return nil
+	}
+	var pools []*v1.PoolStatus
+	for _, entry := range config.GetNtpServerEntries() {
+		if entry.GetKind() == v1.NtpEntryKind_POOL {
+			pools = append(pools, &v1.PoolStatus{Pool: entry})
+		}
+	}
+	if len(pools) == 0 {
+		return pools
+	}
+
+	members := spawnedAssociations(peers)
+	if len(pools) == 1 {
+		pools[0].Members = members
+		return pools
+	}
+	for _, pool := range pools {
+		addresses, err := lookupHost(pool.Pool.GetAddress())
+		if err != nil {
+			log.Println("Cannot resolve pool", pool.Pool.GetAddress(), ":", err.Error())
+			continue
+		}
+		for _, member := range members {
+			for _, address := range addresses {
+				if net.ParseIP(member).Equal(net.ParseIP(address)) {
+					pool.Members = append(pool.Members, member)
+					break
+				}
+			}
+		}
+	}
+	return pools
+}
+
+// spawnedAssociations returns the addresses of the associations which are not configured in ntp.conf.
+func spawnedAssociations(peers []ntpcontrol.Peer) []string {
+	var members []string
+	for _, peer := range peers {
+		if !peer.Configured() {
+			members = append(members, peer.Variables.String("srcadr"))
+		}
+	}
+	return members
+}
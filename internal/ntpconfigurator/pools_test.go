@@ -0,0 +1,66 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ntpservice/internal/ntpcontrol"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tPoolPeers are a pool prototype and two associations spawned from it.
+var tPoolPeers = []ntpcontrol.Peer{
+	{AssociationID: 1, Status: 0x8811, Variables: ntpcontrol.Variables{"srcadr": "0.0.0.0", "refid": "POOL"}},
+	{AssociationID: 2, Status: 0x161a, Variables: ntpcontrol.Variables{"srcadr": "192.0.2.10"}},
+	{AssociationID: 3, Status: 0x1414, Variables: ntpcontrol.Variables{"srcadr": "198.51.100.7"}},
+}
+
+func prepareNtpConfiguratorWithPools(t *testing.T, conf string) *NtpConfigurator {
+	tN := prepareNtpConfigurator()
+	tN.NtpConfPath = filepath.Join(t.TempDir(), "ntp.conf")
+	assert.NoError(t, os.WriteFile(tN.NtpConfPath, []byte(conf), 0644))
+	return tN
+}
+
+func Test_checkPools_SinglePool(t *testing.T) {
+	tN := prepareNtpConfiguratorWithPools(t, "server 10.0.0.1\npool 0.debian.pool.ntp.org iburst\n")
+
+	pools := tN.checkPools(tPoolPeers)
+
+	assert.Len(t, pools, 1)
+	assert.Equal(t, "0.debian.pool.ntp.org", pools[0].Pool.Address)
+	assert.True(t, pools[0].Pool.Iburst)
+	assert.Equal(t, []string{"192.0.2.10", "198.51.100.7"}, pools[0].Members)
+}
+
+func Test_checkPools_MultiplePoolsMatchedByDns(t *testing.T) {
+	tN := prepareNtpConfiguratorWithPools(t, "pool a.pool.example\npool b.pool.example\n")
+	defer func(original func(string) ([]string, error)) { lookupHost = original }(lookupHost)
+	lookupHost = func(host string) ([]string, error) {
+		if host == "a.pool.example" {
+			return []string{"198.51.100.7"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	pools := tN.checkPools(tPoolPeers)
+
+	assert.Len(t, pools, 2)
+	assert.Equal(t, []string{"198.51.100.7"}, pools[0].Members)
+	assert.Empty(t, pools[1].Members)
+}
+
+func Test_checkPools_NoPools(t *testing.T) {
+	tN := prepareNtpConfiguratorWithPools(t, "server 10.0.0.1\n")
+
+	assert.Empty(t, tN.checkPools(tPoolPeers))
+}
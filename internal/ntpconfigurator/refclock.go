@@ -0,0 +1,418 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+)
+
+const refclockDirective = "refclock"
+
+// legacyRefclockPrefix is the prefix of the pseudo addresses of `server` lines which configure a reference clock
+// in the classic ntpd syntax, they are kept together with their `fudge` lines when the servers are replaced.
+const legacyRefclockPrefix = "127.127."
+
+// refclockDrivers maps the driver of an entry to its ntpsec driver name.
+var refclockDrivers = map[v1.RefclockDriver]string{
+	v1.RefclockDriver_GPSD:    "gpsd",
+	v1.RefclockDriver_NMEA:    "nmea",
+	v1.RefclockDriver_PPS:     "pps",
+	v1.RefclockDriver_SHM:     "shm",
+	v1.RefclockDriver_GENERIC: "generic",
+}
+
+// Limits of the ntpsec refclock options, see ntp.conf(5).
+const (
+	maxRefclockUnit   = 255
+	maxRefidLength    = 4
+	maxRefclockStrata = 15
+)
+
+// refclockMaxModes are the highest modes of the drivers which use the mode option, see the driver pages of ntpsec.
+// gpsd selects 0 TPV, 1 TPV with strict PPS and 2 TPV with relaxed PPS, nmea takes a bit mask of the sentences, the
+// baud rate and the processing options, shm 1 skips the difference limit. pps does not use a mode, generic passes it
+// to the receiver of the subtype and is not checked.
+var refclockMaxModes = map[v1.RefclockDriver]uint32{
+	v1.RefclockDriver_GPSD: 2,
+	v1.RefclockDriver_NMEA: 1023,
+	v1.RefclockDriver_PPS:  0,
+	v1.RefclockDriver_SHM:  1,
+}
+
+// serialBaudRates are the speeds the nmea and generic drivers accept.
+var serialBaudRates = map[uint32]bool{4800: true, 9600: true, 19200: true, 38400: true, 57600: true, 115200: true}
+
+// ErrInvalidRefclock is returned when a reference clock entry can not be written to ntp.conf.
+var ErrInvalidRefclock = errors.New("invalid reference clock")
+
+// ValidateRefclocks checks that the entries can be rendered into valid ntpsec `refclock` lines.
+func ValidateRefclocks(refclocks *v1.Refclocks) error {
+	units := make(map[string]bool)
+	for _, entry := range refclocks.GetRefclocks() {
+		if err := validateRefclock(entry); err != nil {
+			return err
+		}
+		name := refclockName(entry)
+		if units[name] {
+			return fmt.Errorf("%w: %s is given twice", ErrInvalidRefclock, name)
+		}
+		units[name] = true
+	}
+	return nil
+}
+
+func validateRefclock(entry *v1.RefclockEntry) error {
+	if entry == nil {
+		return fmt.Errorf("%w: entry is empty", ErrInvalidRefclock)
+	}
+	driver, ok := refclockDrivers[entry.GetDriver()]
+	if !ok {
+		return fmt.Errorf("%w: unknown driver %d", ErrInvalidRefclock, entry.GetDriver())
+	}
+	name := refclockName(entry)
+	if entry.GetUnit() > maxRefclockUnit {
+		return fmt.Errorf("%w: unit %d of %s is out of range 0-%d", ErrInvalidRefclock, entry.GetUnit(), driver, maxRefclockUnit)
+	}
+	if refid := entry.GetRefid(); len(refid) > maxRefidLength || strings.ContainsAny(refid, " \t\r\n#") {
+		return fmt.Errorf("%w: refid %q of %s is not 1-%d characters", ErrInvalidRefclock, refid, name, maxRefidLength)
+	}
+	if err := validateFudgeTime(name, "time1", entry.GetTime1()); err != nil {
+		return err
+	}
+	if err := validateFudgeTime(name, "time2", entry.GetTime2()); err != nil {
+		return err
+	}
+	if entry.GetStratum() > maxRefclockStrata {
+		return fmt.Errorf("%w: stratum %d of %s is out of range 0-%d", ErrInvalidRefclock, entry.GetStratum(), name, maxRefclockStrata)
+	}
+	if maxMode, ok := refclockMaxModes[entry.GetDriver()]; ok && entry.GetMode() > maxMode {
+		if maxMode == 0 {
+			return fmt.Errorf("%w: mode can not be used with %s", ErrInvalidRefclock, name)
+		}
+		return fmt.Errorf("%w: mode %d of %s is out of range 0-%d", ErrInvalidRefclock, entry.GetMode(), name, maxMode)
+	}
+	if entry.GetSubtype() != 0 && entry.GetDriver() != v1.RefclockDriver_GENERIC {
+		return fmt.Errorf("%w: subtype is only used by the generic driver, not by %s", ErrInvalidRefclock, name)
+	}
+	serial := entry.GetDriver() == v1.RefclockDriver_NMEA || entry.GetDriver() == v1.RefclockDriver_GENERIC
+	if entry.GetBaud() != 0 && (!serial || !serialBaudRates[entry.GetBaud()]) {
+		return fmt.Errorf("%w: baud %d can not be used with %s", ErrInvalidRefclock, entry.GetBaud(), name)
+	}
+	if entry.GetPpspath() != "" && !serial {
+		return fmt.Errorf("%w: ppspath can not be used with %s", ErrInvalidRefclock, name)
+	}
+	if err := validateDevicePath(name, "path", entry.GetPath()); err != nil {
+		return err
+	}
+	if err := validateDevicePath(name, "ppspath", entry.GetPpspath()); err != nil {
+		return err
+	}
+	if poll := entry.GetMinpoll(); poll != 0 && (poll < minPollExponent || poll > maxPollExponent) {
+		return fmt.Errorf("%w: minpoll %d of %s is out of range %d-%d", ErrInvalidRefclock, poll, name, minPollExponent, maxPollExponent)
+	}
+	if poll := entry.GetMaxpoll(); poll != 0 && (poll < minPollExponent || poll > maxPollExponent) {
+		return fmt.Errorf("%w: maxpoll %d of %s is out of range %d-%d", ErrInvalidRefclock, poll, name, minPollExponent, maxPollExponent)
+	}
+	if entry.GetMinpoll() != 0 && entry.GetMaxpoll() != 0 && entry.GetMinpoll() > entry.GetMaxpoll() {
+		return fmt.Errorf("%w: minpoll %d is greater than maxpoll %d of %s", ErrInvalidRefclock, entry.GetMinpoll(), entry.GetMaxpoll(), name)
+	}
+	return nil
+}
+
+func validateFudgeTime(name string, option string, value float32) error {
+	if math.IsNaN(float64(value)) || math.IsInf(float64(value), 0) {
+		return fmt.Errorf("%w: %s of %s is not a number", ErrInvalidRefclock, option, name)
+	}
+	return nil
+}
+
+func validateDevicePath(name string, option string, path string) error {
+	if path != "" && (!filepath.IsAbs(path) || strings.ContainsAny(path, " \t\r\n#")) {
+		return fmt.Errorf("%w: %s %q of %s is not an absolute path", ErrInvalidRefclock, option, path, name)
+	}
+	return nil
+}
+
+// refclockName returns the driver name and the unit as ntpsec reports them, e.g. SHM(0).
+func refclockName(entry *v1.RefclockEntry) string {
+	return fmt.Sprintf("%s(%d)", strings.ToUpper(refclockDrivers[entry.GetDriver()]), entry.GetUnit())
+}
+
+// RenderRefclock converts the entry into an ntpsec `refclock` line, e.g. `refclock shm unit 0 refid GPS time1 0.035 prefer`.
+func RenderRefclock(entry *v1.RefclockEntry) string {
+	fields := []string{refclockDirective, refclockDrivers[entry.GetDriver()], "unit", strconv.FormatUint(uint64(entry.GetUnit()), 10)}
+	uintOptions := []struct {
+		name  string
+		value uint32
+	}{{"subtype", entry.GetSubtype()}, {"mode", entry.GetMode()}, {"baud", entry.GetBaud()}, {"stratum", entry.GetStratum()}}
+	for _, option := range uintOptions {
+		if option.value != 0 {
+			fields = append(fields, option.name, strconv.FormatUint(uint64(option.value), 10))
+		}
+	}
+	if entry.GetPath() != "" {
+		fields = append(fields, "path", entry.GetPath())
+	}
+	if entry.GetPpspath() != "" {
+		fields = append(fields, "ppspath", entry.GetPpspath())
+	}
+	if entry.GetTime1() != 0 {
+		fields = append(fields, "time1", strconv.FormatFloat(float64(entry.GetTime1()), 'f', -1, 32))
+	}
+	if entry.GetTime2() != 0 {
+		fields = append(fields, "time2", strconv.FormatFloat(float64(entry.GetTime2()), 'f', -1, 32))
+	}
+	if entry.GetRefid() != "" {
+		fields = append(fields, "refid", entry.GetRefid())
+	}
+	for i, set := range []bool{entry.GetFlag1(), entry.GetFlag2(), entry.GetFlag3(), entry.GetFlag4()} {
+		if set {
+			fields = append(fields, "flag"+strconv.Itoa(i+1), "1")
+		}
+	}
+	if entry.GetMinpoll() != 0 {
+		fields = append(fields, "minpoll", strconv.Itoa(int(entry.GetMinpoll())))
+	}
+	if entry.GetMaxpoll() != 0 {
+		fields = append(fields, "maxpoll", strconv.Itoa(int(entry.GetMaxpoll())))
+	}
+	if entry.GetPrefer() {
+		fields = append(fields, "prefer")
+	}
+	if entry.GetNoselect() {
+		fields = append(fields, "noselect")
+	}
+	return strings.Join(fields, " ")
+}
+
+// ParseRefclock parses an ntpsec `refclock` line back into an entry.
+// A line with an option which is not part of RefclockEntry, e.g. holdover, can not be represented and is refused.
+func ParseRefclock(line string) (*v1.RefclockEntry, error) {
+	if i := strings.Index(line, "#"); i >= 0 {
+		line = line[:i]
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != refclockDirective {
+		return nil, fmt.Errorf("%w: %q is not a refclock line", ErrInvalidRefclock, line)
+	}
+	entry := &v1.RefclockEntry{}
+	driver, ok := parseRefclockDriver(fields[1])
+	if !ok {
+		return nil, fmt.Errorf("%w: driver %q is not supported", ErrInvalidRefclock, fields[1])
+	}
+	entry.Driver = driver
+	for i := 2; i < len(fields); i++ {
+		option := fields[i]
+		switch option {
+		case "prefer":
+			entry.Prefer = true
+		case "noselect":
+			entry.Noselect = true
+		case "flag1", "flag2", "flag3", "flag4":
+			// ntpsec accepts the flags with and without a value.
+			set := true
+			if i+1 < len(fields) && (fields[i+1] == "0" || fields[i+1] == "1") {
+				set = fields[i+1] == "1"
+				i++
+			}
+			switch option {
+			case "flag1":
+				entry.Flag1 = set
+			case "flag2":
+				entry.Flag2 = set
+			case "flag3":
+				entry.Flag3 = set
+			case "flag4":
+				entry.Flag4 = set
+			}
+		case "refid", "path", "ppspath":
+			if i+1 >= len(fields) {
+				return nil, fmt.Errorf("%w: option %s of %s has no value", ErrInvalidRefclock, option, fields[1])
+			}
+			switch option {
+			case "refid":
+				entry.Refid = fields[i+1]
+			case "path":
+				entry.Path = fields[i+1]
+			case "ppspath":
+				entry.Ppspath = fields[i+1]
+			}
+			i++
+		case "time1", "time2":
+			if i+1 >= len(fields) {
+				return nil, fmt.Errorf("%w: option %s of %s has no value", ErrInvalidRefclock, option, fields[1])
+			}
+			value, err := strconv.ParseFloat(fields[i+1], 32)
+			if err != nil {
+				return nil, fmt.Errorf("%w: option %s of %s has invalid value %q", ErrInvalidRefclock, option, fields[1], fields[i+1])
+			}
+			if option == "time1" {
+				entry.Time1 = float32(value)
+			} else {
+				entry.Time2 = float32(value)
+			}
+			i++
+		case "unit", "subtype", "mode", "baud", "stratum", "minpoll", "maxpoll":
+			if i+1 >= len(fields) {
+				return nil, fmt.Errorf("%w: option %s of %s has no value", ErrInvalidRefclock, option, fields[1])
+			}
+			value, err := strconv.ParseUint(fields[i+1], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%w: option %s of %s has invalid value %q", ErrInvalidRefclock, option, fields[1], fields[i+1])
+			}
+			switch option {
+			case "unit":
+				entry.Unit = uint32(value)
+			case "subtype":
+				entry.Subtype = uint32(value)
+			case "mode":
+				entry.Mode = uint32(value)
+			case "baud":
+				entry.Baud = uint32(value)
+			case "stratum":
+				entry.Stratum = uint32(value)
+			case "minpoll":
+				entry.Minpoll = int32(value)
+			case "maxpoll":
+				entry.Maxpoll = int32(value)
+			}
+			i++
+		default:
+			return nil, fmt.Errorf("%w: option %s of %s is not supported", ErrInvalidRefclock, option, fields[1])
+		}
+	}
+	return entry, nil
+}
+
+func parseRefclockDriver(name string) (v1.RefclockDriver, bool) {
+	for driver, driverName := range refclockDrivers {
+		if driverName == name {
+			return driver, true
+		}
+	}
+	return 0, false
+}
+
+// SetRefclocks replaces the refclock lines of ntp.conf and restarts ntpsec. Refclock lines which are no entry, e.g.
+// of the jjy or trimble driver, are kept as they are; an entry with the driver and unit of such a line is refused.
+// If ntpsec does not restart the previous ntp.conf is restored and an *ApplyError is returned.
+func (n *NtpConfigurator) SetRefclocks(ctx context.Context, refclocks *v1.Refclocks) error {
+	if err := n.require(FeatureRefclocks); err != nil {
+		return err
+	}
+	if err := ValidateRefclocks(refclocks); err != nil {
+		return err
+	}
+	return n.applyChange(ctx, func() error {
+		input, err := n.Files.ReadFile(n.NtpConfPath)
+		if err != nil {
+			return err
+		}
+		unmanaged := unmanagedRefclocks(splitLines(input))
+		for _, entry := range refclocks.GetRefclocks() {
+			if unmanaged[refclockName(entry)] {
+				return &preconditionError{err: fmt.Errorf("%w: %s is configured by a refclock line which is kept", ErrInvalidRefclock, refclockName(entry))}
+			}
+		}
+		return n.rewriteNtpConf(func(lines []string) []string {
+			var kept []string
+			for _, line := range lines {
+				if fields := strings.Fields(line); len(fields) > 0 && fields[0] == refclockDirective {
+					if _, err := ParseRefclock(line); err == nil {
+						continue
+					}
+				}
+				kept = append(kept, line)
+			}
+			var directives []string
+			for _, entry := range refclocks.GetRefclocks() {
+				directives = append(directives, RenderRefclock(entry))
+			}
+			return insertBeforeServers(kept, directives...)
+		})
+	})
+}
+
+// GetRefclocks reads the refclock lines of ntp.conf, reference clocks of the classic `server 127.127.t.u` syntax and
+// refclock lines which are no entry are not reported.
+func (n *NtpConfigurator) GetRefclocks() (*v1.Refclocks, error) {
+	input, err := n.Files.ReadFile(n.NtpConfPath)
+	if err != nil {
+		return nil, err
+	}
+	refclocks := &v1.Refclocks{}
+	for _, line := range splitLines(input) {
+		if fields := strings.Fields(line); len(fields) == 0 || fields[0] != refclockDirective {
+			continue
+		}
+		entry, err := ParseRefclock(line)
+		if err != nil {
+			log.Println("Skipping refclock entry:", err.Error())
+			continue
+		}
+		refclocks.Refclocks = append(refclocks.Refclocks, entry)
+	}
+	return refclocks, nil
+}
+
+// unmanagedRefclocks returns the names of the refclock lines which can not be parsed into an entry, e.g. JJY(0).
+func unmanagedRefclocks(lines []string) map[string]bool {
+	names := make(map[string]bool)
+	for _, line := range lines {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != refclockDirective {
+			continue
+		}
+		if _, err := ParseRefclock(line); err == nil {
+			continue
+		}
+		unit := "0"
+		for i := 2; i+1 < len(fields); i++ {
+			if fields[i] == "unit" {
+				unit = fields[i+1]
+			}
+		}
+		names[fmt.Sprintf("%s(%s)", strings.ToUpper(fields[1]), unit)] = true
+	}
+	return names
+}
+
+// isLegacyRefclock reports whether the fields are a `server` or `fudge` line of a reference clock in the classic syntax.
+func isLegacyRefclock(fields []string) bool {
+	return len(fields) > 1 && (fields[0] == serverDirective || fields[0] == "fudge") && strings.HasPrefix(fields[1], legacyRefclockPrefix)
+}
+
+// toRefclockStatus converts the clock variables of a reference clock, nil for network peers.
+func toRefclockStatus(clock ntpcontrol.Variables) *v1.RefclockStatus {
+	if clock == nil {
+		return nil
+	}
+	return &v1.RefclockStatus{
+		Name:      clock.String("name"),
+		Device:    clock.String("device"),
+		Timecode:  clock.String("timecode"),
+		Polls:     uint32(clock.Int("poll")),
+		NoReply:   uint32(clock.Int("noreply")),
+		BadFormat: uint32(clock.Int("badformat")),
+		BadData:   uint32(clock.Int("baddata")),
+		Time1:     float32(clock.Float("fudgetime1")),
+		Time2:     float32(clock.Float("fudgetime2")),
+	}
+}
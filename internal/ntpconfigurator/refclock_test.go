@@ -0,0 +1,175 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_RenderRefclock(t *testing.T) {
+	tests := []struct {
+		entry *v1.RefclockEntry
+		want  string
+	}{
+		{&v1.RefclockEntry{}, "refclock gpsd unit 0"},
+		{&v1.RefclockEntry{Driver: v1.RefclockDriver_SHM, Unit: 1, Refid: "GPS", Time1: 0.035, Prefer: true},
+			"refclock shm unit 1 time1 0.035 refid GPS prefer"},
+		{&v1.RefclockEntry{Driver: v1.RefclockDriver_NMEA, Mode: 18, Baud: 9600, Path: "/dev/ttyS0", Ppspath: "/dev/pps0", Time2: 0.4, Minpoll: 4, Maxpoll: 4},
+			"refclock nmea unit 0 mode 18 baud 9600 path /dev/ttyS0 ppspath /dev/pps0 time2 0.4 minpoll 4 maxpoll 4"},
+		{&v1.RefclockEntry{Driver: v1.RefclockDriver_PPS, Flag2: true, Flag3: true, Noselect: true},
+			"refclock pps unit 0 flag2 1 flag3 1 noselect"},
+		{&v1.RefclockEntry{Driver: v1.RefclockDriver_GENERIC, Subtype: 2, Stratum: 1},
+			"refclock generic unit 0 subtype 2 stratum 1"},
+	}
+	for _, tt := range tests {
+		line := RenderRefclock(tt.entry)
+		assert.Equal(t, tt.want, line)
+		parsed, err := ParseRefclock(line)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(tt.entry, parsed), "Did not get expected result. Wanted: %v, got: %v", tt.entry, parsed)
+	}
+}
+
+func Test_ParseRefclock(t *testing.T) {
+	entry, err := ParseRefclock("refclock pps flag3 ppspath /dev/pps1 prefer # kernel discipline")
+
+	assert.NoError(t, err)
+	assert.True(t, entry.GetFlag3())
+	assert.True(t, entry.GetPrefer())
+	assert.Equal(t, "/dev/pps1", entry.GetPpspath())
+
+	for _, line := range []string{"refclock", "refclock local unit 0", "refclock shm unit x", "refclock shm time1", "server 127.127.28.0",
+		"refclock jjy unit 0", "refclock gpsd unit 0 holdover 5"} {
+		_, err := ParseRefclock(line)
+		assert.ErrorIs(t, err, ErrInvalidRefclock, "Did not get expected result for %q", line)
+	}
+}
+
+func Test_ValidateRefclocks(t *testing.T) {
+	assert.NoError(t, ValidateRefclocks(&v1.Refclocks{}))
+	assert.NoError(t, ValidateRefclocks(&v1.Refclocks{Refclocks: []*v1.RefclockEntry{
+		{Driver: v1.RefclockDriver_SHM, Refid: "GPS"}, {Driver: v1.RefclockDriver_SHM, Unit: 1, Refid: "PPS", Prefer: true}}}))
+	assert.NoError(t, ValidateRefclocks(&v1.Refclocks{Refclocks: []*v1.RefclockEntry{
+		{Driver: v1.RefclockDriver_GPSD, Mode: 2}, {Driver: v1.RefclockDriver_NMEA, Mode: 1023}, {Driver: v1.RefclockDriver_GENERIC, Subtype: 2, Mode: 4096}}}))
+
+	invalid := []*v1.RefclockEntry{
+		nil,
+		{Driver: v1.RefclockDriver(9)},
+		{Unit: 256},
+		{Refid: "TOOLONG"},
+		{Refid: "G S"},
+		{Time1: float32(math.NaN())},
+		{Stratum: 16},
+		{Driver: v1.RefclockDriver_SHM, Subtype: 1},
+		{Driver: v1.RefclockDriver_PPS, Baud: 9600},
+		{Driver: v1.RefclockDriver_NMEA, Baud: 1234},
+		{Driver: v1.RefclockDriver_SHM, Ppspath: "/dev/pps0"},
+		{Driver: v1.RefclockDriver_NMEA, Path: "ttyS0"},
+		{Minpoll: 2},
+		{Minpoll: 6, Maxpoll: 4},
+		{Driver: v1.RefclockDriver_GPSD, Mode: 3},
+		{Driver: v1.RefclockDriver_NMEA, Mode: 1024},
+		{Driver: v1.RefclockDriver_PPS, Mode: 1},
+		{Driver: v1.RefclockDriver_SHM, Mode: 2},
+	}
+	for _, entry := range invalid {
+		assert.ErrorIs(t, ValidateRefclocks(&v1.Refclocks{Refclocks: []*v1.RefclockEntry{entry}}), ErrInvalidRefclock, "Did not get expected result for %v", entry)
+	}
+	assert.ErrorIs(t, ValidateRefclocks(&v1.Refclocks{Refclocks: []*v1.RefclockEntry{{}, {}}}), ErrInvalidRefclock)
+}
+
+func Test_SetRefclocks(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "driftfile /var/lib/ntpsec/ntp.drift\nrefclock shm unit 0\nserver 192.168.1.1 iburst\n")
+	refclocks := &v1.Refclocks{Refclocks: []*v1.RefclockEntry{
+		{Driver: v1.RefclockDriver_GPSD, Refid: "GPS"},
+		{Driver: v1.RefclockDriver_PPS, Prefer: true, Flag3: true},
+	}}
+
+	assert.NoError(t, tN.SetRefclocks(context.Background(), refclocks))
+
+	content, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "driftfile /var/lib/ntpsec/ntp.drift\n"+
+		"refclock gpsd unit 0 refid GPS\n"+
+		"refclock pps unit 0 flag3 1 prefer\n"+
+		"server 192.168.1.1 iburst\n", string(content))
+	read, err := tN.GetRefclocks()
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(refclocks, read), "Did not get expected result. Wanted: %v, got: %v", refclocks, read)
+}
+
+func Test_SetRefclocks_KeepsUnknownLines(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, "refclock jjy unit 1 path /dev/ttyS1\n"+
+		"refclock gpsd unit 0 holdover 5 # not an entry\n"+
+		"refclock shm unit 0\n"+
+		"server 192.168.1.1 iburst\n")
+
+	assert.NoError(t, tN.SetRefclocks(context.Background(), &v1.Refclocks{Refclocks: []*v1.RefclockEntry{{Driver: v1.RefclockDriver_PPS}}}))
+
+	content, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "refclock jjy unit 1 path /dev/ttyS1\n"+
+		"refclock gpsd unit 0 holdover 5 # not an entry\n"+
+		"refclock pps unit 0\n"+
+		"server 192.168.1.1 iburst\n", string(content))
+	read, err := tN.GetRefclocks()
+	assert.NoError(t, err)
+	assert.Len(t, read.GetRefclocks(), 1, "Did not get expected result. Only the entries must be reported")
+
+	err = tN.SetRefclocks(context.Background(), &v1.Refclocks{Refclocks: []*v1.RefclockEntry{{Driver: v1.RefclockDriver_GPSD}}})
+
+	assert.ErrorIs(t, err, ErrInvalidRefclock, "Did not get expected result. An entry must not duplicate a kept line")
+	after, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, string(content), string(after))
+}
+
+func Test_ReplaceCurrentNtpServers_KeepsRefclocks(t *testing.T) {
+	conf := "refclock shm unit 0 refid GPS\n" +
+		"server 127.127.22.0 minpoll 4\n" +
+		"fudge 127.127.22.0 flag3 1\n" +
+		"server old.example.com\n"
+	tN := prepareNtpConfiguratorWithKeys(t, conf)
+
+	assert.NoError(t, tN.ReplaceCurrentNtpServersOrPools(&v1.Ntp{NtpServer: []string{"new.example.com"}}))
+
+	content, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "refclock shm unit 0 refid GPS\n"+
+		"server 127.127.22.0 minpoll 4\n"+
+		"fudge 127.127.22.0 flag3 1\n"+
+		"server new.example.com\n", string(content))
+	servers, err := tN.GetCurrentNtpServers()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"new.example.com"}, servers.NtpServer)
+}
+
+func Test_toPeerDetails_Refclock(t *testing.T) {
+	now := time.Now()
+	peers := []ntpcontrol.Peer{{
+		AssociationID: 3,
+		Status:        0x9614,
+		Variables:     ntpcontrol.Variables{"srcadr": "127.127.28.0", "srchost": "SHM(0)", "refid": "GPS", "stratum": "0"},
+		Clock: ntpcontrol.Variables{"name": "SHM(0)", "device": "SHM/Shared memory interface", "timecode": "", "poll": "120",
+			"noreply": "2", "badformat": "0", "baddata": "1", "fudgetime1": "35.000"},
+	}}
+
+	details := toPeerDetails(peers, now)
+
+	assert.Len(t, details, 1)
+	assert.Equal(t, "SHM(0)", details[0].Address)
+	assert.Equal(t, "l", details[0].Type)
+	want := &v1.RefclockStatus{Name: "SHM(0)", Device: "SHM/Shared memory interface", Polls: 120, NoReply: 2, BadData: 1, Time1: 35}
+	assert.True(t, proto.Equal(want, details[0].Refclock), "Did not get expected result. Wanted: %v, got: %v", want, details[0].Refclock)
+	assert.Nil(t, toPeerDetails([]ntpcontrol.Peer{{Variables: ntpcontrol.Variables{"srcadr": "192.168.1.1"}}}, now)[0].Refclock)
+}
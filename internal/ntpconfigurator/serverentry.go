@@ -0,0 +1,177 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+)
+
+const serverDirective = "server"
+const poolDirective = "pool"
+const peerDirective = "peer"
+
+// entryDirectives maps the kind of an entry to its ntp.conf directive.
+var entryDirectives = map[v1.NtpEntryKind]string{
+	v1.NtpEntryKind_SERVER: serverDirective,
+	v1.NtpEntryKind_POOL:   poolDirective,
+	v1.NtpEntryKind_PEER:   peerDirective,
+}
+
+// Limits of the ntpsec server options, see ntp.conf(5).
+const (
+	minPollExponent = 3
+	maxPollExponent = 17
+	minNtpVersion   = 1
+	maxNtpVersion   = 4
+	maxKeyId        = 65535
+)
+
+// ErrInvalidServerEntry is returned when a server or pool entry can not be written to ntp.conf.
+var ErrInvalidServerEntry = errors.New("invalid ntp server entry")
+
+// ValidateServerEntry checks that the entry can be rendered into a valid ntpsec `server`, `pool` or `peer` line.
+func ValidateServerEntry(entry *v1.NtpServerEntry) error {
+	if entry == nil {
+		return fmt.Errorf("%w: entry is empty", ErrInvalidServerEntry)
+	}
+	if _, ok := entryDirectives[entry.GetKind()]; !ok {
+		return fmt.Errorf("%w: unknown kind %d of %s", ErrInvalidServerEntry, entry.GetKind(), entry.GetAddress())
+	}
+	address := entry.GetAddress()
+	if address == "" || strings.ContainsAny(address, " \t\r\n#") {
+		return fmt.Errorf("%w: address %q is not a single host name or ip address", ErrInvalidServerEntry, address)
+	}
+	if err := validatePollExponent("minpoll", entry.GetMinpoll()); err != nil {
+		return err
+	}
+	if err := validatePollExponent("maxpoll", entry.GetMaxpoll()); err != nil {
+		return err
+	}
+	if entry.GetMinpoll() != 0 && entry.GetMaxpoll() != 0 && entry.GetMinpoll() > entry.GetMaxpoll() {
+		return fmt.Errorf("%w: minpoll %d is greater than maxpoll %d of %s", ErrInvalidServerEntry, entry.GetMinpoll(), entry.GetMaxpoll(), address)
+	}
+	if entry.GetNts() && entry.GetKind() == v1.NtpEntryKind_PEER {
+		return fmt.Errorf("%w: nts can not be used with peer %s", ErrInvalidServerEntry, address)
+	}
+	if entry.GetNts() && entry.GetKeyId() != 0 {
+		return fmt.Errorf("%w: nts and key %d of %s can not be used together", ErrInvalidServerEntry, entry.GetKeyId(), address)
+	}
+	if entry.GetKeyId() > maxKeyId {
+		return fmt.Errorf("%w: key id %d of %s is out of range 1-%d", ErrInvalidServerEntry, entry.GetKeyId(), address, maxKeyId)
+	}
+	if version := entry.GetVersion(); version != 0 && (version < minNtpVersion || version > maxNtpVersion) {
+		return fmt.Errorf("%w: version %d of %s is out of range %d-%d", ErrInvalidServerEntry, version, address, minNtpVersion, maxNtpVersion)
+	}
+	return nil
+}
+
+func validatePollExponent(name string, value int32) error {
+	if value != 0 && (value < minPollExponent || value > maxPollExponent) {
+		return fmt.Errorf("%w: %s %d is out of range %d-%d", ErrInvalidServerEntry, name, value, minPollExponent, maxPollExponent)
+	}
+	return nil
+}
+
+// RenderServerEntry converts the entry into an ntpsec `server`, `pool` or `peer` line, e.g. `server 0.pool.ntp.org iburst prefer minpoll 4`.
+func RenderServerEntry(entry *v1.NtpServerEntry) string {
+	fields := []string{entryDirectives[entry.GetKind()], entry.GetAddress()}
+	if entry.GetIburst() {
+		fields = append(fields, "iburst")
+	}
+	if entry.GetPreempt() {
+		fields = append(fields, "preempt")
+	}
+	if entry.GetBurst() {
+		fields = append(fields, "burst")
+	}
+	if entry.GetPrefer() {
+		fields = append(fields, "prefer")
+	}
+	if entry.GetNoselect() {
+		fields = append(fields, "noselect")
+	}
+	if entry.GetNts() {
+		fields = append(fields, "nts")
+	}
+	if entry.GetMinpoll() != 0 {
+		fields = append(fields, "minpoll", strconv.Itoa(int(entry.GetMinpoll())))
+	}
+	if entry.GetMaxpoll() != 0 {
+		fields = append(fields, "maxpoll", strconv.Itoa(int(entry.GetMaxpoll())))
+	}
+	if entry.GetKeyId() != 0 {
+		fields = append(fields, "key", strconv.FormatUint(uint64(entry.GetKeyId()), 10))
+	}
+	if entry.GetVersion() != 0 {
+		fields = append(fields, "version", strconv.Itoa(int(entry.GetVersion())))
+	}
+	return strings.Join(fields, " ")
+}
+
+// ParseServerEntry parses an ntpsec `server`, `pool` or `peer` line back into an entry.
+// Options which are not part of NtpServerEntry are ignored.
+func ParseServerEntry(line string) (*v1.NtpServerEntry, error) {
+	if i := strings.Index(line, "#"); i >= 0 {
+		line = line[:i]
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("%w: %q is not a server or pool line", ErrInvalidServerEntry, line)
+	}
+	entry := &v1.NtpServerEntry{Address: fields[1]}
+	switch fields[0] {
+	case serverDirective:
+		entry.Kind = v1.NtpEntryKind_SERVER
+	case poolDirective:
+		entry.Kind = v1.NtpEntryKind_POOL
+	case peerDirective:
+		entry.Kind = v1.NtpEntryKind_PEER
+	default:
+		return nil, fmt.Errorf("%w: %q is not a server or pool line", ErrInvalidServerEntry, line)
+	}
+	for i := 2; i < len(fields); i++ {
+		switch fields[i] {
+		case "iburst":
+			entry.Iburst = true
+		case "preempt":
+			entry.Preempt = true
+		case "burst":
+			entry.Burst = true
+		case "prefer":
+			entry.Prefer = true
+		case "noselect":
+			entry.Noselect = true
+		case "nts":
+			entry.Nts = true
+		case "minpoll", "maxpoll", "key", "version":
+			if i+1 >= len(fields) {
+				return nil, fmt.Errorf("%w: option %s of %s has no value", ErrInvalidServerEntry, fields[i], entry.Address)
+			}
+			value, err := strconv.ParseUint(fields[i+1], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%w: option %s of %s has invalid value %q", ErrInvalidServerEntry, fields[i], entry.Address, fields[i+1])
+			}
+			switch fields[i] {
+			case "minpoll":
+				entry.Minpoll = int32(value)
+			case "maxpoll":
+				entry.Maxpoll = int32(value)
+			case "key":
+				entry.KeyId = uint32(value)
+			case "version":
+				entry.Version = int32(value)
+			}
+			i++
+		}
+	}
+	return entry, nil
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_RenderServerEntry_AllOptions(t *testing.T) {
+	entry := &v1.NtpServerEntry{Address: "0.tr.pool.ntp.org", Iburst: true, Burst: true, Prefer: true, Noselect: true,
+		Minpoll: 4, Maxpoll: 10, KeyId: 42, Version: 4}
+
+	line := RenderServerEntry(entry)
+
+	assert.Equal(t, "server 0.tr.pool.ntp.org iburst burst prefer noselect minpoll 4 maxpoll 10 key 42 version 4", line)
+}
+
+func Test_RenderServerEntry_AddressOnly(t *testing.T) {
+	assert.Equal(t, "server 192.168.0.1", RenderServerEntry(&v1.NtpServerEntry{Address: "192.168.0.1"}))
+}
+
+func Test_ParseServerEntry_RoundTrip(t *testing.T) {
+	entry := &v1.NtpServerEntry{Address: "fd00::1", Iburst: true, Prefer: true, Minpoll: 3, Maxpoll: 6, KeyId: 7}
+
+	parsed, err := ParseServerEntry(RenderServerEntry(entry))
+
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(entry, parsed), "expected %v, got %v", entry, parsed)
+}
+
+func Test_RenderServerEntry_Pool(t *testing.T) {
+	entry := &v1.NtpServerEntry{Kind: v1.NtpEntryKind_POOL, Address: "0.debian.pool.ntp.org", Iburst: true, Preempt: true}
+
+	line := RenderServerEntry(entry)
+	parsed, err := ParseServerEntry(line)
+
+	assert.Equal(t, "pool 0.debian.pool.ntp.org iburst preempt", line)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(entry, parsed), "expected %v, got %v", entry, parsed)
+}
+
+func Test_RenderServerEntry_Nts(t *testing.T) {
+	entry := &v1.NtpServerEntry{Address: "nts.example.com:4460", Iburst: true, Nts: true}
+
+	line := RenderServerEntry(entry)
+	parsed, err := ParseServerEntry(line)
+
+	assert.Equal(t, "server nts.example.com:4460 iburst nts", line)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(entry, parsed), "expected %v, got %v", entry, parsed)
+}
+
+func Test_ParseServerEntry_IgnoresUnknownOptionsAndComments(t *testing.T) {
+	parsed, err := ParseServerEntry("server time.example.com iburst xleave # lab server")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "time.example.com", parsed.Address)
+	assert.True(t, parsed.Iburst)
+}
+
+func Test_ParseServerEntry_Errors(t *testing.T) {
+	for _, line := range []string{"restrict default kod", "server", "server a.b minpoll", "server a.b key x"} {
+		_, err := ParseServerEntry(line)
+		assert.ErrorIs(t, err, ErrInvalidServerEntry, line)
+	}
+}
+
+func Test_ValidateServerEntry(t *testing.T) {
+	assert.NoError(t, ValidateServerEntry(&v1.NtpServerEntry{Address: "1.2.3.4", Minpoll: 4, Maxpoll: 17, KeyId: 1, Version: 3}))
+
+	invalid := []*v1.NtpServerEntry{
+		nil,
+		{Address: ""},
+		{Address: "1.2.3.4 iburst"},
+		{Address: "1.2.3.4", Minpoll: 2},
+		{Address: "1.2.3.4", Maxpoll: 18},
+		{Address: "1.2.3.4", Minpoll: 8, Maxpoll: 6},
+		{Address: "1.2.3.4", KeyId: 65536},
+		{Address: "1.2.3.4", Version: 5},
+		{Address: "1.2.3.4", Kind: v1.NtpEntryKind(7)},
+		{Address: "1.2.3.4", Nts: true, KeyId: 1},
+	}
+	for _, entry := range invalid {
+		assert.ErrorIs(t, ValidateServerEntry(entry), ErrInvalidServerEntry, "%v", entry)
+	}
+}
+
+func Test_ReplaceAndGetCurrentNtpServers_WithEntries(t *testing.T) {
+	tN := prepareNtpConfigurator()
+	tN.NtpConfPath = filepath.Join(t.TempDir(), "ntp.conf")
+	assert.NoError(t, os.WriteFile(tN.NtpConfPath, []byte("driftfile /var/lib/ntpsec/ntp.drift\npool 2.debian.pool.ntp.org iburst\nserver old.example.com\n"), 0644))
+
+	tN.ReplaceCurrentNtpServersOrPools(&v1.Ntp{
+		NtpServer: []string{"plain.example.com", "typed.example.com"},
+		NtpServerEntries: []*v1.NtpServerEntry{
+			{Address: "typed.example.com", Iburst: true, Prefer: true, Maxpoll: 8},
+			{Kind: v1.NtpEntryKind_POOL, Address: "0.debian.pool.ntp.org", Iburst: true},
+		},
+	})
+
+	content, err := os.ReadFile(tN.NtpConfPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "driftfile /var/lib/ntpsec/ntp.drift\nserver plain.example.com\nserver typed.example.com iburst prefer maxpoll 8\npool 0.debian.pool.ntp.org iburst\n", string(content))
+
+	servers, err := tN.GetCurrentNtpServers()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"plain.example.com", "typed.example.com"}, servers.NtpServer)
+	assert.Len(t, servers.NtpServerEntries, 3)
+	assert.Equal(t, "typed.example.com", servers.NtpServerEntries[1].Address)
+	assert.True(t, servers.NtpServerEntries[1].Prefer)
+	assert.Equal(t, int32(8), servers.NtpServerEntries[1].Maxpoll)
+	assert.Equal(t, v1.NtpEntryKind_POOL, servers.NtpServerEntries[2].Kind)
+}
+
+func Test_GetAndSetNtpServers_RoundTrip(t *testing.T) {
+	tN := prepareNtpConfigurator()
+	tN.NtpConfPath = filepath.Join(t.TempDir(), "ntp.conf")
+	conf := "driftfile /var/lib/ntpsec/ntp.drift\nserver a.example.com iburst prefer\nserver b.example.com\npool 0.debian.pool.ntp.org iburst\n"
+	assert.NoError(t, os.WriteFile(tN.NtpConfPath, []byte(conf), 0644))
+
+	servers, err := tN.GetCurrentNtpServers()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, servers.NtpServer)
+	assert.NoError(t, tN.ReplaceCurrentNtpServersOrPools(servers))
+
+	content, err := os.ReadFile(tN.NtpConfPath)
+	assert.NoError(t, err)
+	assert.Equal(t, conf, string(content), "Did not get expected result. Sending back the servers of GetNtpServer must not change ntp.conf")
+}
+
+func Test_serverLines_DeduplicatesAddresses(t *testing.T) {
+	lines := serverLines(&v1.Ntp{
+		NtpServer:        []string{"a.example.com", " a.example.com  iburst", "b.example.com iburst", "b.example.com", ""},
+		NtpServerEntries: []*v1.NtpServerEntry{{Address: "a.example.com", Prefer: true}},
+	}, RenderServerEntry)
+
+	assert.Equal(t, []string{"server b.example.com iburst", "server a.example.com prefer"}, lines)
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+)
+
+const restrictDirective = "restrict"
+const restrictDefault = "default"
+const restrictSource = "source"
+const restrictMask = "mask"
+const noServeFlag = "noserve"
+
+// defaultRestrictFlags are the flags of the default restrict rule of ntpsec, time is served but nothing else.
+const defaultRestrictFlags = "kod nomodify nopeer noquery limited"
+
+// localhostAddresses are never restricted, the service reads the status of ntpd through them.
+var localhostAddresses = []string{"127.0.0.1", "::1"}
+
+// restrictFlagNames maps the restrict flags to their names in ntp.conf.
+var restrictFlagNames = map[v1.RestrictFlag]string{
+	v1.RestrictFlag_NOMODIFY: "nomodify",
+	v1.RestrictFlag_NOQUERY:  "noquery",
+	v1.RestrictFlag_LIMITED:  "limited",
+	v1.RestrictFlag_KOD:      "kod",
+	v1.RestrictFlag_NOPEER:   "nopeer",
+}
+
+// ErrInvalidServing is returned when the serving configuration can not be written to ntp.conf.
+var ErrInvalidServing = errors.New("invalid serving configuration")
+
+// ValidateServing checks that the serving configuration can be written to ntp.conf.
+func ValidateServing(serving *v1.ServingConfig) error {
+	if !serving.GetEnabled() && len(serving.GetRules()) > 0 {
+		return fmt.Errorf("%w: rules require serving to be enabled", ErrInvalidServing)
+	}
+	subnets := make(map[netip.Prefix]bool)
+	for _, rule := range serving.GetRules() {
+		prefix, err := netip.ParsePrefix(rule.GetCidr())
+		if err != nil {
+			return fmt.Errorf("%w: %q is no CIDR subnet", ErrInvalidServing, rule.GetCidr())
+		}
+		if prefix.Masked() != prefix {
+			return fmt.Errorf("%w: %q has host bits set, use %s", ErrInvalidServing, rule.GetCidr(), prefix.Masked())
+		}
+		if subnets[prefix] {
+			return fmt.Errorf("%w: subnet %s is given twice", ErrInvalidServing, prefix)
+		}
+		subnets[prefix] = true
+		for _, flag := range rule.GetFlags() {
+			if _, ok := restrictFlagNames[flag]; !ok {
+				return fmt.Errorf("%w: unknown flag %d of subnet %s", ErrInvalidServing, flag, prefix)
+			}
+		}
+	}
+	return nil
+}
+
+// SetServing replaces the restrict rules of ntp.conf and restarts ntpsec, `restrict source` rules are kept.
+// If ntpsec does not restart the previous ntp.conf is restored and an *ApplyError is returned.
+func (n *NtpConfigurator) SetServing(ctx context.Context, serving *v1.ServingConfig) error {
+	if err := n.require(FeatureServing); err != nil {
+		return err
+	}
+	if err := ValidateServing(serving); err != nil {
+		return err
+	}
+	return n.applyChange(ctx, func() error {
+		return n.rewriteNtpConf(func(lines []string) []string {
+			var kept []string
+			for _, line := range lines {
+				if fields := strings.Fields(line); !isManagedRestrict(fields) {
+					kept = append(kept, line)
+				}
+			}
+			return insertBeforeServers(kept, restrictLines(serving)...)
+		})
+	})
+}
+
+// GetServing reads the time service offered to other devices from the restrict rules of ntp.conf.
+func (n *NtpConfigurator) GetServing() (*v1.ServingConfig, error) {
+	input, err := n.Files.ReadFile(n.NtpConfPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseServing(splitLines(input)), nil
+}
+
+// restrictLines renders the serving configuration, the default rule denies time service if only subnets are served.
+func restrictLines(serving *v1.ServingConfig) []string {
+	defaultRule := strings.Join([]string{restrictDirective, restrictDefault, defaultRestrictFlags}, " ")
+	if !serving.GetEnabled() || len(serving.GetRules()) > 0 {
+		defaultRule += " " + noServeFlag
+	}
+	lines := []string{defaultRule}
+	for _, address := range localhostAddresses {
+		lines = append(lines, restrictDirective+" "+address)
+	}
+	for _, rule := range serving.GetRules() {
+		prefix := netip.MustParsePrefix(rule.GetCidr())
+		mask := net.CIDRMask(prefix.Bits(), prefix.Addr().BitLen())
+		line := []string{restrictDirective, prefix.Addr().String(), restrictMask, net.IP(mask).String()}
+		lines = append(lines, strings.Join(append(line, flagNames(rule.GetFlags())...), " "))
+	}
+	return lines
+}
+
+// parseServing converts the restrict rules into the serving configuration. Without a default rule ntpd serves
+// everybody.
+func parseServing(lines []string) *v1.ServingConfig {
+	serving := &v1.ServingConfig{Enabled: true}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if !isManagedRestrict(fields) || isLocalhost(fields[1]) {
+			continue
+		}
+		if fields[1] == restrictDefault {
+			serving.Enabled = !containsField(fields[2:], noServeFlag)
+			continue
+		}
+		rule, err := parseRestrictRule(fields)
+		if err != nil {
+			log.Println("Skipping restrict rule:", err.Error())
+			continue
+		}
+		serving.Rules = append(serving.Rules, rule)
+	}
+	if len(serving.Rules) > 0 {
+		serving.Enabled = true
+	}
+	return serving
+}
+
+// parseRestrictRule parses `restrict address [mask mask] [flag...]`, the address may also be given as CIDR subnet.
+func parseRestrictRule(fields []string) (*v1.RestrictRule, error) {
+	args := fields[1:]
+	if args[0] == "-4" || args[0] == "-6" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%q has no address", strings.Join(fields, " "))
+	}
+	prefix, err := netip.ParsePrefix(args[0])
+	if err != nil {
+		address, err := netip.ParseAddr(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("%q has no address", strings.Join(fields, " "))
+		}
+		prefix = netip.PrefixFrom(address, address.BitLen())
+	}
+	args = args[1:]
+	if len(args) >= 2 && args[0] == restrictMask {
+		mask := net.ParseIP(args[1])
+		if mask == nil {
+			return nil, fmt.Errorf("%q has an invalid mask", strings.Join(fields, " "))
+		}
+		if prefix.Addr().Is4() {
+			mask = mask.To4()
+		}
+		ones, bits := net.IPMask(mask).Size()
+		if bits == 0 {
+			return nil, fmt.Errorf("%q has an invalid mask", strings.Join(fields, " "))
+		}
+		prefix = netip.PrefixFrom(prefix.Addr(), ones)
+		args = args[2:]
+	}
+	rule := &v1.RestrictRule{Cidr: prefix.Masked().String()}
+	for _, name := range args {
+		for flag, flagName := range restrictFlagNames {
+			if name == flagName {
+				rule.Flags = append(rule.Flags, flag)
+			}
+		}
+	}
+	sort.Slice(rule.Flags, func(i, j int) bool { return rule.Flags[i] < rule.Flags[j] })
+	return rule, nil
+}
+
+// isManagedRestrict reports whether the line is a restrict rule replaced by SetServing.
+func isManagedRestrict(fields []string) bool {
+	return len(fields) > 1 && fields[0] == restrictDirective && fields[1] != restrictSource
+}
+
+func isLocalhost(address string) bool {
+	for _, localhost := range localhostAddresses {
+		if address == localhost {
+			return true
+		}
+	}
+	return false
+}
+
+func containsField(fields []string, name string) bool {
+	for _, field := range fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// flagNames returns the names of the flags in the order of their values without duplicates.
+func flagNames(flags []v1.RestrictFlag) []string {
+	sorted := append([]v1.RestrictFlag(nil), flags...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var names []string
+	for i, flag := range sorted {
+		if i == 0 || flag != sorted[i-1] {
+			names = append(names, restrictFlagNames[flag])
+		}
+	}
+	return names
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const tDebianConf = "driftfile /var/lib/ntpsec/ntp.drift\n" +
+	"restrict default kod nomodify nopeer noquery limited\n" +
+	"restrict 127.0.0.1\n" +
+	"restrict ::1\n" +
+	"restrict source nomodify noquery\n" +
+	"server 192.168.1.1 iburst\n"
+
+func Test_ValidateServing(t *testing.T) {
+	assert.NoError(t, ValidateServing(&v1.ServingConfig{}))
+	assert.NoError(t, ValidateServing(&v1.ServingConfig{Enabled: true}))
+	assert.NoError(t, ValidateServing(&v1.ServingConfig{Enabled: true, Rules: []*v1.RestrictRule{
+		{Cidr: "192.168.10.0/24", Flags: []v1.RestrictFlag{v1.RestrictFlag_NOMODIFY}}, {Cidr: "fd00::/64"}}}))
+
+	invalid := []*v1.ServingConfig{
+		{Rules: []*v1.RestrictRule{{Cidr: "192.168.10.0/24"}}},
+		{Enabled: true, Rules: []*v1.RestrictRule{{Cidr: "192.168.10.0"}}},
+		{Enabled: true, Rules: []*v1.RestrictRule{{Cidr: "192.168.10.5/24"}}},
+		{Enabled: true, Rules: []*v1.RestrictRule{{Cidr: "10.0.0.0/8"}, {Cidr: "10.0.0.0/8"}}},
+		{Enabled: true, Rules: []*v1.RestrictRule{{Cidr: "10.0.0.0/8", Flags: []v1.RestrictFlag{9}}}},
+	}
+	for _, serving := range invalid {
+		assert.ErrorIs(t, ValidateServing(serving), ErrInvalidServing, "Did not get expected result for %v", serving)
+	}
+}
+
+func Test_SetServing(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, tDebianConf)
+	serving := &v1.ServingConfig{Enabled: true, Rules: []*v1.RestrictRule{
+		{Cidr: "192.168.10.0/24", Flags: []v1.RestrictFlag{v1.RestrictFlag_KOD, v1.RestrictFlag_NOMODIFY, v1.RestrictFlag_LIMITED}},
+		{Cidr: "fd00::/64", Flags: []v1.RestrictFlag{v1.RestrictFlag_NOQUERY}},
+	}}
+
+	assert.NoError(t, tN.SetServing(context.Background(), serving))
+
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "driftfile /var/lib/ntpsec/ntp.drift\n"+
+		"restrict source nomodify noquery\n"+
+		"restrict default kod nomodify nopeer noquery limited noserve\n"+
+		"restrict 127.0.0.1\n"+
+		"restrict ::1\n"+
+		"restrict 192.168.10.0 mask 255.255.255.0 nomodify limited kod\n"+
+		"restrict fd00:: mask ffff:ffff:ffff:ffff:: noquery\n"+
+		"server 192.168.1.1 iburst\n", string(conf))
+	read, err := tN.GetServing()
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.10.0/24", read.Rules[0].Cidr)
+	assert.Equal(t, []v1.RestrictFlag{v1.RestrictFlag_NOMODIFY, v1.RestrictFlag_LIMITED, v1.RestrictFlag_KOD}, read.Rules[0].Flags)
+	assert.Equal(t, "fd00::/64", read.Rules[1].Cidr)
+	assert.True(t, read.Enabled)
+}
+
+func Test_SetServing_EnableAndDisable(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, tDebianConf)
+
+	read, _ := tN.GetServing()
+	assert.True(t, proto.Equal(&v1.ServingConfig{Enabled: true}, read), "Did not get expected result. The ntpsec default serves everybody")
+
+	assert.NoError(t, tN.SetServing(context.Background(), &v1.ServingConfig{}))
+	read, _ = tN.GetServing()
+	assert.False(t, read.Enabled)
+
+	assert.NoError(t, tN.SetServing(context.Background(), &v1.ServingConfig{Enabled: true}))
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Contains(t, string(conf), "restrict default kod nomodify nopeer noquery limited\n")
+	read, _ = tN.GetServing()
+	assert.True(t, read.Enabled)
+	assert.Empty(t, read.Rules)
+}
+
+func Test_parseServing(t *testing.T) {
+	serving := parseServing([]string{
+		"restrict default ignore noserve",
+		"restrict -4 10.1.0.0 mask 255.255.0.0 nomodify notrap",
+		"restrict 10.2.0.0/16 noquery",
+		"restrict 10.3.0.1",
+		"restrict mask",
+	})
+
+	assert.True(t, serving.Enabled)
+	assert.Len(t, serving.Rules, 3)
+	assert.Equal(t, "10.1.0.0/16", serving.Rules[0].Cidr)
+	assert.Equal(t, []v1.RestrictFlag{v1.RestrictFlag_NOMODIFY}, serving.Rules[0].Flags)
+	assert.Equal(t, "10.2.0.0/16", serving.Rules[1].Cidr)
+	assert.Equal(t, "10.3.0.1/32", serving.Rules[2].Cidr)
+	assert.True(t, parseServing(nil).Enabled, "Did not get expected result. Without restrict rules ntpd serves everybody")
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"log"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+)
+
+// systemVariableNames are the system variables of ntpd reported in the status, ntpq -c rv prints them as well.
+var systemVariableNames = []string{"offset", "frequency", "sys_jitter", "clk_wander", "rootdelay", "rootdisp",
+	"leap", "stratum", "refid", "peer", "ss_uptime"}
+
+// readSystemVariables reads the system variables of the time daemon, nil if it does not answer within the control
+// timeout or the deadline of ctx.
+func (n *NtpConfigurator) readSystemVariables(ctx context.Context) *v1.SystemVariables {
+	ctx, cancel := context.WithTimeout(ctx, controlTimeout)
+	defer cancel()
+	system, err := n.Backend.SystemVariables(ctx, systemVariableNames...)
+	if err != nil {
+		log.Println("Cannot read system variables from", n.Backend.Name()+":", err.Error())
+		return nil
+	}
+	return toSystemVariables(system)
+}
+
+// toSystemVariables converts the system variables, variables the daemon does not send are 0.
+func toSystemVariables(system ntpcontrol.Variables) *v1.SystemVariables {
+	return &v1.SystemVariables{
+		Offset:         float32(system.Float("offset")),
+		Frequency:      float32(system.Float("frequency")),
+		SysJitter:      float32(system.Float("sys_jitter")),
+		ClkWander:      float32(system.Float("clk_wander")),
+		RootDelay:      float32(system.Float("rootdelay")),
+		RootDispersion: float32(system.Float("rootdisp")),
+		Leap:           uint32(system.Int("leap")),
+		Stratum:        uint32(system.Int("stratum")),
+		Refid:          system.String("refid"),
+		SystemPeer:     uint32(system.Int("peer")),
+		Uptime:         uint64(system.Int("ss_uptime")),
+	}
+}
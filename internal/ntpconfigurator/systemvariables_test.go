@@ -0,0 +1,65 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+	"ntpservice/internal/ntpcontrol/ntpcontroltest"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+var tSystemVariables = ntpcontrol.Variables{
+	"offset":     "-0.012345",
+	"frequency":  "-12.5",
+	"sys_jitter": "0.0421",
+	"clk_wander": "0.003",
+	"rootdelay":  "15.274",
+	"rootdisp":   "22.6",
+	"leap":       "0",
+	"stratum":    "3",
+	"refid":      "192.168.1.1",
+	"peer":       "12345",
+	"ss_uptime":  "86400",
+}
+
+func Test_readSystemVariables(t *testing.T) {
+	server, err := ntpcontroltest.NewServer(tSystemVariables, tAssociations(time.Now()), ntpcontroltest.Options{})
+	assert.NoError(t, err)
+	t.Cleanup(func() { server.Close() })
+	tN := prepareNtpConfigurator()
+	tN.Backend = &NtpSecBackend{Exec: tExecutor{}, Control: ntpcontrol.NewClient(server.Addr)}
+
+	system := tN.readSystemVariables(context.Background())
+
+	expected := &v1.SystemVariables{Offset: -0.012345, Frequency: -12.5, SysJitter: 0.0421, ClkWander: 0.003,
+		RootDelay: 15.274, RootDispersion: 22.6, Stratum: 3, Refid: "192.168.1.1", SystemPeer: 12345, Uptime: 86400}
+	assert.True(t, proto.Equal(expected, system), "Did not get expected result. Wanted: %v, got: %v", expected, system)
+}
+
+func Test_readSystemVariables_NtpdNotRunning(t *testing.T) {
+	server, err := ntpcontroltest.NewServer(nil, nil, ntpcontroltest.Options{})
+	assert.NoError(t, err)
+	server.Close()
+	tN := prepareNtpConfigurator()
+	tN.Backend = &NtpSecBackend{Exec: tExecutor{}, Control: ntpcontrol.NewClient(server.Addr)}
+
+	assert.Nil(t, tN.readSystemVariables(context.Background()))
+}
+
+func Test_toSystemVariables_Unsynchronized(t *testing.T) {
+	system := toSystemVariables(ntpcontrol.Variables{"stratum": "16", "leap": "3", "refid": "INIT"})
+
+	expected := &v1.SystemVariables{Leap: 3, Stratum: 16, Refid: "INIT"}
+	assert.True(t, proto.Equal(expected, system), "Did not get expected result. Wanted: %v, got: %v", expected, system)
+}
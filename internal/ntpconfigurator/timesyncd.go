@@ -0,0 +1,345 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+	"ntpservice/internal/servicemanager"
+	"ntpservice/utils/executor"
+)
+
+// timesyncdConfigPath is the drop-in with the servers, the settings of timesyncd.conf and other drop-ins are kept.
+const timesyncdConfigPath = "/etc/systemd/timesyncd.conf.d/dm-ntp.conf"
+const timesyncdMainConfigPath = "/etc/systemd/timesyncd.conf"
+
+// TimesyncdService is the name of the systemd-timesyncd service, timesyncd is only available with systemd.
+const TimesyncdService = "systemd-timesyncd"
+
+var timesyncdShowCmd = executor.NewCommand(queryTimeout, "timedatectl", "show-timesync", "--all")
+
+// timesyncdSyncMarker is touched by timesyncd on every synchronization.
+const timesyncdSyncMarker = "/run/systemd/timesync/synchronized"
+
+// Section and keys of the drop-in.
+const (
+	timeSection           = "[Time]"
+	ntpKey                = "NTP"
+	fallbackNtpKey        = "FallbackNTP"
+	timesyncdDropInHeader = "# Written by dm-ntp, the servers are replaced by SetNtpServer."
+)
+
+// unsynchronizedStratum is the stratum ntpd reports while it is not synchronized.
+const unsynchronizedStratum = "16"
+
+// systemdDurationUnits are the units of the time spans printed by timedatectl, e.g. 2min 8s.
+var systemdDurationUnits = map[string]time.Duration{
+	"us":  time.Microsecond,
+	"ms":  time.Millisecond,
+	"s":   time.Second,
+	"min": time.Minute,
+	"h":   time.Hour,
+	"d":   24 * time.Hour,
+}
+
+// TimesyncdBackend configures systemd-timesyncd, the status is read with timedatectl.
+// timesyncd uses a single server at a time, it is reported as the only association.
+type TimesyncdBackend struct {
+	Exec           executor.Executor
+	Services       servicemanager.ServiceManager
+	SyncMarkerPath string
+	Daemon
+}
+
+// NewTimesyncdBackend returns the systemd-timesyncd backend.
+func NewTimesyncdBackend(ex executor.Executor, services servicemanager.ServiceManager) *TimesyncdBackend {
+	return &TimesyncdBackend{Exec: ex, Services: services, SyncMarkerPath: timesyncdSyncMarker}
+}
+
+func (b *TimesyncdBackend) Name() string {
+	return TimesyncdBackendName
+}
+
+func (b *TimesyncdBackend) ConfPath() string {
+	return timesyncdConfigPath
+}
+
+// OptionalConf reports true, the drop-in is created with the first SetNtpServer.
+func (b *TimesyncdBackend) OptionalConf() bool {
+	return true
+}
+
+// ReplaceServers replaces the NTP= and FallbackNTP= settings of the [Time] section, the options of the servers
+// are not written as timesyncd has none.
+func (b *TimesyncdBackend) ReplaceServers(conf []byte, config *v1.Ntp) []byte {
+	settings := []string{ntpKey + "=" + strings.Join(timesyncdServers(config), " ")}
+	if fallback := config.GetFallbackNtpServer(); len(fallback) > 0 {
+		settings = append(settings, fallbackNtpKey+"="+strings.Join(fallback, " "))
+	}
+	lines := splitLines(conf)
+	if len(lines) == 0 {
+		lines = []string{timesyncdDropInHeader}
+	}
+	var kept []string
+	inserted, inTime := false, false
+	for _, line := range lines {
+		if section, ok := iniSection(line); ok {
+			inTime = section == timeSection
+			kept = append(kept, line)
+			if inTime && !inserted {
+				kept = append(kept, settings...)
+				inserted = true
+			}
+			continue
+		}
+		if key, _, ok := iniSetting(line); inTime && ok && (key == ntpKey || key == fallbackNtpKey) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if !inserted {
+		kept = append(kept, timeSection)
+		kept = append(kept, settings...)
+	}
+	return []byte(strings.Join(kept, "\n") + "\n")
+}
+
+// timesyncdServers returns the addresses of the configuration, a typed entry replaces a plain address with the same
+// value as in ntp.conf.
+func timesyncdServers(config *v1.Ntp) []string {
+	var addresses []string
+	seen := make(map[string]bool)
+	add := func(address string) {
+		if !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	for _, val := range config.GetNtpServer() {
+		if fields := strings.Fields(val); len(fields) > 0 {
+			add(fields[0])
+		}
+	}
+	for _, entry := range config.GetNtpServerEntries() {
+		add(entry.GetAddress())
+	}
+	return addresses
+}
+
+// ReadServers returns the servers of the NTP= and FallbackNTP= settings, an empty setting resets the list as in systemd.
+func (b *TimesyncdBackend) ReadServers(conf []byte) *v1.Ntp {
+	ntpServers := &v1.Ntp{}
+	inTime := false
+	for _, line := range splitLines(conf) {
+		if section, ok := iniSection(line); ok {
+			inTime = section == timeSection
+			continue
+		}
+		key, value, ok := iniSetting(line)
+		if !inTime || !ok {
+			continue
+		}
+		switch key {
+		case ntpKey:
+			if value == "" {
+				ntpServers.NtpServer, ntpServers.NtpServerEntries = nil, nil
+			}
+			for _, address := range strings.Fields(value) {
+				ntpServers.NtpServer = append(ntpServers.NtpServer, address)
+				ntpServers.NtpServerEntries = append(ntpServers.NtpServerEntries, &v1.NtpServerEntry{Address: address})
+			}
+		case fallbackNtpKey:
+			if value == "" {
+				ntpServers.FallbackNtpServer = nil
+			}
+			ntpServers.FallbackNtpServer = append(ntpServers.FallbackNtpServer, strings.Fields(value)...)
+		}
+	}
+	return ntpServers
+}
+
+// iniSection returns the section of a section header line, e.g. [Time].
+func iniSection(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	return line, strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]")
+}
+
+// iniSetting returns the key and value of a setting line, comments are no settings.
+func iniSetting(line string) (string, string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+		return "", "", false
+	}
+	key, value, ok := strings.Cut(line, "=")
+	return strings.TrimSpace(key), strings.TrimSpace(value), ok
+}
+
+func (b *TimesyncdBackend) Stop(ctx context.Context) error {
+	return b.Services.Stop(ctx, b.service(TimesyncdService))
+}
+
+func (b *TimesyncdBackend) Start(ctx context.Context) error {
+	return b.Services.Start(ctx, b.service(TimesyncdService))
+}
+
+func (b *TimesyncdBackend) Restart(ctx context.Context) error {
+	return b.Services.Restart(ctx, b.service(TimesyncdService))
+}
+
+func (b *TimesyncdBackend) Active(ctx context.Context) bool {
+	return b.Services.Active(ctx, b.service(TimesyncdService))
+}
+
+// Step does nothing, timesyncd has no one-shot mode and steps the clock itself after the start.
+func (b *TimesyncdBackend) Step(ctx context.Context) error {
+	log.Println("timesyncd sets the system time after the start")
+	return nil
+}
+
+// Peers returns the server timesyncd uses, none if it has not selected one.
+func (b *TimesyncdBackend) Peers(ctx context.Context) ([]ntpcontrol.Peer, error) {
+	properties, err := b.showTimesync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if properties["ServerAddress"] == "" {
+		return nil, nil
+	}
+	synced, lastSync := b.synchronized()
+	message := parseNtpMessage(properties["NTPMessage"])
+	variables := ntpcontrol.Variables{
+		"srcadr":    properties["ServerAddress"],
+		"srchost":   properties["ServerName"],
+		"hmode":     strconv.Itoa(ntpcontrol.ModeClient),
+		"stratum":   message["Stratum"],
+		"leap":      message["Leap"],
+		"precision": message["Precision"],
+		"rootdelay": durationToMilliseconds(message["RootDelay"]),
+		"rootdisp":  durationToMilliseconds(message["RootDispersion"]),
+		"jitter":    durationToMilliseconds(message["Jitter"]),
+	}
+	if poll := parseSystemdDuration(properties["PollIntervalUSec"]); poll > 0 {
+		variables["hpoll"] = strconv.Itoa(int(math.Round(math.Log2(poll.Seconds()))))
+	}
+	status := ntpcontrol.PeerConfigured | uint16(ntpcontrol.SelectionReject)<<8
+	if len(message) > 0 {
+		// timesyncd keeps only the last message, the reach register shows that it was answered.
+		variables["reach"] = "1"
+		status = ntpcontrol.PeerConfigured | ntpcontrol.PeerReachable | uint16(ntpcontrol.SelectionCandidate)<<8
+	}
+	if synced {
+		variables["rec"] = ntpcontrol.FormatTime(lastSync)
+		status = ntpcontrol.PeerConfigured | ntpcontrol.PeerReachable | uint16(ntpcontrol.SelectionSystemPeer)<<8
+	}
+	return []ntpcontrol.Peer{{AssociationID: 1, Status: status, Variables: variables}}, nil
+}
+
+// SystemVariables returns the stratum, reference id and leap indicator derived from the server timesyncd uses and
+// the frequency correction of the kernel, the names are ignored.
+func (b *TimesyncdBackend) SystemVariables(ctx context.Context, names ...string) (ntpcontrol.Variables, error) {
+	properties, err := b.showTimesync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	message := parseNtpMessage(properties["NTPMessage"])
+	if synced, _ := b.synchronized(); !synced || message["Stratum"] == "" {
+		return ntpcontrol.Variables{"stratum": unsynchronizedStratum, "leap": "3"}, nil
+	}
+	stratum, _ := strconv.Atoi(message["Stratum"])
+	return ntpcontrol.Variables{
+		"stratum":   strconv.Itoa(stratum + 1),
+		"refid":     properties["ServerAddress"],
+		"leap":      message["Leap"],
+		"rootdelay": durationToMilliseconds(message["RootDelay"]),
+		"rootdisp":  durationToMilliseconds(message["RootDispersion"]),
+		"frequency": kernelFrequencyToPpm(properties["Frequency"]),
+		"peer":      "1",
+	}, nil
+}
+
+// Supports reports true for the fallback servers only, the other features are written in ntpsec syntax.
+func (b *TimesyncdBackend) Supports(feature Feature) bool {
+	return feature == FeatureFallback
+}
+
+func (b *TimesyncdBackend) showTimesync(ctx context.Context) (map[string]string, error) {
+	out, err := b.Exec.Run(ctx, timesyncdShowCmd)
+	if err != nil {
+		return nil, err
+	}
+	properties := make(map[string]string)
+	for _, line := range strings.Split(string(out), "\n") {
+		if key, value, ok := strings.Cut(line, "="); ok {
+			properties[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return properties, nil
+}
+
+// synchronized reports whether timesyncd synchronized the clock and when it did it the last time.
+func (b *TimesyncdBackend) synchronized() (bool, time.Time) {
+	info, err := os.Stat(b.SyncMarkerPath)
+	if err != nil {
+		return false, time.Time{}
+	}
+	return true, info.ModTime()
+}
+
+// parseNtpMessage parses the NTPMessage property, e.g. `{ Leap=0, Version=4, Stratum=2, RootDelay=15.274ms }`.
+func parseNtpMessage(value string) map[string]string {
+	message := make(map[string]string)
+	value = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(value), "{"), "}")
+	for _, field := range strings.Split(value, ",") {
+		if key, fieldValue, ok := strings.Cut(field, "="); ok {
+			message[strings.TrimSpace(key)] = strings.TrimSpace(fieldValue)
+		}
+	}
+	return message
+}
+
+// parseSystemdDuration parses a time span printed by systemd, e.g. `2min 8s` or `518us`, 0 if it is invalid.
+func parseSystemdDuration(value string) time.Duration {
+	var duration time.Duration
+	for _, part := range strings.Fields(value) {
+		end := strings.IndexFunc(part, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if end <= 0 {
+			return 0
+		}
+		number, err := strconv.ParseFloat(part[:end], 64)
+		unit, ok := systemdDurationUnits[part[end:]]
+		if err != nil || !ok {
+			return 0
+		}
+		duration += time.Duration(number * float64(unit))
+	}
+	return duration
+}
+
+// durationToMilliseconds converts a time span printed by systemd into the unit ntpd uses, empty if it is missing.
+func durationToMilliseconds(value string) string {
+	if value == "" {
+		return ""
+	}
+	return strconv.FormatFloat(float64(parseSystemdDuration(value))/float64(time.Millisecond), 'f', -1, 64)
+}
+
+// kernelFrequencyToPpm converts the Frequency property, the frequency offset of the kernel in ppm scaled by 2^16.
+func kernelFrequencyToPpm(value string) string {
+	frequency, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatFloat(float64(frequency)/65536, 'f', -1, 64)
+}
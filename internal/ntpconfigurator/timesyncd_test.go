@@ -0,0 +1,190 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+	"ntpservice/internal/servicemanager/servicemanagertest"
+	"ntpservice/utils/executor"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const tShowTimesync = "SystemNTPServers=\n" +
+	"FallbackNTPServers=0.debian.pool.ntp.org\n" +
+	"ServerName=192.168.1.1\n" +
+	"ServerAddress=192.168.1.1\n" +
+	"RootDistanceMaxUSec=5s\n" +
+	"PollIntervalUSec=2min 8s\n" +
+	"NTPMessage={ Leap=0, Version=4, Mode=4, Stratum=2, Precision=-23, RootDelay=15.274ms, RootDispersion=518us, " +
+	"Reference=C0A80101, OriginateTimestamp=Sun 2026-10-18 10:00:00 UTC, Ignored=no, PacketCount=5, Jitter=1.5ms }\n" +
+	"Frequency=-1234567\n"
+
+// tTimesyncdExecutor answers timedatectl and records all commands.
+type tTimesyncdExecutor struct {
+	tFailingExecutor
+	show string
+}
+
+func (o *tTimesyncdExecutor) Run(ctx context.Context, command executor.Command) ([]byte, error) {
+	if _, err := o.tFailingExecutor.Run(ctx, command); err != nil {
+		return nil, err
+	}
+	if command.String() == timesyncdShowCmd.String() {
+		return []byte(o.show), nil
+	}
+	return []byte{}, nil
+}
+
+func prepareTimesyncd(t *testing.T, show string) (*NtpConfigurator, *TimesyncdBackend, *servicemanagertest.ServiceManager) {
+	tN := prepareNtpConfigurator()
+	dir := t.TempDir()
+	tN.NtpConfPath = filepath.Join(dir, "timesyncd.conf.d", "dm-ntp.conf")
+	tN.HistoryPath = filepath.Join(dir, "history")
+	ut := &tTimesyncdExecutor{show: show}
+	services := servicemanagertest.New(TimesyncdService)
+	backend := NewTimesyncdBackend(ut, services)
+	backend.SyncMarkerPath = filepath.Join(dir, "synchronized")
+	tN.Backend = backend
+	return tN, backend, services
+}
+
+func Test_TimesyncdReplaceServers(t *testing.T) {
+	backend := NewTimesyncdBackend(tExecutor{}, servicemanagertest.New())
+	config := &v1.Ntp{
+		NtpServer:         []string{"10.0.0.1 iburst", "10.0.0.2"},
+		NtpServerEntries:  []*v1.NtpServerEntry{{Address: "10.0.0.2", Prefer: true}, {Address: "pool.example", Kind: v1.NtpEntryKind_POOL}},
+		FallbackNtpServer: []string{"0.debian.pool.ntp.org", "1.debian.pool.ntp.org"},
+	}
+
+	conf := backend.ReplaceServers(nil, config)
+	assert.Equal(t, timesyncdDropInHeader+"\n[Time]\nNTP=10.0.0.1 10.0.0.2 pool.example\n"+
+		"FallbackNTP=0.debian.pool.ntp.org 1.debian.pool.ntp.org\n", string(conf))
+
+	conf = backend.ReplaceServers([]byte("# local\n[Time]\nNTP=192.168.1.1\nFallbackNTP=192.168.1.2\nPollIntervalMaxSec=256\n"),
+		&v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+	assert.Equal(t, "# local\n[Time]\nNTP=10.0.0.1\nPollIntervalMaxSec=256\n", string(conf),
+		"Did not get expected result. The other settings must be kept")
+
+	conf = backend.ReplaceServers([]byte("# local\n"), &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+	assert.Equal(t, "# local\n[Time]\nNTP=10.0.0.1\n", string(conf))
+}
+
+func Test_TimesyncdReadServers(t *testing.T) {
+	backend := NewTimesyncdBackend(tExecutor{}, servicemanagertest.New())
+
+	ntpServers := backend.ReadServers([]byte("[Time]\nNTP=192.168.1.1\nNTP=\nNTP=10.0.0.1 10.0.0.2\n# NTP=10.0.0.9\n" +
+		"FallbackNTP=0.debian.pool.ntp.org\n[Other]\nNTP=10.0.0.3\n"))
+
+	expected := &v1.Ntp{
+		NtpServer:         []string{"10.0.0.1", "10.0.0.2"},
+		NtpServerEntries:  []*v1.NtpServerEntry{{Address: "10.0.0.1"}, {Address: "10.0.0.2"}},
+		FallbackNtpServer: []string{"0.debian.pool.ntp.org"},
+	}
+	assert.True(t, proto.Equal(expected, ntpServers), "Did not get expected result. Wanted: %v, got: %v", expected, ntpServers)
+	assert.True(t, proto.Equal(&v1.Ntp{}, backend.ReadServers(nil)))
+}
+
+func Test_parseSystemdDuration(t *testing.T) {
+	assert.Equal(t, 128*time.Second, parseSystemdDuration("2min 8s"))
+	assert.Equal(t, 15274*time.Microsecond, parseSystemdDuration("15.274ms"))
+	assert.Equal(t, 518*time.Microsecond, parseSystemdDuration("518us"))
+	assert.Equal(t, time.Duration(0), parseSystemdDuration("infinity"))
+	assert.Equal(t, time.Duration(0), parseSystemdDuration(""))
+}
+
+func Test_TimesyncdPeers(t *testing.T) {
+	_, backend, _ := prepareTimesyncd(t, tShowTimesync)
+
+	peers, err := backend.Peers(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, peers, 1)
+	peer := peers[0]
+	assert.Equal(t, ntpcontrol.SelectionCandidate, peer.Selection())
+	assert.True(t, peer.Reachable())
+	assert.Equal(t, "192.168.1.1", peer.Remote())
+	assert.Equal(t, int64(128), peer.Poll())
+	assert.Equal(t, int64(2), peer.Variables.Int("stratum"))
+	assert.InDelta(t, 15.274, peer.Variables.Float("rootdelay"), 1e-9)
+	assert.InDelta(t, 1.5, peer.Variables.Float("jitter"), 1e-9)
+
+	synced := time.Now().Add(-30 * time.Second).Truncate(time.Second)
+	assert.NoError(t, os.WriteFile(backend.SyncMarkerPath, nil, 0644))
+	assert.NoError(t, os.Chtimes(backend.SyncMarkerPath, synced, synced))
+
+	peers, err = backend.Peers(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, ntpcontrol.SelectionSystemPeer, peers[0].Selection())
+	assert.Equal(t, int64(30), peers[0].When(synced.Add(30*time.Second)))
+}
+
+func Test_TimesyncdPeers_NoServer(t *testing.T) {
+	_, backend, _ := prepareTimesyncd(t, "SystemNTPServers=\nServerName=\nServerAddress=\n")
+
+	peers, err := backend.Peers(context.Background())
+
+	assert.NoError(t, err)
+	assert.Empty(t, peers)
+}
+
+func Test_TimesyncdSystemVariables(t *testing.T) {
+	_, backend, _ := prepareTimesyncd(t, tShowTimesync)
+
+	variables, err := backend.SystemVariables(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "16", variables["stratum"])
+	assert.Equal(t, "3", variables["leap"])
+
+	assert.NoError(t, os.WriteFile(backend.SyncMarkerPath, nil, 0644))
+	variables, err = backend.SystemVariables(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "3", variables["stratum"])
+	assert.Equal(t, "192.168.1.1", variables["refid"])
+	assert.Equal(t, "0", variables["leap"])
+	assert.InDelta(t, -18.838, variables.Float("frequency"), 1e-3)
+	assert.Equal(t, int64(1), variables.Int("peer"))
+}
+
+func Test_TimesyncdWriteConfiguration_CreatesDropIn(t *testing.T) {
+	tN, _, services := prepareTimesyncd(t, tShowTimesync)
+
+	err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}, FallbackNtpServer: []string{"0.debian.pool.ntp.org"}})
+
+	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, timesyncdDropInHeader+"\n[Time]\nNTP=10.0.0.1\nFallbackNTP=0.debian.pool.ntp.org\n", string(conf))
+	assert.Equal(t, []string{"stop systemd-timesyncd", "start systemd-timesyncd", "status systemd-timesyncd"}, services.Calls())
+
+	ntpServers, err := tN.GetCurrentNtpServers()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, ntpServers.GetNtpServer())
+	assert.Equal(t, []string{"0.debian.pool.ntp.org"}, ntpServers.GetFallbackNtpServer())
+}
+
+func Test_ValidateBackend(t *testing.T) {
+	tN, _, _ := prepareTimesyncd(t, "")
+	fallback := &v1.Ntp{NtpServer: []string{"10.0.0.1"}, FallbackNtpServer: []string{"0.debian.pool.ntp.org"}}
+	nts := &v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "nts.example", Nts: true}}}
+
+	assert.NoError(t, tN.ValidateBackend(fallback))
+	assert.ErrorIs(t, tN.ValidateBackend(nts), ErrNotSupported)
+
+	tN.Backend = NewNtpSecBackend(tExecutor{}, servicemanagertest.New())
+	assert.ErrorIs(t, tN.ValidateBackend(fallback), ErrNotSupported)
+	assert.NoError(t, tN.ValidateBackend(nts))
+
+	tN.Backend = NewChronyBackend(tExecutor{}, servicemanagertest.New())
+	assert.NoError(t, tN.ValidateBackend(nts))
+}
@@ -0,0 +1,277 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"ntpservice/internal/ntpcontrol"
+)
+
+// Steps of applying a configuration.
+const (
+	StepSnapshot     = "snapshot"
+	StepWrite        = "write"
+	StepStop         = "stop"
+	StepUpdateTime   = "update-time"
+	StepStart        = "start"
+	StepRestart      = "restart"
+	StepVerifyActive = "verify-active"
+	StepVerifySync   = "verify-sync"
+)
+
+// syncPollInterval is the interval of the peer reads while waiting for the synchronization.
+var syncPollInterval = time.Second
+
+// errNotActive is returned when the time daemon is not active after it was started.
+var errNotActive = errors.New("time daemon is not active")
+
+// errNotSynced is returned when ntpd selected no system peer within the sync timeout.
+var errNotSynced = errors.New("no system peer selected")
+
+// MaxSyncTimeout is the longest wait for the synchronization after a restart, the wait holds the ntp.conf lock.
+const MaxSyncTimeout = 5 * time.Minute
+
+// ErrInvalidSyncTimeout is returned for a sync timeout above MaxSyncTimeout.
+var ErrInvalidSyncTimeout = errors.New("invalid sync timeout")
+
+// ValidateSyncTimeout checks the sync timeout of a request in milliseconds against MaxSyncTimeout.
+func ValidateSyncTimeout(syncTimeoutMs uint32) error {
+	if time.Duration(syncTimeoutMs)*time.Millisecond > MaxSyncTimeout {
+		return fmt.Errorf("%w: %d ms is above the maximum of %v", ErrInvalidSyncTimeout, syncTimeoutMs, MaxSyncTimeout)
+	}
+	return nil
+}
+
+// ApplyError is returned by WriteConfiguration and the other changes of ntp.conf when a step after the validation
+// failed.
+type ApplyError struct {
+	Step        string
+	Err         error
+	RolledBack  bool  // the previous configuration was restored and the time daemon restarted
+	RollbackErr error // why the rollback failed, nil if it succeeded or was not needed
+}
+
+func (e *ApplyError) Error() string {
+	message := fmt.Sprintf("step %s failed: %v", e.Step, e.Err)
+	switch {
+	case e.RolledBack:
+		message += ", previous configuration restored"
+	case e.RollbackErr != nil:
+		message += fmt.Sprintf(", rollback failed: %v", e.RollbackErr)
+	}
+	return message
+}
+
+func (e *ApplyError) Unwrap() error {
+	return e.Err
+}
+
+// snapshot is the configuration before a change: ntp.conf and the files referenced by it which the change writes.
+type snapshot struct {
+	conf  []byte            // nil if the optional drop-in of the backend did not exist
+	files map[string][]byte // content of the referenced files by path, nil if a file did not exist
+}
+
+// takeSnapshot reads ntp.conf and the files, the caller holds ntpConfMutex.
+func (n *NtpConfigurator) takeSnapshot(files ...string) (*snapshot, error) {
+	conf, err := n.readConf()
+	if err != nil {
+		return nil, err
+	}
+	taken := &snapshot{conf: conf, files: make(map[string][]byte)}
+	for _, path := range files {
+		content, err := n.Files.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		taken.files[path] = content
+	}
+	return taken, nil
+}
+
+// applyConfiguration changes ntp.conf with write and restarts the time daemon. The previous ntp.conf is restored and
+// the daemon restarted when it does not become active or, with a sync timeout, selects no system peer in time.
+// The written ntp.conf is returned, nil if nothing was written.
+// ntpConfMutex is held from the snapshot to the end of the apply or rollback, so no other change of ntp.conf is lost
+// by a rollback; write must not lock it.
+// The deadline and the cancellation of ctx apply to the steps up to the stop of the daemon, a request which ends
+// before leaves the daemon running with the previous ntp.conf.
+func (n *NtpConfigurator) applyConfiguration(ctx context.Context, write func() error, syncTimeout time.Duration) ([]byte, error) {
+	ntpConfMutex.Lock()
+	defer ntpConfMutex.Unlock()
+	previous, attempted, err := n.writeChange(ctx, write)
+	if err != nil {
+		return nil, err
+	}
+	return attempted, n.restartDaemon(ctx, previous, syncTimeout)
+}
+
+// applyChange changes a part of ntp.conf and the referenced files with write and restarts the time daemon, e.g. for
+// the serving rules or the symmetric keys. ntp.conf and the files are restored and the daemon restarted again when
+// the restart fails or the daemon is not active afterwards. The written ntp.conf is recorded in the history, the
+// referenced files are not part of it. The locking and the cancellation are the ones of applyConfiguration, the
+// restart is not canceled.
+func (n *NtpConfigurator) applyChange(ctx context.Context, write func() error, files ...string) error {
+	ntpConfMutex.Lock()
+	defer ntpConfMutex.Unlock()
+	previous, attempted, err := n.writeChange(ctx, write, files...)
+	if err != nil {
+		return err
+	}
+	err = n.restartChange(ctx, previous)
+	n.recordRevision(attempted, err, 0)
+	return err
+}
+
+// restartChange restarts the time daemon with the written change, the snapshot is restored when it fails.
+func (n *NtpConfigurator) restartChange(ctx context.Context, previous *snapshot) error {
+	ctx = context.WithoutCancel(ctx)
+	if err := n.Backend.Restart(ctx); err != nil {
+		return n.rollback(ctx, previous, &ApplyError{Step: StepRestart, Err: err})
+	}
+	if !n.Backend.Active(ctx) {
+		return n.rollback(ctx, previous, &ApplyError{Step: StepVerifyActive, Err: errNotActive})
+	}
+	return nil
+}
+
+// preconditionError is returned by a write which refuses the change before it wrote anything, e.g. because of the
+// current ntp.conf. writeChange returns the wrapped error as is, without a rollback.
+type preconditionError struct {
+	err error
+}
+
+func (e *preconditionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *preconditionError) Unwrap() error {
+	return e.err
+}
+
+// writeChange takes the snapshot and writes the change, it returns the snapshot and the written ntp.conf.
+// The snapshot of the first change is recorded as the original revision. A failed write is rolled back.
+func (n *NtpConfigurator) writeChange(ctx context.Context, write func() error, files ...string) (*snapshot, []byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, &ApplyError{Step: StepSnapshot, Err: err}
+	}
+	previous, err := n.takeSnapshot(files...)
+	if err != nil {
+		return nil, nil, &ApplyError{Step: StepSnapshot, Err: err}
+	}
+	n.recordOriginal(previous.conf)
+	if err := write(); err != nil {
+		var refused *preconditionError
+		if errors.As(err, &refused) {
+			return nil, nil, refused.err
+		}
+		return nil, nil, n.rollback(ctx, previous, &ApplyError{Step: StepWrite, Err: err})
+	}
+	attempted, err := n.Files.ReadFile(n.NtpConfPath)
+	if err != nil {
+		return nil, nil, n.rollback(ctx, previous, &ApplyError{Step: StepWrite, Err: err})
+	}
+	return previous, attempted, nil
+}
+
+// restartDaemon updates the system time and restarts the time daemon with the written configuration, the snapshot
+// is restored if a step fails.
+// Only the stop and the wait for the synchronization use ctx. Once the daemon is stopped the remaining steps and the
+// rollback run to the end even if the request is canceled or its deadline passes, an interrupted restart would leave
+// the daemon stopped; these commands are bounded by their default timeouts. A request canceled while waiting for the
+// synchronization is rolled back.
+func (n *NtpConfigurator) restartDaemon(ctx context.Context, previous *snapshot, syncTimeout time.Duration) error {
+	if err := n.Backend.Stop(ctx); err != nil {
+		return n.rollback(ctx, previous, &ApplyError{Step: StepStop, Err: err})
+	}
+	request := ctx
+	ctx = context.WithoutCancel(ctx)
+	if err := n.Backend.Step(ctx); err != nil {
+		return n.rollback(ctx, previous, &ApplyError{Step: StepUpdateTime, Err: err})
+	}
+	log.Println("System time updated by", n.Backend.Name())
+	if err := n.Backend.Start(ctx); err != nil {
+		return n.rollback(ctx, previous, &ApplyError{Step: StepStart, Err: err})
+	}
+	if !n.Backend.Active(ctx) {
+		return n.rollback(ctx, previous, &ApplyError{Step: StepVerifyActive, Err: errNotActive})
+	}
+	if syncTimeout > 0 {
+		if err := n.waitForSync(request, syncTimeout); err != nil {
+			return n.rollback(ctx, previous, &ApplyError{Step: StepVerifySync, Err: err})
+		}
+	}
+	return nil
+}
+
+// rollback restores the snapshot and restarts the time daemon, the outcome is recorded in applyErr.
+// The caller holds ntpConfMutex. The restart is not canceled with ctx, a canceled request must not leave the daemon
+// stopped.
+func (n *NtpConfigurator) rollback(ctx context.Context, previous *snapshot, applyErr *ApplyError) error {
+	log.Println("Applying the configuration failed, restoring the previous one:", applyErr.Err)
+	ctx = context.WithoutCancel(ctx)
+	if err := n.restore(previous); err != nil {
+		applyErr.RollbackErr = err
+		return applyErr
+	}
+	if err := n.Backend.Restart(ctx); err != nil {
+		applyErr.RollbackErr = err
+		return applyErr
+	}
+	applyErr.RolledBack = true
+	return applyErr
+}
+
+// restore writes the snapshot back, files which did not exist are removed.
+func (n *NtpConfigurator) restore(previous *snapshot) error {
+	for path, content := range previous.files {
+		if err := n.restoreFile(path, content); err != nil {
+			return err
+		}
+	}
+	return n.restoreFile(n.NtpConfPath, previous.conf)
+}
+
+// restoreFile writes the content back to the file, nil content removes it.
+func (n *NtpConfigurator) restoreFile(path string, content []byte) error {
+	if content == nil {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return n.Files.WriteFileAtomic(path, content, ntpConfPermissions)
+}
+
+// waitForSync waits until the time daemon selects a system peer. It fails when the timeout passes or when ctx is
+// done, a canceled request stops waiting early and is rolled back.
+func (n *NtpConfigurator) waitForSync(ctx context.Context, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	poll := time.NewTicker(syncPollInterval)
+	defer poll.Stop()
+	for {
+		for _, peer := range n.readPeers(ctx) {
+			if peer.Selection() == ntpcontrol.SelectionSystemPeer {
+				return nil
+			}
+		}
+		if time.Now().Add(syncPollInterval).After(deadline) {
+			return fmt.Errorf("%w within %v", errNotSynced, timeout)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", errNotSynced, ctx.Err())
+		case <-poll.C:
+		}
+	}
+}
@@ -0,0 +1,456 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpconfigurator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+	"ntpservice/internal/ntpcontrol"
+	"ntpservice/internal/ntpcontrol/ntpcontroltest"
+	"ntpservice/internal/servicemanager"
+	"ntpservice/internal/servicemanager/servicemanagertest"
+	"ntpservice/utils/executor"
+	"ntpservice/utils/files"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tPreviousConf = "driftfile /var/lib/ntpsec/ntp.drift\nserver 192.168.1.1 iburst\n"
+
+// tFailingExecutor fails the commands in failing and records all commands, onRun is called before a command ends.
+type tFailingExecutor struct {
+	mutex    sync.Mutex
+	failing  map[string]bool
+	commands []executor.Command
+	onRun    func(ctx context.Context, command executor.Command)
+}
+
+func (o *tFailingExecutor) Run(ctx context.Context, command executor.Command) ([]byte, error) {
+	o.mutex.Lock()
+	o.commands = append(o.commands, command)
+	failing, onRun := o.failing[command.String()], o.onRun
+	o.mutex.Unlock()
+	if onRun != nil {
+		onRun(ctx, command)
+	}
+	if failing {
+		return nil, errors.New("exit status 1")
+	}
+	return []byte{}, nil
+}
+
+func prepareTransaction(t *testing.T) (*NtpConfigurator, *tFailingExecutor, *servicemanagertest.ServiceManager) {
+	tN := prepareNtpConfiguratorWithKeys(t, tPreviousConf)
+	ut := &tFailingExecutor{failing: make(map[string]bool)}
+	services := servicemanagertest.New(NtpSecService)
+	tN.Backend = NewNtpSecBackend(ut, services)
+	return tN, ut, services
+}
+
+func Test_WriteConfiguration_Applied(t *testing.T) {
+	tN, ut, services := prepareTransaction(t)
+
+	err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+
+	assert.Nil(t, err, "Did not get expected result. Wanted: Nil, got: %q", err)
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, "driftfile /var/lib/ntpsec/ntp.drift\nserver 10.0.0.1\n", string(conf))
+	assert.Equal(t, []string{"stop ntpsec", "start ntpsec", "status ntpsec"}, services.Calls())
+	assert.Equal(t, []executor.Command{UpdateSystemTimeCmd}, ut.commands)
+}
+
+func Test_WriteConfiguration_RollsBackFailedStep(t *testing.T) {
+	tests := []struct {
+		action  string           // failing action of the ntpsec service
+		command executor.Command // failing command
+		step    string
+	}{
+		{action: servicemanager.ActionStop, step: StepStop},
+		{command: UpdateSystemTimeCmd, step: StepUpdateTime},
+		{action: servicemanager.ActionStart, step: StepStart},
+		{action: servicemanager.ActionStatus, step: StepVerifyActive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.step, func(t *testing.T) {
+			tN, ut, services := prepareTransaction(t)
+			if tt.action != "" {
+				services.Fail(tt.action, NtpSecService)
+			}
+			ut.failing[tt.command.String()] = true
+
+			err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+
+			var applyErr *ApplyError
+			assert.ErrorAs(t, err, &applyErr)
+			assert.Equal(t, tt.step, applyErr.Step)
+			assert.True(t, applyErr.RolledBack)
+			assert.Nil(t, applyErr.RollbackErr)
+			conf, _ := os.ReadFile(tN.NtpConfPath)
+			assert.Equal(t, tPreviousConf, string(conf), "Did not get expected result. The previous ntp.conf must be restored")
+			calls := services.Calls()
+			assert.Equal(t, "restart ntpsec", calls[len(calls)-1])
+		})
+	}
+}
+
+func Test_WriteConfiguration_RollbackFails(t *testing.T) {
+	tN, _, services := prepareTransaction(t)
+	services.Fail(servicemanager.ActionStart, NtpSecService)
+	services.Fail(servicemanager.ActionRestart, NtpSecService)
+
+	err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	assert.Equal(t, StepStart, applyErr.Step)
+	assert.False(t, applyErr.RolledBack)
+	assert.NotNil(t, applyErr.RollbackErr)
+	assert.Contains(t, err.Error(), "rollback failed")
+}
+
+func Test_WriteConfiguration_MissingNtpConf(t *testing.T) {
+	tN, ut, services := prepareTransaction(t)
+	tN.NtpConfPath = "/non/existing/ntp.conf"
+
+	err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	assert.Equal(t, StepSnapshot, applyErr.Step)
+	assert.Empty(t, ut.commands, "Did not get expected result. ntpsec must not be touched")
+	assert.Empty(t, services.Calls())
+}
+
+func Test_WriteConfiguration_VerifySync(t *testing.T) {
+	previous := syncPollInterval
+	syncPollInterval = 10 * time.Millisecond
+	defer func() { syncPollInterval = previous }()
+
+	tN, _, _ := prepareTransaction(t)
+	tN.Backend.(*NtpSecBackend).Control = startFakeNtpd(t)
+	assert.NoError(t, tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}, SyncTimeoutMs: 1000}))
+
+	// Without the system peer ntpd is not synchronized.
+	server, err := ntpcontroltest.NewServer(ntpcontrol.Variables{}, tAssociations(time.Now())[2:], ntpcontroltest.Options{})
+	assert.NoError(t, err)
+	defer server.Close()
+	tN, _, _ = prepareTransaction(t)
+	tN.Backend.(*NtpSecBackend).Control = ntpcontrol.NewClient(server.Addr)
+
+	err = tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}, SyncTimeoutMs: 50})
+
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	assert.Equal(t, StepVerifySync, applyErr.Step)
+	assert.ErrorIs(t, err, errNotSynced)
+	assert.True(t, applyErr.RolledBack)
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, tPreviousConf, string(conf))
+}
+
+func Test_WriteConfiguration_SyncTimeoutAboveMaximum(t *testing.T) {
+	tN, ut, services := prepareTransaction(t)
+
+	err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}, SyncTimeoutMs: 300001})
+
+	assert.ErrorIs(t, err, ErrInvalidSyncTimeout)
+	assert.Empty(t, ut.commands, "Did not get expected result. ntpsec must not be touched")
+	assert.Empty(t, services.Calls())
+	assert.ErrorIs(t, tN.RestoreConfiguration(context.Background(), 1, 300001), ErrInvalidSyncTimeout)
+	assert.NoError(t, ValidateSyncTimeout(300000))
+}
+
+func Test_WriteConfiguration_VerifySyncCanceled(t *testing.T) {
+	previous := syncPollInterval
+	syncPollInterval = 10 * time.Millisecond
+	defer func() { syncPollInterval = previous }()
+
+	server, err := ntpcontroltest.NewServer(ntpcontrol.Variables{}, tAssociations(time.Now())[2:], ntpcontroltest.Options{})
+	assert.NoError(t, err)
+	defer server.Close()
+	tN, _, _ := prepareTransaction(t)
+	tN.Backend.(*NtpSecBackend).Control = ntpcontrol.NewClient(server.Addr)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = tN.WriteConfiguration(ctx, &v1.Ntp{NtpServer: []string{"10.0.0.1"}, SyncTimeoutMs: 300000})
+
+	assert.Less(t, time.Since(start), 10*time.Second, "Did not get expected result. The wait must end with the request")
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	assert.Equal(t, StepVerifySync, applyErr.Step)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, applyErr.RolledBack)
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, tPreviousConf, string(conf))
+}
+
+func Test_WriteConfiguration_Daemon(t *testing.T) {
+	tN := prepareNtpConfiguratorWithKeys(t, tPreviousConf)
+	ut := &tFailingExecutor{failing: make(map[string]bool)}
+	services := servicemanagertest.New("ntpsec-plant")
+	backend := NewNtpSecBackend(ut, services)
+	backend.Daemon = Daemon{Service: "ntpsec-plant", StepTimeout: 45 * time.Second}
+	tN.Backend = backend
+
+	err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"stop ntpsec-plant", "start ntpsec-plant", "status ntpsec-plant"}, services.Calls())
+	assert.Equal(t, []executor.Command{executor.NewCommand(45*time.Second, "ntpd", "-gq")}, ut.commands)
+}
+
+func Test_WriteConfiguration_RollbackKeepsConcurrentChange(t *testing.T) {
+	tN, ut, _ := prepareTransaction(t)
+	ut.failing[UpdateSystemTimeCmd.String()] = true
+	serving := &v1.ServingConfig{Enabled: true, Rules: []*v1.RestrictRule{{Cidr: "10.0.0.0/8"}}}
+	servingDone := make(chan error, 1)
+	ut.onRun = func(ctx context.Context, command executor.Command) {
+		go func() { servingDone <- tN.SetServing(context.Background(), serving) }()
+		select {
+		case err := <-servingDone:
+			t.Errorf("Did not get expected result. SetServing must wait for the apply, got: %v", err)
+			servingDone <- err
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	assert.True(t, applyErr.RolledBack)
+	assert.NoError(t, <-servingDone)
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Contains(t, string(conf), "restrict 10.0.0.0 mask 255.0.0.0", "Did not get expected result. The rollback must not drop the serving rules")
+	assert.Contains(t, string(conf), "server 192.168.1.1 iburst")
+	assert.NotContains(t, string(conf), "server 10.0.0.1")
+}
+
+func Test_DeleteKey_ChecksUsageAfterConcurrentApply(t *testing.T) {
+	tN, ut, _ := prepareTransaction(t)
+	assert.NoError(t, tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 5, Secret: "secret"}))
+	deleteDone := make(chan error, 1)
+	ut.onRun = func(ctx context.Context, command executor.Command) {
+		go func() { deleteDone <- tN.DeleteKey(context.Background(), 5) }()
+		select {
+		case err := <-deleteDone:
+			t.Errorf("Did not get expected result. DeleteKey must wait for the apply, got: %v", err)
+			deleteDone <- err
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "10.0.0.1", KeyId: 5}}})
+
+	assert.NoError(t, err)
+	assert.ErrorIs(t, <-deleteDone, ErrKeyInUse)
+	keys, _ := tN.ListKeys()
+	assert.Len(t, keys, 1, "Did not get expected result. A key used by the applied server must be kept")
+}
+
+func Test_WriteConfiguration_RollbackRemovesCreatedDropIn(t *testing.T) {
+	tN, _, services := prepareTimesyncd(t, tShowTimesync)
+	services.Fail(servicemanager.ActionStart, TimesyncdService)
+
+	err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	assert.True(t, applyErr.RolledBack)
+	_, statErr := os.Stat(tN.NtpConfPath)
+	assert.True(t, os.IsNotExist(statErr), "Did not get expected result. The drop-in which did not exist must be removed, got: %v", statErr)
+}
+
+func Test_WriteConfiguration_CanceledBeforeApply(t *testing.T) {
+	tN, ut, services := prepareTransaction(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := tN.WriteConfiguration(ctx, &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	assert.ErrorIs(t, err, context.Canceled)
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, tPreviousConf, string(conf))
+	assert.Empty(t, ut.commands, "Did not get expected result. ntpsec must not be touched")
+	assert.Empty(t, services.Calls())
+}
+
+func Test_WriteConfiguration_CanceledAfterStop(t *testing.T) {
+	tN, ut, services := prepareTransaction(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	var stepHasDeadline bool
+	ut.onRun = func(stepCtx context.Context, command executor.Command) {
+		_, stepHasDeadline = stepCtx.Deadline()
+		cancel()
+	}
+
+	err := tN.WriteConfiguration(ctx, &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+
+	assert.NoError(t, err, "Did not get expected result. A stopped daemon must be started even if the request is canceled")
+	assert.False(t, stepHasDeadline, "Did not get expected result. The steps after the stop must not use the deadline of the request")
+	assert.Equal(t, []string{"stop ntpsec", "start ntpsec", "status ntpsec"}, services.Calls())
+}
+
+func Test_SetServing_RollsBackFailedRestart(t *testing.T) {
+	tN, _, services := prepareTransaction(t)
+	services.Fail(servicemanager.ActionRestart, NtpSecService)
+
+	err := tN.SetServing(context.Background(), &v1.ServingConfig{Enabled: true})
+
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	assert.Equal(t, StepRestart, applyErr.Step)
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, tPreviousConf, string(conf), "Did not get expected result. The previous ntp.conf must be restored")
+}
+
+func Test_CreateKey_RollsBackInactiveDaemon(t *testing.T) {
+	tN, _, services := prepareTransaction(t)
+	services.Fail(servicemanager.ActionStatus, NtpSecService)
+
+	err := tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 5, Type: v1.KeyType_SHA1, Secret: "secret"})
+
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	assert.Equal(t, StepVerifyActive, applyErr.Step)
+	assert.True(t, applyErr.RolledBack)
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, tPreviousConf, string(conf), "Did not get expected result. The previous ntp.conf must be restored")
+	_, statErr := os.Stat(tN.KeysPath)
+	assert.True(t, os.IsNotExist(statErr), "Did not get expected result. The ntp.keys which did not exist must be removed, got: %v", statErr)
+}
+
+// tFaultyFileSystem fails the next writes or renames of a file, by base name, the other operations are the ones of os.
+type tFaultyFileSystem struct {
+	*files.OsFileSystemOperations
+	writeFaults map[string]int
+	moveFaults  map[string]int
+}
+
+// tFailingFile fails every write to the temporary file.
+type tFailingFile struct {
+	files.FileIO
+}
+
+func (f tFailingFile) Write(p []byte) (int, error) {
+	return 0, errors.New("no space left on device")
+}
+
+func (fileSystem *tFaultyFileSystem) CreateTemp(dir string, pattern string) (files.FileIO, error) {
+	temp, err := fileSystem.OsFileSystemOperations.CreateTemp(dir, pattern)
+	base := strings.TrimSuffix(strings.TrimPrefix(pattern, "."), ".tmp-*")
+	if err != nil || fileSystem.writeFaults[base] == 0 {
+		return temp, err
+	}
+	fileSystem.writeFaults[base]--
+	return tFailingFile{temp}, nil
+}
+
+func (fileSystem *tFaultyFileSystem) Move(oldPath string, newPath string) error {
+	if base := filepath.Base(newPath); fileSystem.moveFaults[base] > 0 {
+		fileSystem.moveFaults[base]--
+		return errors.New("read-only file system")
+	}
+	return fileSystem.OsFileSystemOperations.Move(oldPath, newPath)
+}
+
+func prepareFaultyTransaction(t *testing.T) (*NtpConfigurator, *tFailingExecutor, *servicemanagertest.ServiceManager, *tFaultyFileSystem) {
+	tN, ut, services := prepareTransaction(t)
+	fileSystem := &tFaultyFileSystem{
+		OsFileSystemOperations: &files.OsFileSystemOperations{},
+		writeFaults:            make(map[string]int),
+		moveFaults:             make(map[string]int),
+	}
+	tN.Files = &files.OsFileUtils{FileSystemOperations: fileSystem}
+	return tN, ut, services, fileSystem
+}
+
+// assertNoTemporaryFiles checks that a failed write removed its temporary file.
+func assertNoTemporaryFiles(t *testing.T, dir string) {
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		assert.NotContains(t, entry.Name(), ".tmp-", "Did not get expected result. No temporary file must be left")
+	}
+}
+
+func Test_WriteConfiguration_FileFaultRollsBack(t *testing.T) {
+	tests := []struct {
+		name   string
+		inject func(fileSystem *tFaultyFileSystem)
+	}{
+		{"write", func(fileSystem *tFaultyFileSystem) { fileSystem.writeFaults["ntp.conf"] = 1 }},
+		{"rename", func(fileSystem *tFaultyFileSystem) { fileSystem.moveFaults["ntp.conf"] = 1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tN, ut, services, fileSystem := prepareFaultyTransaction(t)
+			tt.inject(fileSystem)
+
+			err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+
+			var applyErr *ApplyError
+			assert.ErrorAs(t, err, &applyErr)
+			assert.Equal(t, StepWrite, applyErr.Step)
+			assert.True(t, applyErr.RolledBack)
+			assert.Nil(t, applyErr.RollbackErr)
+			conf, _ := os.ReadFile(tN.NtpConfPath)
+			assert.Equal(t, tPreviousConf, string(conf), "Did not get expected result. The previous ntp.conf must be kept")
+			assertNoTemporaryFiles(t, filepath.Dir(tN.NtpConfPath))
+			assert.Empty(t, ut.commands, "Did not get expected result. The system time must not be set")
+			assert.Equal(t, []string{"restart ntpsec"}, services.Calls())
+		})
+	}
+}
+
+func Test_CreateKey_RenameFaultRestoresKeys(t *testing.T) {
+	tN, _, _, fileSystem := prepareFaultyTransaction(t)
+	previousKeys := "1 MD5 plant-secret\n"
+	assert.NoError(t, os.WriteFile(tN.KeysPath, []byte(previousKeys), 0600))
+	fileSystem.moveFaults["ntp.conf"] = 1
+
+	err := tN.CreateKey(context.Background(), &v1.SymmetricKey{KeyId: 5, Type: v1.KeyType_SHA1, Secret: "secret"})
+
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	assert.Equal(t, StepWrite, applyErr.Step)
+	assert.True(t, applyErr.RolledBack)
+	keys, _ := os.ReadFile(tN.KeysPath)
+	assert.Equal(t, previousKeys, string(keys), "Did not get expected result. The previous ntp.keys must be restored")
+	conf, _ := os.ReadFile(tN.NtpConfPath)
+	assert.Equal(t, tPreviousConf, string(conf))
+}
+
+func Test_WriteConfiguration_RollbackWriteFails(t *testing.T) {
+	tN, ut, services, fileSystem := prepareFaultyTransaction(t)
+	services.Fail(servicemanager.ActionStart, NtpSecService)
+	// the restore of ntp.conf fails, the fault is injected after the change was written
+	ut.onRun = func(ctx context.Context, command executor.Command) { fileSystem.moveFaults["ntp.conf"] = 1 }
+
+	err := tN.WriteConfiguration(context.Background(), &v1.Ntp{NtpServer: []string{"10.0.0.1"}})
+
+	var applyErr *ApplyError
+	assert.ErrorAs(t, err, &applyErr)
+	assert.Equal(t, StepStart, applyErr.Step)
+	assert.False(t, applyErr.RolledBack)
+	assert.ErrorContains(t, applyErr.RollbackErr, "read-only file system")
+	assertNoTemporaryFiles(t, filepath.Dir(tN.NtpConfPath))
+	assert.NotContains(t, services.Calls(), "restart ntpsec", "Did not get expected result. The daemon must not be restarted without the restored ntp.conf")
+}
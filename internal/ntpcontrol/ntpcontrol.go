@@ -0,0 +1,265 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+// Package ntpcontrol implements the client side of the NTP mode 6 control protocol used by ntpq to read the
+// status and variables of ntpd (RFC 1305 appendix B, RFC 9327).
+package ntpcontrol
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultAddress is the control address of the local ntpd.
+const DefaultAddress = "127.0.0.1:123"
+
+// DefaultTimeout is the time to wait for each response fragment.
+const DefaultTimeout = 2 * time.Second
+
+// Opcodes of the control messages.
+const (
+	OpReadStatus    uint8 = 1
+	OpReadVariables uint8 = 2
+	OpReadClock     uint8 = 4
+)
+
+// Mode is the NTP mode of control messages.
+const Mode uint8 = 6
+
+// Version is the NTP version of the requests, the one used by ntpq.
+const Version uint8 = 2
+
+// HeaderSize is the size of the control message header.
+const HeaderSize = 12
+
+// Bits of the second header byte.
+const (
+	flagResponse uint8 = 0x80
+	flagError    uint8 = 0x40
+	flagMore     uint8 = 0x20
+	opcodeMask   uint8 = 0x1f
+)
+
+// maxFragments limits the number of fragments of one response, as ntpq does.
+const maxFragments = 128
+const maxPacketSize = 65535
+
+var (
+	// ErrServer is returned when ntpd answers with the error bit set.
+	ErrServer = errors.New("ntp control error response")
+	// ErrInvalidResponse is returned when the response can not be reassembled.
+	ErrInvalidResponse = errors.New("invalid ntp control response")
+)
+
+// serverErrors are the names of the error codes sent by ntpd.
+var serverErrors = map[uint16]string{
+	0: "unspecified",
+	1: "authentication failure",
+	2: "invalid message length or format",
+	3: "invalid opcode",
+	4: "unknown association id",
+	5: "unknown variable name",
+	6: "invalid variable value",
+	7: "administratively prohibited",
+}
+
+// Header is the header of a control message.
+type Header struct {
+	Response      bool
+	Error         bool
+	More          bool
+	Opcode        uint8
+	Sequence      uint16
+	Status        uint16
+	AssociationID uint16
+	Offset        uint16
+	Count         uint16
+}
+
+// Marshal encodes the header followed by data, padded to a multiple of 4 bytes.
+func (h Header) Marshal(data []byte) []byte {
+	length := HeaderSize + len(data)
+	if length%4 != 0 {
+		length += 4 - length%4
+	}
+	buffer := make([]byte, length)
+	buffer[0] = Version<<3 | Mode
+	buffer[1] = h.Opcode & opcodeMask
+	if h.Response {
+		buffer[1] |= flagResponse
+	}
+	if h.Error {
+		buffer[1] |= flagError
+	}
+	if h.More {
+		buffer[1] |= flagMore
+	}
+	binary.BigEndian.PutUint16(buffer[2:], h.Sequence)
+	binary.BigEndian.PutUint16(buffer[4:], h.Status)
+	binary.BigEndian.PutUint16(buffer[6:], h.AssociationID)
+	binary.BigEndian.PutUint16(buffer[8:], h.Offset)
+	binary.BigEndian.PutUint16(buffer[10:], uint16(len(data)))
+	copy(buffer[HeaderSize:], data)
+	return buffer
+}
+
+// Unmarshal decodes a control message into its header and data.
+func Unmarshal(buffer []byte) (Header, []byte, error) {
+	if len(buffer) < HeaderSize || buffer[0]&0x7 != Mode {
+		return Header{}, nil, fmt.Errorf("%w: not a control message", ErrInvalidResponse)
+	}
+	h := Header{
+		Response:      buffer[1]&flagResponse != 0,
+		Error:         buffer[1]&flagError != 0,
+		More:          buffer[1]&flagMore != 0,
+		Opcode:        buffer[1] & opcodeMask,
+		Sequence:      binary.BigEndian.Uint16(buffer[2:]),
+		Status:        binary.BigEndian.Uint16(buffer[4:]),
+		AssociationID: binary.BigEndian.Uint16(buffer[6:]),
+		Offset:        binary.BigEndian.Uint16(buffer[8:]),
+		Count:         binary.BigEndian.Uint16(buffer[10:]),
+	}
+	if HeaderSize+int(h.Count) > len(buffer) {
+		return Header{}, nil, fmt.Errorf("%w: count %d exceeds the packet", ErrInvalidResponse, h.Count)
+	}
+	return h, buffer[HeaderSize : HeaderSize+int(h.Count)], nil
+}
+
+// Association is an entry of the read status response of association 0.
+type Association struct {
+	ID     uint16
+	Status uint16
+}
+
+// Client sends control requests to ntpd.
+type Client struct {
+	Address  string
+	Timeout  time.Duration
+	sequence atomic.Uint32
+}
+
+// NewClient returns a client for the ntpd listening on address.
+func NewClient(address string) *Client {
+	return &Client{Address: address, Timeout: DefaultTimeout}
+}
+
+// ReadStatus returns the system status word and the associations of ntpd.
+func (c *Client) ReadStatus(ctx context.Context) (uint16, []Association, error) {
+	header, data, err := c.request(ctx, OpReadStatus, 0, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	var associations []Association
+	for i := 0; i+3 < len(data); i += 4 {
+		associations = append(associations, Association{
+			ID:     binary.BigEndian.Uint16(data[i:]),
+			Status: binary.BigEndian.Uint16(data[i+2:]),
+		})
+	}
+	return header.Status, associations, nil
+}
+
+// ReadVariables returns the variables of the association, association 0 are the system variables.
+// Without names all variables ntpd reports by default are returned.
+func (c *Client) ReadVariables(ctx context.Context, associationID uint16, names ...string) (Variables, error) {
+	_, data, err := c.request(ctx, OpReadVariables, associationID, []byte(strings.Join(names, ",")))
+	if err != nil {
+		return nil, err
+	}
+	return ParseVariables(string(data)), nil
+}
+
+// ReadClockVariables returns the variables of the driver of a reference clock association.
+// Without names all variables the driver reports by default are returned.
+func (c *Client) ReadClockVariables(ctx context.Context, associationID uint16, names ...string) (Variables, error) {
+	_, data, err := c.request(ctx, OpReadClock, associationID, []byte(strings.Join(names, ",")))
+	if err != nil {
+		return nil, err
+	}
+	return ParseVariables(string(data)), nil
+}
+
+// request sends the request and reassembles the fragments of the response.
+func (c *Client) request(ctx context.Context, opcode uint8, associationID uint16, data []byte) (Header, []byte, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", c.Address)
+	if err != nil {
+		return Header{}, nil, err
+	}
+	defer conn.Close()
+
+	sequence := uint16(c.sequence.Add(1))
+	request := Header{Opcode: opcode, Sequence: sequence, AssociationID: associationID}
+	if _, err := conn.Write(request.Marshal(data)); err != nil {
+		return Header{}, nil, err
+	}
+
+	fragments := make(map[uint16][]byte)
+	var first Header
+	end := -1
+	buffer := make([]byte, maxPacketSize)
+	for len(fragments) < maxFragments {
+		deadline := time.Now().Add(c.Timeout)
+		if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return Header{}, nil, err
+		}
+		n, err := conn.Read(buffer)
+		if err != nil {
+			return Header{}, nil, err
+		}
+		header, payload, err := Unmarshal(buffer[:n])
+		if err != nil || !header.Response || header.Opcode != opcode || header.Sequence != sequence ||
+			header.AssociationID != associationID {
+			// Late answer of an earlier request or garbage.
+			continue
+		}
+		if header.Error {
+			code := header.Status >> 8
+			return Header{}, nil, fmt.Errorf("%w: %s (%d)", ErrServer, serverErrors[code], code)
+		}
+		if len(fragments) == 0 {
+			first = header
+		}
+		fragments[header.Offset] = append([]byte(nil), payload...)
+		if !header.More {
+			end = int(header.Offset) + len(payload)
+		}
+		if end >= 0 {
+			if data, complete := reassemble(fragments, end); complete {
+				first.Offset, first.Count, first.More = 0, uint16(len(data)), false
+				return first, data, nil
+			}
+		}
+	}
+	return Header{}, nil, fmt.Errorf("%w: more than %d fragments", ErrInvalidResponse, maxFragments)
+}
+
+// reassemble joins the fragments if they cover the response up to end without gaps.
+func reassemble(fragments map[uint16][]byte, end int) ([]byte, bool) {
+	offsets := make([]int, 0, len(fragments))
+	for offset := range fragments {
+		offsets = append(offsets, int(offset))
+	}
+	sort.Ints(offsets)
+	data := make([]byte, 0, end)
+	for _, offset := range offsets {
+		if offset != len(data) {
+			return nil, false
+		}
+		data = append(data, fragments[uint16(offset)]...)
+	}
+	return data, len(data) == end
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpcontrol_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"ntpservice/internal/ntpcontrol"
+	"ntpservice/internal/ntpcontrol/ntpcontroltest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var tPeerVariables = ntpcontrol.Variables{
+	"srcadr":     "193.30.121.7",
+	"srcport":    "123",
+	"dstadr":     "10.0.0.5",
+	"refid":      "131.188.3.223",
+	"stratum":    "2",
+	"hmode":      "3",
+	"hpoll":      "8",
+	"ppoll":      "10",
+	"reach":      "0xff",
+	"delay":      "64.087",
+	"offset":     "40.219",
+	"jitter":     "13.950",
+	"rootdelay":  "1.234",
+	"rootdisp":   "20.5",
+	"dispersion": "3.25",
+	"leap":       "00",
+	"precision":  "-23",
+	"rec":        "0xe5b1c3a4.80000000",
+	"srchost":    "ntp.example.com",
+}
+
+func startServer(t *testing.T, associations []ntpcontroltest.Association, options ntpcontroltest.Options) *ntpcontrol.Client {
+	server, err := ntpcontroltest.NewServer(ntpcontrol.Variables{"version": "ntpd ntpsec-1.2.2", "stratum": "3"}, associations, options)
+	assert.NoError(t, err)
+	t.Cleanup(func() { server.Close() })
+	client := ntpcontrol.NewClient(server.Addr)
+	client.Timeout = time.Second
+	return client
+}
+
+func Test_ReadStatus(t *testing.T) {
+	client := startServer(t, []ntpcontroltest.Association{{ID: 51693, Status: 0x8811}, {ID: 51694, Status: 0x961a}},
+		ntpcontroltest.Options{SystemStatus: 0x0615})
+
+	system, associations, err := client.ReadStatus(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(0x0615), system)
+	assert.Equal(t, []ntpcontrol.Association{{ID: 51693, Status: 0x8811}, {ID: 51694, Status: 0x961a}}, associations)
+}
+
+func Test_ReadVariables(t *testing.T) {
+	client := startServer(t, []ntpcontroltest.Association{{ID: 7, Status: 0x961a, Variables: tPeerVariables}}, ntpcontroltest.Options{})
+
+	variables, err := client.ReadVariables(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, tPeerVariables, variables)
+
+	variables, err = client.ReadVariables(context.Background(), 7, "srcadr", "stratum")
+	assert.NoError(t, err)
+	assert.Equal(t, ntpcontrol.Variables{"srcadr": "193.30.121.7", "stratum": "2"}, variables)
+
+	system, err := client.ReadVariables(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "ntpd ntpsec-1.2.2", system.String("version"))
+}
+
+func Test_ReadVariables_Fragmented(t *testing.T) {
+	large := ntpcontrol.Variables{}
+	for k, v := range tPeerVariables {
+		large[k] = v
+	}
+	large["filtdelay"] = strings.Repeat("64.087 ", 100)
+	for _, reverse := range []bool{false, true} {
+		client := startServer(t, []ntpcontroltest.Association{{ID: 7, Variables: large}},
+			ntpcontroltest.Options{FragmentSize: 32, ReverseFragments: reverse})
+
+		variables, err := client.ReadVariables(context.Background(), 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, large, variables)
+	}
+}
+
+func Test_ReadVariables_Errors(t *testing.T) {
+	client := startServer(t, []ntpcontroltest.Association{{ID: 7, Variables: tPeerVariables}}, ntpcontroltest.Options{})
+
+	_, err := client.ReadVariables(context.Background(), 8)
+	assert.ErrorIs(t, err, ntpcontrol.ErrServer)
+	assert.Contains(t, err.Error(), "unknown association id")
+
+	_, err = client.ReadVariables(context.Background(), 7, "nosuchvariable")
+	assert.ErrorIs(t, err, ntpcontrol.ErrServer)
+}
+
+func Test_Peers(t *testing.T) {
+	pool := ntpcontrol.Variables{"srcadr": "0.0.0.0", "srchost": "0.debian.pool.ntp.org", "refid": "POOL", "stratum": "16", "hmode": "3", "hpoll": "6"}
+	client := startServer(t, []ntpcontroltest.Association{{ID: 1, Status: 0x8811, Variables: pool}, {ID: 2, Status: 0x161a, Variables: tPeerVariables}},
+		ntpcontroltest.Options{})
+
+	peers, err := client.Peers(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, peers, 2)
+	assert.Equal(t, "0.debian.pool.ntp.org", peers[0].Remote())
+	assert.Equal(t, ".POOL.", peers[0].DisplayReferenceID())
+	assert.Equal(t, "p", peers[0].Type())
+	assert.True(t, peers[0].Configured())
+	assert.Equal(t, byte(' '), peers[0].Tally())
+	assert.Equal(t, int64(-1), peers[0].When(time.Now()))
+
+	peer := peers[1]
+	assert.Equal(t, "193.30.121.7", peer.Remote())
+	assert.False(t, peer.Configured())
+	assert.True(t, peer.Reachable())
+	assert.Equal(t, byte('*'), peer.Tally())
+	assert.Equal(t, "sys.peer", peer.SelectionName())
+	assert.Equal(t, "u", peer.Type())
+	assert.Equal(t, "131.188.3.223", peer.DisplayReferenceID())
+	assert.Equal(t, int64(256), peer.Poll())
+	assert.Equal(t, int64(0377), peer.Reach())
+	assert.Equal(t, 64.087, peer.Variables.Float("delay"))
+	assert.Equal(t, int64(0), peer.Leap())
+	peer.Variables = ntpcontrol.Variables{"leap": "11"}
+	assert.Equal(t, int64(3), peer.Leap())
+	peer.Variables = ntpcontrol.Variables{"leap": "2"}
+	assert.Equal(t, int64(2), peer.Leap())
+	received := peers[1].Variables.Time("rec")
+	assert.Equal(t, int64(35), peers[1].When(received.Add(35*time.Second)))
+}
+
+func Test_Peers_UnreadableAssociation(t *testing.T) {
+	client := startServer(t, []ntpcontroltest.Association{{ID: 1, Status: 0x9014, Unreadable: true}, {ID: 2, Status: 0x161a, Variables: tPeerVariables}},
+		ntpcontroltest.Options{})
+
+	peers, err := client.Peers(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, peers, 2, "Did not get expected result. The other associations must be reported")
+	assert.Equal(t, uint16(1), peers[0].AssociationID)
+	assert.True(t, peers[0].Configured())
+	assert.Empty(t, peers[0].Variables)
+	assert.Equal(t, "193.30.121.7", peers[1].Remote())
+}
+
+func Test_ParseVariables(t *testing.T) {
+	variables := ntpcontrol.ParseVariables("version=\"ntpd ntpsec-1.2.2, built\", leap=00,\r\nstratum=2, flag, rec=0xe5b1c3a4.00000000")
+
+	assert.Equal(t, "ntpd ntpsec-1.2.2, built", variables.String("version"))
+	assert.Equal(t, int64(0), variables.Int("leap"))
+	assert.Equal(t, int64(2), variables.Int("stratum"))
+	assert.Equal(t, "", variables.String("flag"))
+	assert.Equal(t, 2022, variables.Time("rec").UTC().Year())
+	assert.True(t, variables.Time("missing").IsZero())
+}
+
+func Test_HeaderRoundTrip(t *testing.T) {
+	header := ntpcontrol.Header{Response: true, More: true, Opcode: ntpcontrol.OpReadVariables, Sequence: 9, Status: 0x0615, AssociationID: 3, Offset: 468}
+
+	packet := header.Marshal([]byte("abc"))
+	decoded, data, err := ntpcontrol.Unmarshal(packet)
+
+	assert.NoError(t, err)
+	assert.Len(t, packet, 16)
+	header.Count = 3
+	assert.Equal(t, header, decoded)
+	assert.Equal(t, []byte("abc"), data)
+}
+
+func Test_Peers_Refclock(t *testing.T) {
+	shm := ntpcontrol.Variables{"srcadr": "127.127.28.0", "srchost": "SHM(0)", "refid": "GPS", "stratum": "0", "hmode": "3"}
+	clock := ntpcontrol.Variables{"name": "SHM(0)", "device": "SHM/Shared memory interface", "poll": "12", "noreply": "1"}
+	client := startServer(t, []ntpcontroltest.Association{{ID: 3, Status: 0x9614, Variables: shm, Clock: clock}, {ID: 4, Status: 0x8011, Variables: tPeerVariables}},
+		ntpcontroltest.Options{})
+
+	peers, err := client.Peers(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, peers, 2)
+	assert.True(t, peers[0].Refclock())
+	assert.Equal(t, "SHM(0)", peers[0].Remote())
+	assert.Equal(t, "l", peers[0].Type())
+	assert.Equal(t, int64(12), peers[0].Clock.Int("poll"))
+	assert.Nil(t, peers[1].Clock)
+}
+
+func Test_ReadClockVariables_NoClock(t *testing.T) {
+	client := startServer(t, []ntpcontroltest.Association{{ID: 7, Status: 0x961a, Variables: tPeerVariables}}, ntpcontroltest.Options{})
+
+	_, err := client.ReadClockVariables(context.Background(), 7)
+
+	assert.ErrorIs(t, err, ntpcontrol.ErrServer)
+}
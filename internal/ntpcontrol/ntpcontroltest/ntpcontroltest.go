@@ -0,0 +1,178 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+// Package ntpcontroltest provides a fake ntpd answering mode 6 control requests for tests.
+package ntpcontroltest
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+
+	"ntpservice/internal/ntpcontrol"
+)
+
+// Error codes sent in the status of error responses.
+const (
+	ErrorInvalidOpcode      uint16 = 3
+	ErrorUnknownAssociation uint16 = 4
+	ErrorUnknownVariable    uint16 = 5
+)
+
+// Association of the fake ntpd, association 0 holds the system variables.
+// Clock holds the clock variables of a reference clock, nil answers read clock requests with an error.
+// Unreadable answers read variables requests with an error as for an association removed after the read status.
+type Association struct {
+	ID         uint16
+	Status     uint16
+	Variables  ntpcontrol.Variables
+	Clock      ntpcontrol.Variables
+	Unreadable bool
+}
+
+// Options of the fake ntpd.
+type Options struct {
+	SystemStatus     uint16
+	FragmentSize     int  // maximum data size of a response fragment, 0 means 468 as ntpd
+	ReverseFragments bool // fragments are sent last first
+}
+
+// Server is a fake ntpd listening on the loopback interface.
+type Server struct {
+	Addr         string
+	options      Options
+	system       ntpcontrol.Variables
+	associations []Association
+	conn         net.PacketConn
+}
+
+const defaultFragmentSize = 468
+
+// NewServer starts a fake ntpd with the system variables and the associations.
+func NewServer(system ntpcontrol.Variables, associations []Association, options Options) (*Server, error) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	if options.FragmentSize == 0 {
+		options.FragmentSize = defaultFragmentSize
+	}
+	server := &Server{
+		Addr:         conn.LocalAddr().String(),
+		options:      options,
+		system:       system,
+		associations: associations,
+		conn:         conn,
+	}
+	go server.serve()
+	return server, nil
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	return s.conn.Close()
+}
+
+func (s *Server) serve() {
+	buffer := make([]byte, 1024)
+	for {
+		n, address, err := s.conn.ReadFrom(buffer)
+		if err != nil {
+			return
+		}
+		request, data, err := ntpcontrol.Unmarshal(buffer[:n])
+		if err != nil || request.Response {
+			continue
+		}
+		response := ntpcontrol.Header{Response: true, Opcode: request.Opcode, Sequence: request.Sequence, AssociationID: request.AssociationID}
+		payload, code := s.answer(request, string(data))
+		if code != 0 {
+			response.Error = true
+			response.Status = code << 8
+			_, _ = s.conn.WriteTo(response.Marshal(nil), address)
+			continue
+		}
+		s.send(response, payload, address)
+	}
+}
+
+func (s *Server) answer(request ntpcontrol.Header, data string) ([]byte, uint16) {
+	switch request.Opcode {
+	case ntpcontrol.OpReadStatus:
+		var payload []byte
+		for _, association := range s.associations {
+			payload = binary.BigEndian.AppendUint16(payload, association.ID)
+			payload = binary.BigEndian.AppendUint16(payload, association.Status)
+		}
+		return payload, 0
+	case ntpcontrol.OpReadVariables:
+		variables := s.system
+		if request.AssociationID != 0 {
+			association := s.find(request.AssociationID)
+			if association == nil || association.Unreadable {
+				return nil, ErrorUnknownAssociation
+			}
+			variables = association.Variables
+		}
+		return selectVariables(variables, data)
+	case ntpcontrol.OpReadClock:
+		association := s.find(request.AssociationID)
+		if association == nil || association.Clock == nil {
+			return nil, ErrorUnknownAssociation
+		}
+		return selectVariables(association.Clock, data)
+	}
+	return nil, ErrorInvalidOpcode
+}
+
+// selectVariables returns the requested variables, all variables without names.
+func selectVariables(variables ntpcontrol.Variables, data string) ([]byte, uint16) {
+	if strings.TrimSpace(data) == "" {
+		return []byte(variables.Format()), 0
+	}
+	selected := ntpcontrol.Variables{}
+	for _, name := range strings.Split(data, ",") {
+		name = strings.TrimSpace(name)
+		value, ok := variables[name]
+		if !ok {
+			return nil, ErrorUnknownVariable
+		}
+		selected[name] = value
+	}
+	return []byte(selected.Format()), 0
+}
+
+func (s *Server) find(id uint16) *Association {
+	for i := range s.associations {
+		if s.associations[i].ID == id {
+			return &s.associations[i]
+		}
+	}
+	return nil
+}
+
+// send splits the payload into fragments.
+func (s *Server) send(response ntpcontrol.Header, payload []byte, address net.Addr) {
+	var packets [][]byte
+	for offset := 0; offset == 0 || offset < len(payload); offset += s.options.FragmentSize {
+		end := min(offset+s.options.FragmentSize, len(payload))
+		fragment := response
+		fragment.Offset = uint16(offset)
+		fragment.More = end < len(payload)
+		if response.Opcode == ntpcontrol.OpReadStatus {
+			fragment.Status = s.options.SystemStatus
+		}
+		packets = append(packets, fragment.Marshal(payload[offset:end]))
+	}
+	if s.options.ReverseFragments {
+		for i, j := 0, len(packets)-1; i < j; i, j = i+1, j-1 {
+			packets[i], packets[j] = packets[j], packets[i]
+		}
+	}
+	for _, packet := range packets {
+		_, _ = s.conn.WriteTo(packet, address)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpcontrol
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Flags of the peer status word.
+const (
+	PeerConfigured    uint16 = 0x8000
+	PeerAuthEnabled   uint16 = 0x4000
+	PeerAuthenticated uint16 = 0x2000
+	PeerReachable     uint16 = 0x1000
+	PeerBroadcast     uint16 = 0x0800
+)
+
+// Selection states of the peer status word.
+const (
+	SelectionReject uint8 = iota
+	SelectionFalsetick
+	SelectionExcess
+	SelectionOutlier
+	SelectionCandidate
+	SelectionBackup
+	SelectionSystemPeer
+	SelectionPpsPeer
+)
+
+// Host modes of an association.
+const (
+	ModeActive    = 1
+	ModePassive   = 2
+	ModeClient    = 3
+	ModeBroadcast = 5
+	ModeBclient   = 6
+)
+
+// tallies are the characters ntpq prints in front of the remote address for each selection state.
+var tallies = [8]byte{' ', 'x', '.', '-', '+', '#', '*', 'o'}
+
+// selectionNames are the names ntpq prints for each selection state.
+var selectionNames = [8]string{"reject", "falsetick", "excess", "outlier", "candidate", "backup", "sys.peer", "pps.peer"}
+
+// refclockPrefix is the prefix of the pseudo addresses of reference clocks.
+const refclockPrefix = "127.127."
+
+// poolReferenceID is the reference id of the pool prototype associations.
+const poolReferenceID = "POOL"
+
+// Peer is an association of ntpd with the variables of its read variables response.
+// Clock holds the variables of the read clock response of a reference clock.
+type Peer struct {
+	AssociationID uint16
+	Status        uint16
+	Variables     Variables
+	Clock         Variables
+}
+
+// Peers returns all associations of ntpd with their variables and the clock variables of the reference clocks.
+// An association whose variables can not be read, e.g. because ntpd removed it after the read status request, is
+// reported with empty variables; only a done ctx fails the whole read.
+func (c *Client) Peers(ctx context.Context) ([]Peer, error) {
+	_, associations, err := c.ReadStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	peers := make([]Peer, 0, len(associations))
+	for _, association := range associations {
+		variables, err := c.ReadVariables(ctx, association.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			peers = append(peers, Peer{AssociationID: association.ID, Status: association.Status, Variables: Variables{}})
+			continue
+		}
+		peer := Peer{AssociationID: association.ID, Status: association.Status, Variables: variables}
+		if peer.Refclock() {
+			// Not every driver answers the read clock request, the association is reported without them.
+			peer.Clock, _ = c.ReadClockVariables(ctx, association.ID)
+		}
+		peers = append(peers, peer)
+	}
+	return peers, nil
+}
+
+// Selection returns the selection state of the peer status word.
+func (p Peer) Selection() uint8 {
+	return uint8(p.Status>>8) & 0x7
+}
+
+// SelectionName returns the name of the selection state, e.g. sys.peer.
+func (p Peer) SelectionName() string {
+	return selectionNames[p.Selection()]
+}
+
+// Tally returns the character ntpq prints in front of the remote address.
+func (p Peer) Tally() byte {
+	return tallies[p.Selection()]
+}
+
+// Configured reports whether the association was configured in ntp.conf, associations spawned from a pool are not.
+func (p Peer) Configured() bool {
+	return p.Status&PeerConfigured != 0
+}
+
+// Authenticated reports whether the last packets of the peer were authenticated.
+func (p Peer) Authenticated() bool {
+	return p.Status&PeerAuthenticated != 0
+}
+
+// Reachable reports whether the peer answered one of the last eight polls.
+func (p Peer) Reachable() bool {
+	return p.Status&PeerReachable != 0
+}
+
+// Refclock reports whether the association is a reference clock.
+func (p Peer) Refclock() bool {
+	return strings.HasPrefix(p.Variables.String("srcadr"), refclockPrefix)
+}
+
+// Remote returns the address of the peer, the host name for pool prototypes and reference clocks if ntpd sends one.
+func (p Peer) Remote() string {
+	if host := p.Variables.String("srchost"); host != "" && (p.Refclock() || p.ReferenceID() == poolReferenceID) {
+		return host
+	}
+	return p.Variables.String("srcadr")
+}
+
+// ReferenceID returns the reference id as sent by ntpd.
+func (p Peer) ReferenceID() string {
+	return strings.Trim(p.Variables.String("refid"), ".")
+}
+
+// DisplayReferenceID returns the reference id as ntpq prints it, ids which are no address are enclosed in dots.
+func (p Peer) DisplayReferenceID() string {
+	refid := p.ReferenceID()
+	if refid == "" || net.ParseIP(refid) != nil {
+		return refid
+	}
+	return "." + refid + "."
+}
+
+// Type returns the association type ntpq prints in the t column.
+func (p Peer) Type() string {
+	hostMode := p.Variables.Int("hmode")
+	switch {
+	case p.Refclock():
+		return "l"
+	case p.ReferenceID() == poolReferenceID:
+		return "p"
+	case hostMode == ModeBroadcast:
+		return "B"
+	case hostMode == ModeBclient:
+		return "b"
+	case hostMode == ModeActive || hostMode == ModePassive:
+		return "s"
+	}
+	if ip := net.ParseIP(p.Variables.String("srcadr")); ip != nil && ip.IsMulticast() {
+		return "m"
+	}
+	return "u"
+}
+
+// Poll returns the poll interval in seconds.
+func (p Peer) Poll() int64 {
+	exponent := p.Variables.Int("hpoll")
+	if peerPoll := p.Variables.Int("ppoll"); peerPoll > 0 && peerPoll < exponent {
+		exponent = peerPoll
+	}
+	if exponent < 0 {
+		return 0
+	}
+	return 1 << exponent
+}
+
+// Reach returns the reach register.
+func (p Peer) Reach() int64 {
+	return p.Variables.Int("reach")
+}
+
+// Leap returns the leap indicator, ntpd sends it either as number or as two bits, e.g. 11.
+func (p Peer) Leap() int64 {
+	value := p.Variables.String("leap")
+	if len(value) == 2 && strings.Trim(value, "01") == "" {
+		leap, _ := strconv.ParseInt(value, 2, 64)
+		return leap
+	}
+	return p.Variables.Int("leap")
+}
+
+// When returns the seconds since the last packet was received, -1 if none was received.
+func (p Peer) When(now time.Time) int64 {
+	last := p.Variables.Time("rec")
+	if last.IsZero() {
+		last = p.Variables.Time("reftime")
+	}
+	if last.IsZero() {
+		return -1
+	}
+	return int64(now.Sub(last).Round(time.Second) / time.Second)
+}
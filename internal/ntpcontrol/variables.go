@@ -0,0 +1,101 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpcontrol
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ntpservice/internal/sntp"
+)
+
+// Variables are the name=value pairs of a read variables response, quotes of string values are removed.
+type Variables map[string]string
+
+// ParseVariables parses the `name=value, name="value"` text sent by ntpd, commas in quoted values are kept.
+func ParseVariables(data string) Variables {
+	variables := make(Variables)
+	var pairs []string
+	start, quoted := 0, false
+	for i, c := range data {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == ',' && !quoted:
+			pairs = append(pairs, data[start:i])
+			start = i + 1
+		}
+	}
+	pairs = append(pairs, data[start:])
+	for _, pair := range pairs {
+		name, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+		if name == "" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = value[1 : len(value)-1]
+		}
+		variables[name] = value
+	}
+	return variables
+}
+
+// Format encodes the variables as ntpd does, values containing commas or spaces are quoted.
+func (v Variables) Format() string {
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		value := v[name]
+		if strings.ContainsAny(value, ", ") {
+			value = strconv.Quote(value)
+		}
+		pairs = append(pairs, name+"="+value)
+	}
+	return strings.Join(pairs, ",\r\n")
+}
+
+// String returns the value of the variable, empty if it is missing.
+func (v Variables) String(name string) string {
+	return v[name]
+}
+
+// Int returns the value of an integer variable, hex values with 0x prefix are accepted.
+func (v Variables) Int(name string) int64 {
+	value, _ := strconv.ParseInt(v[name], 0, 64)
+	return value
+}
+
+// Float returns the value of a floating point variable.
+func (v Variables) Float(name string) float64 {
+	value, _ := strconv.ParseFloat(v[name], 64)
+	return value
+}
+
+// Time returns the value of a timestamp variable sent as hex NTP timestamp, e.g. 0xe5b1c3a4.1f2e3d4c.
+func (v Variables) Time(name string) time.Time {
+	seconds, fraction, _ := strings.Cut(strings.TrimPrefix(v[name], "0x"), ".")
+	high, err := strconv.ParseUint(seconds, 16, 32)
+	if err != nil {
+		return time.Time{}
+	}
+	low, _ := strconv.ParseUint(fraction, 16, 32)
+	return sntp.TimestampToTime(high<<32 | low)
+}
+
+// FormatTime formats the time as hex NTP timestamp as ntpd sends it, the inverse of Time.
+func FormatTime(t time.Time) string {
+	timestamp := sntp.TimeToTimestamp(t)
+	return fmt.Sprintf("0x%08x.%08x", timestamp>>32, timestamp&0xffffffff)
+}
@@ -0,0 +1,554 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpctl
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// command is a command of ntpctl, a group like keys has sub commands instead of run.
+type command struct {
+	name    string
+	args    string // usage of the arguments
+	summary string
+	run     func(c *cli, ctx context.Context, args []string) error
+	sub     []command
+	path    string // names of the group and the command, set by findCommand
+}
+
+// commands are the commands of ntpctl, every RPC of the NtpService is reachable.
+var commands = []command{
+	{name: "get", summary: "print the configured NTP servers (GetNtpServer)", run: runGet},
+	{name: "set", args: "[-sync-timeout duration] [-fallback servers] [-f file.json | server...]",
+		summary: "replace the NTP servers (SetNtpServer)", run: runSet},
+	{name: "status", args: "[-watch [-offset-delta ms]] [-check]",
+		summary: "print the synchronization status (GetStatus, WatchStatus)", run: runStatus},
+	{name: "query", args: "[-samples n] [-version n] [-timeout-ms ms] address",
+		summary: "query an NTP server without configuring it (QueryServer)", run: runQuery},
+	{name: "keys", sub: []command{
+		{name: "list", summary: "print the symmetric keys (ListKeys)", run: runKeysList},
+		{name: "create", args: "[-type md5|sha1|aes128cmac] [-secret-file file] id",
+			summary: "create a symmetric key, the secret is read from stdin by default (CreateKey)", run: runKeysCreate},
+		{name: "rotate", args: "[-type md5|sha1|aes128cmac] [-secret-file file] id",
+			summary: "replace the secret of a symmetric key (RotateKey)", run: runKeysRotate},
+		{name: "delete", args: "id", summary: "delete a symmetric key (DeleteKey)", run: runKeysDelete},
+	}},
+	{name: "nts-ca", sub: []command{
+		{name: "get", summary: "print the CA bundle of NTS (GetNtsCaBundle)", run: runNtsCaGet},
+		{name: "set", args: "file.pem", summary: "replace the CA bundle of NTS, an empty file removes it (SetNtsCaBundle)", run: runNtsCaSet},
+	}},
+	{name: "serving", sub: []command{
+		{name: "get", summary: "print the time service offered to other devices (GetServing)", run: runServingGet},
+		{name: "set", args: "file.json", summary: "replace the time service offered to other devices (SetServing)", run: runServingSet},
+	}},
+	{name: "orphan", sub: []command{
+		{name: "get", summary: "print the orphan mode (GetOrphanMode)", run: runOrphanGet},
+		{name: "set", args: "file.json", summary: "replace the orphan mode and its peers (SetOrphanMode)", run: runOrphanSet},
+	}},
+	{name: "refclocks", sub: []command{
+		{name: "get", summary: "print the reference clocks (GetRefclocks)", run: runRefclocksGet},
+		{name: "set", args: "file.json", summary: "replace the reference clocks (SetRefclocks)", run: runRefclocksSet},
+	}},
+	{name: "history", sub: []command{
+		{name: "list", summary: "print the kept ntp.conf revisions (ListConfigHistory)", run: runHistoryList},
+		{name: "diff", args: "from [to]", summary: "print the diff of two revisions, to the current one by default (DiffConfig)", run: runHistoryDiff},
+		{name: "restore", args: "[-sync-timeout duration] id", summary: "apply a kept revision (RestoreConfig)", run: runHistoryRestore},
+	}},
+	{name: "leap", sub: []command{
+		{name: "get", summary: "print the leap seconds file (GetLeapSecondsFile)", run: runLeapGet},
+		{name: "set", args: "leap-seconds.list", summary: "replace the leap seconds file (SetLeapSecondsFile)", run: runLeapSet},
+	}},
+}
+
+// findCommand returns the command named by the first arguments and the remaining arguments.
+func findCommand(list []command, args []string) (command, []string, bool) {
+	if len(args) == 0 {
+		return command{}, nil, false
+	}
+	for _, cmd := range list {
+		if cmd.name != args[0] {
+			continue
+		}
+		if cmd.sub == nil {
+			cmd.path = cmd.name
+			return cmd, args[1:], true
+		}
+		sub, rest, ok := findCommand(cmd.sub, args[1:])
+		sub.path = cmd.name + " " + sub.path
+		return sub, rest, ok
+	}
+	return command{}, nil, false
+}
+
+func printCommands(w io.Writer, list []command) {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	var add func(prefix string, list []command)
+	add = func(prefix string, list []command) {
+		for _, cmd := range list {
+			if cmd.sub != nil {
+				add(prefix+cmd.name+" ", cmd.sub)
+				continue
+			}
+			fmt.Fprintf(table, "  %s%s %s\t%s\n", prefix, cmd.name, cmd.args, cmd.summary)
+		}
+	}
+	add("", list)
+	table.Flush()
+}
+
+// newFlags returns the flags of a command, errors are printed with the usage of the command.
+func (c *cli) newFlags(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() {}
+	return flags
+}
+
+// parse parses the flags and checks the number of the remaining arguments.
+func parse(flags *flag.FlagSet, args []string, minArgs int, maxArgs int) ([]string, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, errUsage
+	}
+	if flags.NArg() < minArgs || flags.NArg() > maxArgs {
+		return nil, errUsage
+	}
+	return flags.Args(), nil
+}
+
+// readInput reads a file, - is stdin.
+func (c *cli) readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(c.stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// readMessage reads a message in the JSON mapping of protobuf, e.g. the output of get with -o json.
+func (c *cli) readMessage(path string, message proto.Message) error {
+	content, err := c.readInput(path)
+	if err != nil {
+		return err
+	}
+	if err := protojson.Unmarshal(content, message); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// parseUint parses a numeric argument like a key id or a revision.
+func parseUint(value string, bits int) (uint64, error) {
+	number, err := strconv.ParseUint(value, 10, bits)
+	if err != nil {
+		return 0, errUsage
+	}
+	return number, nil
+}
+
+func runGet(c *cli, ctx context.Context, args []string) error {
+	if _, err := parse(c.newFlags("get"), args, 0, 0); err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	ntp, err := c.client.GetNtpServer(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	return show(c, ntp, printNtp)
+}
+
+func runSet(c *cli, ctx context.Context, args []string) error {
+	flags := c.newFlags("set")
+	file := flags.String("f", "", "Ntp message in JSON, - is stdin")
+	syncTimeout := flags.Duration("sync-timeout", 0, "roll back unless a server is selected within this time")
+	fallback := flags.String("fallback", "", "comma separated fallback servers, timesyncd only")
+	servers, err := parse(flags, args, 0, 1<<16)
+	if err != nil {
+		return err
+	}
+	config := &v1.Ntp{}
+	if *file != "" {
+		if len(servers) > 0 {
+			return errUsage
+		}
+		if err := c.readMessage(*file, config); err != nil {
+			return err
+		}
+	} else {
+		if len(servers) == 0 {
+			return errUsage
+		}
+		config.NtpServer = servers
+	}
+	if *fallback != "" {
+		config.FallbackNtpServer = strings.Split(*fallback, ",")
+	}
+	if *syncTimeout > 0 {
+		config.SyncTimeoutMs = uint32(syncTimeout.Milliseconds())
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err = c.client.SetNtpServer(ctx, config)
+	return err
+}
+
+func runStatus(c *cli, ctx context.Context, args []string) error {
+	flags := c.newFlags("status")
+	watch := flags.Bool("watch", false, "print the status whenever it changes until interrupted")
+	offsetDelta := flags.Float64("offset-delta", 0, "minimum change of the offset printed by -watch (in milliseconds)")
+	check := flags.Bool("check", false, "exit with 5 unless the time daemon runs and the clock is synchronized")
+	if _, err := parse(flags, args, 0, 0); err != nil {
+		return err
+	}
+	if *watch && *check {
+		return errUsage
+	}
+	if *watch {
+		return watchStatus(c, ctx, float32(*offsetDelta))
+	}
+	callCtx, cancel := c.call(ctx)
+	defer cancel()
+	current, err := c.client.GetStatus(callCtx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	if err := show(c, current, printStatus); err != nil {
+		return err
+	}
+	if *check && !(current.GetIsNtpServiceRunning() && current.GetIsSynced()) {
+		return errNotSynced
+	}
+	return nil
+}
+
+// watchStatus prints every status of the stream, JSON as one line per status, until ctx is canceled or the service
+// ends the stream.
+func watchStatus(c *cli, ctx context.Context, offsetDeltaMs float32) error {
+	stream, err := c.client.WatchStatus(ctx, &v1.WatchStatusRequest{OffsetDeltaMs: offsetDeltaMs})
+	if err != nil {
+		return err
+	}
+	for {
+		current, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if c.output == FormatJSON {
+			line, err := protojson.Marshal(current)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(c.stdout, string(line))
+			continue
+		}
+		fmt.Fprintf(c.stdout, "--- %s\n", time.Now().Format(time.RFC3339))
+		if err := show(c, current, printStatus); err != nil {
+			return err
+		}
+	}
+}
+
+func runQuery(c *cli, ctx context.Context, args []string) error {
+	flags := c.newFlags("query")
+	samples := flags.Int("samples", 0, "number of packets sent one second apart (1-8), 0 means 1")
+	version := flags.Int("version", 0, "NTP version of the requests (1-4), 0 means 4")
+	timeoutMs := flags.Int("timeout-ms", 0, "time to wait for each response, 0 means 2000")
+	address, err := parse(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	result, err := c.client.QueryServer(ctx, &v1.QueryServerRequest{Address: address[0], Samples: int32(*samples),
+		Version: int32(*version), TimeoutMs: int32(*timeoutMs)})
+	if err != nil {
+		return err
+	}
+	return show(c, result, printQueryResult)
+}
+
+func runKeysList(c *cli, ctx context.Context, args []string) error {
+	if _, err := parse(c.newFlags("list"), args, 0, 0); err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	keys, err := c.client.ListKeys(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	return show(c, keys, printKeys)
+}
+
+func runKeysCreate(c *cli, ctx context.Context, args []string) error {
+	key, err := c.parseKey("create", args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err = c.client.CreateKey(ctx, key)
+	return err
+}
+
+func runKeysRotate(c *cli, ctx context.Context, args []string) error {
+	key, err := c.parseKey("rotate", args)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err = c.client.RotateKey(ctx, key)
+	return err
+}
+
+// parseKey reads the key of create and rotate, the secret is not taken from the arguments to keep it out of the
+// process list and the shell history.
+func (c *cli) parseKey(name string, args []string) (*v1.SymmetricKey, error) {
+	flags := c.newFlags(name)
+	keyType := flags.String("type", "sha1", "digest algorithm, md5, sha1 or aes128cmac")
+	secretFile := flags.String("secret-file", "-", "file with the secret, - is stdin")
+	id, err := parse(flags, args, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	keyId, err := parseUint(id[0], 16)
+	if err != nil {
+		return nil, err
+	}
+	typeValue, ok := v1.KeyType_value[strings.ToUpper(*keyType)]
+	if !ok {
+		return nil, errUsage
+	}
+	secret, err := c.readInput(*secretFile)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.SymmetricKey{KeyId: uint32(keyId), Type: v1.KeyType(typeValue), Secret: strings.TrimSpace(string(secret))}, nil
+}
+
+func runKeysDelete(c *cli, ctx context.Context, args []string) error {
+	id, err := parse(c.newFlags("delete"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+	keyId, err := parseUint(id[0], 16)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err = c.client.DeleteKey(ctx, &v1.SymmetricKeyId{KeyId: uint32(keyId)})
+	return err
+}
+
+func runNtsCaGet(c *cli, ctx context.Context, args []string) error {
+	if _, err := parse(c.newFlags("get"), args, 0, 0); err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	bundle, err := c.client.GetNtsCaBundle(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	return show(c, bundle, func(w io.Writer, bundle *v1.NtsCaBundle) { fmt.Fprint(w, bundle.GetPem()) })
+}
+
+func runNtsCaSet(c *cli, ctx context.Context, args []string) error {
+	file, err := parse(c.newFlags("set"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+	pem, err := c.readInput(file[0])
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err = c.client.SetNtsCaBundle(ctx, &v1.NtsCaBundle{Pem: string(pem)})
+	return err
+}
+
+func runServingGet(c *cli, ctx context.Context, args []string) error {
+	if _, err := parse(c.newFlags("get"), args, 0, 0); err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	serving, err := c.client.GetServing(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	return show(c, serving, printServing)
+}
+
+func runServingSet(c *cli, ctx context.Context, args []string) error {
+	serving := &v1.ServingConfig{}
+	if err := c.parseMessage("set", args, serving); err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err := c.client.SetServing(ctx, serving)
+	return err
+}
+
+func runOrphanGet(c *cli, ctx context.Context, args []string) error {
+	if _, err := parse(c.newFlags("get"), args, 0, 0); err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	orphan, err := c.client.GetOrphanMode(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	return show(c, orphan, printOrphan)
+}
+
+func runOrphanSet(c *cli, ctx context.Context, args []string) error {
+	orphan := &v1.OrphanConfig{}
+	if err := c.parseMessage("set", args, orphan); err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err := c.client.SetOrphanMode(ctx, orphan)
+	return err
+}
+
+func runRefclocksGet(c *cli, ctx context.Context, args []string) error {
+	if _, err := parse(c.newFlags("get"), args, 0, 0); err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	refclocks, err := c.client.GetRefclocks(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	return show(c, refclocks, printRefclocks)
+}
+
+func runRefclocksSet(c *cli, ctx context.Context, args []string) error {
+	refclocks := &v1.Refclocks{}
+	if err := c.parseMessage("set", args, refclocks); err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err := c.client.SetRefclocks(ctx, refclocks)
+	return err
+}
+
+// parseMessage reads the message of a set command from the file given as only argument.
+func (c *cli) parseMessage(name string, args []string, message proto.Message) error {
+	file, err := parse(c.newFlags(name), args, 1, 1)
+	if err != nil {
+		return err
+	}
+	return c.readMessage(file[0], message)
+}
+
+func runHistoryList(c *cli, ctx context.Context, args []string) error {
+	if _, err := parse(c.newFlags("list"), args, 0, 0); err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	history, err := c.client.ListConfigHistory(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	return show(c, history, printHistory)
+}
+
+func runHistoryDiff(c *cli, ctx context.Context, args []string) error {
+	revisions, err := parse(c.newFlags("diff"), args, 1, 2)
+	if err != nil {
+		return err
+	}
+	request := &v1.ConfigDiffRequest{}
+	if request.From, err = parseUint(revisions[0], 64); err != nil {
+		return err
+	}
+	if len(revisions) == 2 {
+		if request.To, err = parseUint(revisions[1], 64); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	diff, err := c.client.DiffConfig(ctx, request)
+	if err != nil {
+		return err
+	}
+	return show(c, diff, func(w io.Writer, diff *v1.ConfigDiff) { fmt.Fprint(w, diff.GetDiff()) })
+}
+
+func runHistoryRestore(c *cli, ctx context.Context, args []string) error {
+	flags := c.newFlags("restore")
+	syncTimeout := flags.Duration("sync-timeout", 0, "roll back unless a server is selected within this time")
+	id, err := parse(flags, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	revision, err := parseUint(id[0], 64)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err = c.client.RestoreConfig(ctx, &v1.RestoreConfigRequest{Id: revision, SyncTimeoutMs: uint32(syncTimeout.Milliseconds())})
+	return err
+}
+
+func runLeapGet(c *cli, ctx context.Context, args []string) error {
+	if _, err := parse(c.newFlags("get"), args, 0, 0); err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	leap, err := c.client.GetLeapSecondsFile(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	return show(c, leap, func(w io.Writer, leap *v1.LeapSecondsFile) { fmt.Fprint(w, leap.GetContent()) })
+}
+
+func runLeapSet(c *cli, ctx context.Context, args []string) error {
+	file, err := parse(c.newFlags("set"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+	content, err := c.readInput(file[0])
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.call(ctx)
+	defer cancel()
+	_, err = c.client.SetLeapSecondsFile(ctx, &v1.LeapSecondsFile{Content: string(content)})
+	return err
+}
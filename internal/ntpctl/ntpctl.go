@@ -0,0 +1,218 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+// Package ntpctl is the command line client of the NtpService, it calls the RPCs over the unix socket or a TCP
+// listener and prints the results as tables or JSON.
+package ntpctl
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultSocket is the unix socket of the service installed by the deb package.
+const DefaultSocket = "/var/run/devicemodel/ntp.sock"
+
+// DefaultTimeout bounds every RPC except WatchStatus.
+const DefaultTimeout = 30 * time.Second
+
+// Exit codes of ntpctl, scripts can tell a down service from a refused request.
+const (
+	ExitOK          = 0 // the command succeeded
+	ExitFailed      = 1 // the RPC failed, e.g. an aborted SetNtpServer which was rolled back
+	ExitUsage       = 2 // invalid command, flags or input file
+	ExitUnavailable = 3 // the service is not reachable or did not answer in time
+	ExitRejected    = 4 // the service refused the request: invalid argument, permission denied or not supported
+	ExitNotSynced   = 5 // status -check: the time daemon is not running or the clock is not synchronized
+)
+
+// Output formats of the results.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// errUsage is returned for invalid arguments, the usage of the command is printed.
+var errUsage = errors.New("usage")
+
+// errNotSynced is returned by status -check for an unsynchronized clock.
+var errNotSynced = errors.New("the clock is not synchronized")
+
+// options are the global flags in front of the command.
+type options struct {
+	socket        string
+	tcp           string
+	tlsCA         string
+	tlsCert       string
+	tlsKey        string
+	tlsServerName string
+	timeout       time.Duration
+	output        string
+}
+
+// cli runs one command with the client of the service.
+type cli struct {
+	options
+	client v1.NtpServiceClient
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// Run parses the arguments without the program name, runs the command and returns the exit code.
+// The context cancels the command, e.g. a watch on SIGINT.
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	c := &cli{stdin: stdin, stdout: stdout, stderr: stderr}
+	flags := flag.NewFlagSet("ntpctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&c.socket, "socket", DefaultSocket, "unix socket of the service")
+	flags.StringVar(&c.tcp, "tcp", "", "TCP address of the service, e.g. device:50006, replaces -socket")
+	flags.StringVar(&c.tlsCA, "tls-ca", "", "PEM file of the CAs of the server certificate, enables TLS for -tcp")
+	flags.StringVar(&c.tlsCert, "tls-cert", "", "PEM file of the client certificate for mutual TLS")
+	flags.StringVar(&c.tlsKey, "tls-key", "", "PEM file of the key of the client certificate")
+	flags.StringVar(&c.tlsServerName, "tls-server-name", "", "name expected in the server certificate, the host of -tcp by default")
+	flags.DurationVar(&c.timeout, "timeout", DefaultTimeout, "timeout of an RPC")
+	flags.StringVar(&c.output, "o", FormatTable, "output format, table or json")
+	flags.Usage = func() { c.usage(flags) }
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if c.output != FormatTable && c.output != FormatJSON {
+		fmt.Fprintf(stderr, "ntpctl: unknown output format %q, expected table or json\n", c.output)
+		return ExitUsage
+	}
+	if flags.NArg() == 0 {
+		c.usage(flags)
+		return ExitUsage
+	}
+	cmd, args, ok := findCommand(commands, flags.Args())
+	if !ok {
+		fmt.Fprintf(stderr, "ntpctl: unknown command %q\n", strings.Join(flags.Args(), " "))
+		c.usage(flags)
+		return ExitUsage
+	}
+
+	conn, err := c.dial()
+	if err != nil {
+		fmt.Fprintln(stderr, "ntpctl:", err)
+		return ExitUsage
+	}
+	defer conn.Close()
+	c.client = v1.NewNtpServiceClient(conn)
+
+	err = cmd.run(c, ctx, args)
+	if errors.Is(err, errUsage) {
+		fmt.Fprintf(stderr, "usage: ntpctl [flags] %s %s\n", cmd.path, cmd.args)
+		return ExitUsage
+	}
+	return c.report(err)
+}
+
+// dial connects to the unix socket or, with -tcp, to the TCP listener. The connection is established by the first RPC.
+func (c *cli) dial() (*grpc.ClientConn, error) {
+	if c.tcp == "" {
+		return grpc.NewClient("unix://"+c.socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	creds, err := c.transportCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return grpc.NewClient(c.tcp, grpc.WithTransportCredentials(creds))
+}
+
+// transportCredentials returns TLS credentials if a CA or a client certificate is given, plaintext otherwise.
+func (c *cli) transportCredentials() (credentials.TransportCredentials, error) {
+	if c.tlsCA == "" && c.tlsCert == "" && c.tlsKey == "" {
+		return insecure.NewCredentials(), nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: c.tlsServerName}
+	if c.tlsCA != "" {
+		pem, err := os.ReadFile(c.tlsCA)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate in %s", c.tlsCA)
+		}
+	}
+	if c.tlsCert != "" || c.tlsKey != "" {
+		certificate, err := tls.LoadX509KeyPair(c.tlsCert, c.tlsKey)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// call returns the context of an RPC bounded by the timeout.
+func (c *cli) call(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// report prints the error of a command with the code and the details of the status and returns the exit code.
+func (c *cli) report(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if errors.Is(err, errNotSynced) {
+		fmt.Fprintln(c.stderr, "ntpctl:", err)
+		return ExitNotSynced
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		fmt.Fprintln(c.stderr, "ntpctl:", err)
+		return ExitUsage
+	}
+	fmt.Fprintf(c.stderr, "ntpctl: %s: %s\n", st.Code(), st.Message())
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			for _, key := range sortedKeys(info.GetMetadata()) {
+				fmt.Fprintf(c.stderr, "  %s: %s\n", key, info.GetMetadata()[key])
+			}
+		}
+	}
+	return exitCode(st.Code())
+}
+
+// exitCode maps the status code of a failed RPC to the exit code.
+func exitCode(code codes.Code) int {
+	switch code {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return ExitUnavailable
+	case codes.InvalidArgument, codes.PermissionDenied, codes.Unauthenticated, codes.Unimplemented,
+		codes.FailedPrecondition, codes.NotFound, codes.AlreadyExists, codes.OutOfRange:
+		return ExitRejected
+	}
+	return ExitFailed
+}
+
+func (c *cli) usage(flags *flag.FlagSet) {
+	fmt.Fprintln(c.stderr, "usage: ntpctl [flags] <command> [arguments]")
+	fmt.Fprintln(c.stderr, "\ncommands:")
+	printCommands(c.stderr, commands)
+	fmt.Fprintln(c.stderr, "\nflags:")
+	flags.PrintDefaults()
+	fmt.Fprintln(c.stderr, "\nexit codes: 0 ok, 1 failed, 2 usage, 3 service unavailable, 4 request rejected, 5 not synchronized")
+}
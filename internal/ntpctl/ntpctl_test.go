@@ -0,0 +1,270 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpctl
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// tServer is the NtpService answering with fixed results and recording the requests.
+type tServer struct {
+	v1.UnimplementedNtpServiceServer
+	mutex    sync.Mutex
+	requests []proto.Message
+	statuses []*v1.Status
+}
+
+func (s *tServer) record(request proto.Message) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests = append(s.requests, request)
+}
+
+func (s *tServer) GetNtpServer(context.Context, *emptypb.Empty) (*v1.Ntp, error) {
+	return &v1.Ntp{NtpServer: []string{"10.0.0.1"}, NtpServerEntries: []*v1.NtpServerEntry{
+		{Address: "pool.example.com", Kind: v1.NtpEntryKind_POOL, Iburst: true, Maxpoll: 10},
+	}}, nil
+}
+
+func (s *tServer) SetNtpServer(_ context.Context, config *v1.Ntp) (*emptypb.Empty, error) {
+	s.record(config)
+	if len(config.GetNtpServer()) > 0 && config.GetNtpServer()[0] == "unreachable.example.com" {
+		st, _ := status.New(codes.Aborted, "Failed to Set: start failed").WithDetails(&errdetails.ErrorInfo{
+			Reason: "APPLY_FAILED", Metadata: map[string]string{"step": "start", "rolledBack": "true"}})
+		return nil, st.Err()
+	}
+	if len(config.GetNtpServer()) > 0 && config.GetNtpServer()[0] == "" {
+		return nil, status.New(codes.InvalidArgument, "empty server").Err()
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *tServer) GetStatus(context.Context, *emptypb.Empty) (*v1.Status, error) {
+	return s.statuses[0], nil
+}
+
+func (s *tServer) WatchStatus(request *v1.WatchStatusRequest, stream grpc.ServerStreamingServer[v1.Status]) error {
+	s.record(request)
+	for _, current := range s.statuses {
+		if err := stream.Send(current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *tServer) CreateKey(_ context.Context, key *v1.SymmetricKey) (*emptypb.Empty, error) {
+	s.record(key)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *tServer) DiffConfig(_ context.Context, request *v1.ConfigDiffRequest) (*v1.ConfigDiff, error) {
+	s.record(request)
+	return &v1.ConfigDiff{Diff: "-server 10.0.0.1\n+server 10.0.0.2\n"}, nil
+}
+
+// startServer serves the fake on a unix socket, the directory is short enough for the socket path limit.
+func startServer(t *testing.T) (*tServer, string) {
+	dir, err := os.MkdirTemp("", "ntpctl")
+	assert.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "ntp.sock")
+	lis, err := net.Listen("unix", socket)
+	assert.NoError(t, err)
+	fake := &tServer{statuses: []*v1.Status{
+		{IsNtpServiceRunning: true, IsSynced: false, PeerDetails: []*v1.PeerDetails{{RemoteServer: "+10.0.0.1", Reach: "377"}}},
+		{IsNtpServiceRunning: true, IsSynced: true, SystemVariables: &v1.SystemVariables{Offset: 0.25, Stratum: 2}},
+	}}
+	server := grpc.NewServer()
+	v1.RegisterNtpServiceServer(server, fake)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return fake, socket
+}
+
+// run runs ntpctl with the socket and returns the exit code, stdout and stderr.
+func run(socket string, stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := Run(context.Background(), append([]string{"-socket", socket}, args...), strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func Test_Get(t *testing.T) {
+	_, socket := startServer(t)
+
+	code, stdout, _ := run(socket, "", "get")
+
+	assert.Equal(t, ExitOK, code)
+	assert.Equal(t, "KIND    ADDRESS           OPTIONS\n"+
+		"server  10.0.0.1          \n"+
+		"pool    pool.example.com  iburst maxpoll 10\n", stdout)
+}
+
+func Test_Get_JSON(t *testing.T) {
+	_, socket := startServer(t)
+
+	code, stdout, _ := run(socket, "", "-o", "json", "get")
+
+	assert.Equal(t, ExitOK, code)
+	ntp := &v1.Ntp{}
+	assert.NoError(t, readJSON(stdout, ntp))
+	assert.Equal(t, "pool.example.com", ntp.GetNtpServerEntries()[0].GetAddress())
+}
+
+func Test_Set(t *testing.T) {
+	fake, socket := startServer(t)
+
+	code, _, stderr := run(socket, "", "set", "-sync-timeout", "30s", "-fallback", "10.0.1.1,10.0.1.2", "10.0.0.1", "10.0.0.2")
+
+	assert.Equal(t, ExitOK, code, stderr)
+	assert.True(t, proto.Equal(&v1.Ntp{NtpServer: []string{"10.0.0.1", "10.0.0.2"}, SyncTimeoutMs: 30000,
+		FallbackNtpServer: []string{"10.0.1.1", "10.0.1.2"}}, fake.requests[0]), "Did not get expected result. got: %v", fake.requests[0])
+}
+
+func Test_Set_FromStdin(t *testing.T) {
+	fake, socket := startServer(t)
+
+	code, _, stderr := run(socket, `{"ntpServerEntries": [{"address": "ptb.example.com", "prefer": true}]}`, "set", "-f", "-")
+
+	assert.Equal(t, ExitOK, code, stderr)
+	assert.True(t, proto.Equal(&v1.Ntp{NtpServerEntries: []*v1.NtpServerEntry{{Address: "ptb.example.com", Prefer: true}}}, fake.requests[0]))
+}
+
+func Test_Set_Aborted(t *testing.T) {
+	_, socket := startServer(t)
+
+	code, _, stderr := run(socket, "", "set", "unreachable.example.com")
+
+	assert.Equal(t, ExitFailed, code)
+	assert.Contains(t, stderr, "ntpctl: Aborted: Failed to Set: start failed")
+	assert.Contains(t, stderr, "  step: start\n")
+}
+
+func Test_Set_Rejected(t *testing.T) {
+	_, socket := startServer(t)
+
+	code, _, _ := run(socket, "", "set", "")
+
+	assert.Equal(t, ExitRejected, code)
+}
+
+func Test_Status(t *testing.T) {
+	_, socket := startServer(t)
+
+	code, stdout, _ := run(socket, "", "status")
+
+	assert.Equal(t, ExitOK, code)
+	assert.Contains(t, stdout, "Synchronized:        no\n")
+	assert.Contains(t, stdout, "+10.0.0.1")
+}
+
+func Test_Status_Check(t *testing.T) {
+	_, socket := startServer(t)
+
+	code, _, stderr := run(socket, "", "status", "-check")
+
+	assert.Equal(t, ExitNotSynced, code)
+	assert.Contains(t, stderr, errNotSynced.Error())
+}
+
+func Test_Status_Watch(t *testing.T) {
+	fake, socket := startServer(t)
+
+	code, stdout, stderr := run(socket, "", "-o", "json", "status", "-watch", "-offset-delta", "2")
+
+	assert.Equal(t, ExitOK, code, stderr)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Len(t, lines, 2, "Did not get expected result. Every status must be one JSON line")
+	second := &v1.Status{}
+	assert.NoError(t, readJSON(lines[1], second))
+	assert.True(t, proto.Equal(fake.statuses[1], second))
+	assert.True(t, proto.Equal(&v1.WatchStatusRequest{OffsetDeltaMs: 2}, fake.requests[0]))
+}
+
+func Test_KeysCreate(t *testing.T) {
+	fake, socket := startServer(t)
+
+	code, _, stderr := run(socket, "s3cret\n", "keys", "create", "-type", "md5", "7")
+
+	assert.Equal(t, ExitOK, code, stderr)
+	assert.True(t, proto.Equal(&v1.SymmetricKey{KeyId: 7, Type: v1.KeyType_MD5, Secret: "s3cret"}, fake.requests[0]))
+}
+
+func Test_HistoryDiff(t *testing.T) {
+	fake, socket := startServer(t)
+
+	code, stdout, _ := run(socket, "", "history", "diff", "3", "5")
+
+	assert.Equal(t, ExitOK, code)
+	assert.Equal(t, "-server 10.0.0.1\n+server 10.0.0.2\n", stdout)
+	assert.True(t, proto.Equal(&v1.ConfigDiffRequest{From: 3, To: 5}, fake.requests[0]))
+}
+
+func Test_Unimplemented(t *testing.T) {
+	_, socket := startServer(t)
+
+	code, _, stderr := run(socket, "", "serving", "get")
+
+	assert.Equal(t, ExitRejected, code)
+	assert.Contains(t, stderr, "Unimplemented")
+}
+
+func Test_Unavailable(t *testing.T) {
+	code, _, _ := run(filepath.Join(t.TempDir(), "missing.sock"), "", "get")
+
+	assert.Equal(t, ExitUnavailable, code)
+}
+
+func Test_Usage(t *testing.T) {
+	_, socket := startServer(t)
+
+	for _, args := range [][]string{
+		{},
+		{"reboot"},
+		{"keys"},
+		{"keys", "delete", "abc"},
+		{"set"},
+		{"set", "-f", "config.json", "10.0.0.1"},
+		{"status", "-watch", "-check"},
+		{"history", "diff"},
+		{"-o", "yaml", "get"},
+	} {
+		code, _, _ := run(socket, "", args...)
+		assert.Equal(t, ExitUsage, code, "Did not get expected result for %v", args)
+	}
+}
+
+func Test_exitCode(t *testing.T) {
+	assert.Equal(t, ExitUnavailable, exitCode(codes.Unavailable))
+	assert.Equal(t, ExitUnavailable, exitCode(codes.DeadlineExceeded))
+	assert.Equal(t, ExitRejected, exitCode(codes.PermissionDenied))
+	assert.Equal(t, ExitRejected, exitCode(codes.InvalidArgument))
+	assert.Equal(t, ExitFailed, exitCode(codes.Aborted))
+	assert.Equal(t, ExitFailed, exitCode(codes.Unknown))
+}
+
+func readJSON(content string, message proto.Message) error {
+	return protojson.Unmarshal([]byte(content), message)
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package ntpctl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	v1 "ntpservice/api/siemens_iedge_dmapi_v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// show prints the message as indented JSON with -o json, otherwise with the table printer.
+// The JSON can be passed to the set commands.
+func show[M proto.Message](c *cli, message M, table func(w io.Writer, message M)) error {
+	if c.output == FormatJSON {
+		content, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(message)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(c.stdout, string(content))
+		return nil
+	}
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	table(w, message)
+	return w.Flush()
+}
+
+func printNtp(w io.Writer, ntp *v1.Ntp) {
+	fmt.Fprintln(w, "KIND\tADDRESS\tOPTIONS")
+	for _, address := range ntp.GetNtpServer() {
+		fmt.Fprintf(w, "server\t%s\t\n", address)
+	}
+	for _, entry := range ntp.GetNtpServerEntries() {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", strings.ToLower(entry.GetKind().String()), entry.GetAddress(), entryOptions(entry))
+	}
+	for _, address := range ntp.GetFallbackNtpServer() {
+		fmt.Fprintf(w, "fallback\t%s\t\n", address)
+	}
+}
+
+// entryOptions formats the options of a server entry like the ntp.conf line.
+func entryOptions(entry *v1.NtpServerEntry) string {
+	var options []string
+	flags := []struct {
+		name string
+		set  bool
+	}{
+		{"iburst", entry.GetIburst()}, {"burst", entry.GetBurst()}, {"prefer", entry.GetPrefer()},
+		{"noselect", entry.GetNoselect()}, {"preempt", entry.GetPreempt()}, {"nts", entry.GetNts()},
+	}
+	for _, flag := range flags {
+		if flag.set {
+			options = append(options, flag.name)
+		}
+	}
+	numbers := []struct {
+		name  string
+		value int64
+	}{
+		{"minpoll", int64(entry.GetMinpoll())}, {"maxpoll", int64(entry.GetMaxpoll())},
+		{"key", int64(entry.GetKeyId())}, {"version", int64(entry.GetVersion())},
+	}
+	for _, number := range numbers {
+		if number.value != 0 {
+			options = append(options, number.name+" "+strconv.FormatInt(number.value, 10))
+		}
+	}
+	return strings.Join(options, " ")
+}
+
+func printStatus(w io.Writer, current *v1.Status) {
+	fmt.Fprintf(w, "Running:\t%s\n", yesNo(current.GetIsNtpServiceRunning()))
+	fmt.Fprintf(w, "Synchronized:\t%s\n", yesNo(current.GetIsSynced()))
+	fmt.Fprintf(w, "Orphan:\t%s\n", yesNo(current.GetOrphan()))
+	fmt.Fprintf(w, "Last configuration:\t%s\n", current.GetLastConfigurationTime())
+	fmt.Fprintf(w, "Last sync:\t%s\n", current.GetLastSyncTime())
+	if system := current.GetSystemVariables(); system != nil {
+		fmt.Fprintf(w, "Offset:\t%.3f ms\n", system.GetOffset())
+		fmt.Fprintf(w, "Frequency:\t%.3f ppm\n", system.GetFrequency())
+		fmt.Fprintf(w, "Stratum:\t%d\n", system.GetStratum())
+		fmt.Fprintf(w, "Reference:\t%s\n", system.GetRefid())
+	}
+	if leap := current.GetLeap(); leap != nil && leap.GetFile() != "" {
+		fmt.Fprintf(w, "Leap seconds file:\t%s, expires %s\n", leap.GetFile(), leap.GetExpires())
+		if leap.GetError() != "" {
+			fmt.Fprintf(w, "Leap seconds error:\t%s\n", leap.GetError())
+		}
+	}
+	for _, nts := range current.GetNts() {
+		result := "ok"
+		if !nts.GetKeSucceeded() {
+			result = nts.GetError()
+		}
+		fmt.Fprintf(w, "NTS %s:\t%s\n", nts.GetServer(), result)
+	}
+	if len(current.GetPeerDetails()) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "REMOTE\tREFID\tST\tT\tWHEN\tPOLL\tREACH\tDELAY\tOFFSET\tJITTER\tSELECTION")
+	for _, peer := range current.GetPeerDetails() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t%s\t%.3f\t%.3f\t%.3f\t%s\n", peer.GetRemoteServer(), peer.GetReferenceID(),
+			peer.GetStratum(), peer.GetType(), peer.GetWhen(), peer.GetPoll(), peer.GetReach(), peer.GetDelay(),
+			peer.GetOffset(), peer.GetJitter(), peer.GetSelection())
+	}
+}
+
+func printQueryResult(w io.Writer, result *v1.QueryServerResult) {
+	fmt.Fprintf(w, "Server:\t%s\n\n", result.GetAddress())
+	fmt.Fprintln(w, "SAMPLE\tOFFSET\tDELAY\tSTRATUM\tREFID\tLEAP\tERROR")
+	for i, sample := range result.GetSamples() {
+		fmt.Fprintf(w, "%d\t%.3f\t%.3f\t%d\t%s\t%d\t%s\n", i+1, sample.GetOffset(), sample.GetDelay(), sample.GetStratum(),
+			sample.GetReferenceID(), sample.GetLeapIndicator(), sample.GetError())
+	}
+}
+
+func printKeys(w io.Writer, keys *v1.SymmetricKeys) {
+	fmt.Fprintln(w, "ID\tTYPE")
+	for _, key := range keys.GetKeys() {
+		fmt.Fprintf(w, "%d\t%s\n", key.GetKeyId(), key.GetType())
+	}
+}
+
+func printServing(w io.Writer, serving *v1.ServingConfig) {
+	fmt.Fprintf(w, "Serving:\t%s\n", yesNo(serving.GetEnabled()))
+	if len(serving.GetRules()) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "SUBNET\tFLAGS")
+	for _, rule := range serving.GetRules() {
+		var flags []string
+		for _, flag := range rule.GetFlags() {
+			flags = append(flags, strings.ToLower(flag.String()))
+		}
+		fmt.Fprintf(w, "%s\t%s\n", rule.GetCidr(), strings.Join(flags, " "))
+	}
+}
+
+func printOrphan(w io.Writer, orphan *v1.OrphanConfig) {
+	if orphan.GetStratum() == 0 {
+		fmt.Fprintln(w, "Orphan mode:\tdisabled")
+	} else {
+		fmt.Fprintf(w, "Orphan stratum:\t%d\n", orphan.GetStratum())
+		fmt.Fprintf(w, "Orphan wait:\t%d s\n", orphan.GetOrphanWait())
+	}
+	if len(orphan.GetPeers()) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "PEER\tOPTIONS")
+	for _, peer := range orphan.GetPeers() {
+		fmt.Fprintf(w, "%s\t%s\n", peer.GetAddress(), entryOptions(peer))
+	}
+}
+
+func printRefclocks(w io.Writer, refclocks *v1.Refclocks) {
+	fmt.Fprintln(w, "DRIVER\tUNIT\tREFID\tPREFER\tNOSELECT\tSTRATUM\tPATH")
+	for _, refclock := range refclocks.GetRefclocks() {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%d\t%s\n", strings.ToLower(refclock.GetDriver().String()), refclock.GetUnit(),
+			refclock.GetRefid(), yesNo(refclock.GetPrefer()), yesNo(refclock.GetNoselect()), refclock.GetStratum(), refclock.GetPath())
+	}
+}
+
+func printHistory(w io.Writer, history *v1.ConfigHistory) {
+	fmt.Fprintln(w, "ID\tTIME\tAPPLIED\tRESTORED FROM\tSERVERS\tRESULT")
+	for _, revision := range history.GetRevisions() {
+		restoredFrom := ""
+		if revision.GetRestoredFrom() != 0 {
+			restoredFrom = strconv.FormatUint(revision.GetRestoredFrom(), 10)
+		}
+		result := revision.GetResult()
+		if revision.GetFailedStep() != "" {
+			result = revision.GetFailedStep() + ": " + result
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", revision.GetId(), revision.GetTime(), yesNo(revision.GetApplied()),
+			restoredFrom, strings.Join(revision.GetServers(), ", "), result)
+	}
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
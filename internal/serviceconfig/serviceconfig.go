@@ -0,0 +1,374 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+// Package serviceconfig loads the configuration of the service itself: the paths of its files, the owner of the
+// unix socket, the settings of the time daemon and of the metrics, TLS and health endpoints. Every value has a default and can be overridden by the
+// configuration file and, for container deployments, by an environment variable.
+package serviceconfig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Path is the default configuration file, without it the defaults and the environment variables are used.
+const Path = "/etc/iedk/ntp/service.yaml"
+
+// PathEnv is the environment variable with the path of the configuration file.
+const PathEnv = "NTPSERVICE_CONFIG"
+
+// Version is the supported version of the configuration file.
+const Version = 1
+
+// Defaults of the configuration.
+const (
+	DefaultLastConfigPath       = "/etc/iedk/lastntpconfigdate.rec"
+	DefaultLegacyLastConfigPath = "/opt/lastntpconfigdate.rec"
+	DefaultClassicNtpConfPath   = "/etc/ntp.conf"
+	DefaultNtpSecConfPath       = "/etc/ntpsec/ntp.conf"
+	DefaultNtpSecMigrationPath  = "/etc/iedk/ntp/migration/ntpsec.migration"
+	DefaultNtpKeysPath          = "/etc/ntpsec/ntp.keys"
+	DefaultNtsCaBundlePath      = "/etc/ntpsec/nts-ca.pem"
+	DefaultLeapSecondsPath      = "/etc/ntpsec/leap-seconds.list"
+	DefaultHistoryPath          = "/etc/iedk/ntp/history"
+	DefaultAccessPolicyPath     = "/etc/iedk/ntp/access.yaml"
+	DefaultSocketOwner          = "root"
+	DefaultSocketGroup          = "docker"
+	DefaultSocketMode           = FileMode(0660)
+	DefaultStepTimeout          = 20 * time.Second
+	// DefaultHealthMaxOffsetMs is the step threshold of ntpd, larger offsets are stepped instead of slewed.
+	DefaultHealthMaxOffsetMs = 128
+)
+
+// Environment variables overriding the configuration file.
+const (
+	NtpConfEnv          = "NTPSERVICE_NTP_CONF"
+	LastConfigEnv       = "NTPSERVICE_LAST_CONFIG"
+	LegacyLastConfigEnv = "NTPSERVICE_LEGACY_LAST_CONFIG"
+	ClassicNtpConfEnv   = "NTPSERVICE_CLASSIC_NTP_CONF"
+	NtpSecMigrationEnv  = "NTPSERVICE_NTPSEC_MIGRATION"
+	NtpKeysEnv          = "NTPSERVICE_NTP_KEYS"
+	NtsCaBundleEnv      = "NTPSERVICE_NTS_CA_BUNDLE"
+	LeapSecondsEnv      = "NTPSERVICE_LEAP_SECONDS"
+	HistoryEnv          = "NTPSERVICE_HISTORY"
+	AccessPolicyEnv     = "NTPSERVICE_ACCESS_POLICY"
+	SocketOwnerEnv      = "NTPSERVICE_SOCKET_OWNER"
+	SocketGroupEnv      = "NTPSERVICE_SOCKET_GROUP"
+	SocketModeEnv       = "NTPSERVICE_SOCKET_MODE"
+	DaemonUnitEnv       = "NTPSERVICE_DAEMON_UNIT"
+	StepTimeoutEnv      = "NTPSERVICE_STEP_TIMEOUT"
+	BackendEnv          = "NTPSERVICE_BACKEND"
+	MetricsAddressEnv   = "NTPSERVICE_METRICS_ADDRESS"
+	TLSCertEnv          = "NTPSERVICE_TLS_CERT"
+	TLSKeyEnv           = "NTPSERVICE_TLS_KEY"
+	TLSClientCAEnv      = "NTPSERVICE_TLS_CLIENT_CA"
+	RequireTLSEnv       = "NTPSERVICE_REQUIRE_TLS"
+	HealthMaxOffsetEnv  = "NTPSERVICE_HEALTH_MAX_OFFSET_MS"
+)
+
+// backends are the values of daemon.backend, empty and auto detect the installed time daemon.
+var backends = []string{"", "auto", "ntpsec", "chrony", "timesyncd"}
+
+// ErrInvalidConfig is returned when the configuration file or an environment variable has an invalid value.
+var ErrInvalidConfig = errors.New("invalid service configuration")
+
+// Config is the configuration of the service.
+//
+//	version: 1
+//	paths:
+//	  ntpConf: /data/ntpsec/ntp.conf
+//	socket:
+//	  group: ntpclients
+//	  mode: "0640"
+//	daemon:
+//	  stepTimeout: 30s
+//	metrics:
+//	  address: ":9559"
+type Config struct {
+	Version int     `yaml:"version"`
+	Paths   Paths   `yaml:"paths"`
+	Socket  Socket  `yaml:"socket"`
+	Daemon  Daemon  `yaml:"daemon"`
+	Metrics Metrics `yaml:"metrics"`
+	TLS     TLS     `yaml:"tls"`
+	Health  Health  `yaml:"health"`
+}
+
+// Paths are the files read and written by the service and its migrations.
+type Paths struct {
+	NtpConf          string `yaml:"ntpConf"`          // configuration file of the time daemon, empty for the one of the backend
+	LastConfig       string `yaml:"lastConfig"`       // time of the last SetNtpServer
+	LegacyLastConfig string `yaml:"legacyLastConfig"` // former location of lastConfig, moved by the migration
+	ClassicNtpConf   string `yaml:"classicNtpConf"`   // ntp.conf of ntp classic, migrated to ntpsec
+	NtpSecMigration  string `yaml:"ntpSecMigration"`  // marker of the ntp classic to ntpsec migration
+	NtpKeys          string `yaml:"ntpKeys"`          // symmetric keys of ntpsec
+	NtsCaBundle      string `yaml:"ntsCaBundle"`      // CA bundle of the NTS key establishment of ntpsec
+	LeapSeconds      string `yaml:"leapSeconds"`      // leap seconds file of ntpsec
+	History          string `yaml:"history"`          // directory of the configuration history
+	AccessPolicy     string `yaml:"accessPolicy"`     // access policy of the unix socket, every client may call every RPC without it
+}
+
+// Socket is the owner and the permissions of the unix socket.
+type Socket struct {
+	Owner string   `yaml:"owner"`
+	Group string   `yaml:"group"`
+	Mode  FileMode `yaml:"mode"`
+}
+
+// Daemon are the settings of the time daemon.
+type Daemon struct {
+	Backend     string        `yaml:"backend"`     // ntpsec, chrony or timesyncd, empty or auto detects the installed daemon
+	Unit        string        `yaml:"unit"`        // service of the daemon, empty for the one of the backend
+	StepTimeout time.Duration `yaml:"stepTimeout"` // timeout of `ntpd -gq` and `chronyd -q`
+}
+
+// Metrics is the listener of the Prometheus metrics.
+type Metrics struct {
+	Address string `yaml:"address"` // TCP address, e.g. ":9559", the metrics are not served without it
+}
+
+// TLS is the TLS configuration of the TCP listener of the gRPC server.
+type TLS struct {
+	Cert     string `yaml:"cert"`     // PEM file of the server certificate, TLS is enabled when it is set
+	Key      string `yaml:"key"`      // PEM file of the private key of the server certificate
+	ClientCA string `yaml:"clientCA"` // PEM file of the CAs of the client certificates, clients without one are refused
+	Require  bool   `yaml:"require"`  // refuse a TCP listener on a non-loopback address without TLS
+}
+
+// Health are the settings of the grpc.health.v1.Health service.
+type Health struct {
+	MaxOffsetMs float64 `yaml:"maxOffsetMs"` // offset of the system clock up to which the service is SERVING
+}
+
+// FileMode is a permission mode written in octal, e.g. "0660".
+type FileMode os.FileMode
+
+// UnmarshalYAML reads the mode as octal number, quoted or not.
+func (m *FileMode) UnmarshalYAML(node *yaml.Node) error {
+	mode, err := ParseFileMode(node.Value)
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
+
+// ParseFileMode parses an octal permission mode like 0660 or 0o660.
+func ParseFileMode(value string) (FileMode, error) {
+	mode, err := strconv.ParseUint(strings.TrimPrefix(value, "0o"), 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("mode %q is no octal permission between 0000 and 0777", value)
+	}
+	return FileMode(mode), nil
+}
+
+func (m FileMode) String() string {
+	return fmt.Sprintf("%04o", uint32(m))
+}
+
+// Default returns the configuration without file and environment variables.
+func Default() *Config {
+	return &Config{
+		Version: Version,
+		Paths: Paths{
+			LastConfig:       DefaultLastConfigPath,
+			LegacyLastConfig: DefaultLegacyLastConfigPath,
+			ClassicNtpConf:   DefaultClassicNtpConfPath,
+			NtpSecMigration:  DefaultNtpSecMigrationPath,
+			NtpKeys:          DefaultNtpKeysPath,
+			NtsCaBundle:      DefaultNtsCaBundlePath,
+			LeapSeconds:      DefaultLeapSecondsPath,
+			History:          DefaultHistoryPath,
+			AccessPolicy:     DefaultAccessPolicyPath,
+		},
+		Socket: Socket{Owner: DefaultSocketOwner, Group: DefaultSocketGroup, Mode: DefaultSocketMode},
+		Daemon: Daemon{StepTimeout: DefaultStepTimeout},
+		Health: Health{MaxOffsetMs: DefaultHealthMaxOffsetMs},
+	}
+}
+
+// FromEnv loads the configuration file given by the NTPSERVICE_CONFIG environment variable, Path without it.
+func FromEnv() (*Config, error) {
+	path := os.Getenv(PathEnv)
+	if path == "" {
+		path = Path
+	}
+	return Load(path)
+}
+
+// Load reads the configuration file over the defaults, applies the environment variables and validates the result.
+// A missing file is not an error, the defaults are used.
+func Load(path string) (*Config, error) {
+	config := Default()
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := config.parse(content); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if err := config.applyEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// parse decodes the file over the configuration, unknown keys are rejected.
+func (c *Config) parse(content []byte) error {
+	c.Version = 0
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if c.Version != Version {
+		return fmt.Errorf("%w: unsupported version %d, expected %d", ErrInvalidConfig, c.Version, Version)
+	}
+	return nil
+}
+
+// applyEnv overrides the values whose environment variable is set.
+func (c *Config) applyEnv(lookup func(string) (string, bool)) error {
+	overrides := map[string]*string{
+		NtpConfEnv:          &c.Paths.NtpConf,
+		LastConfigEnv:       &c.Paths.LastConfig,
+		LegacyLastConfigEnv: &c.Paths.LegacyLastConfig,
+		ClassicNtpConfEnv:   &c.Paths.ClassicNtpConf,
+		NtpSecMigrationEnv:  &c.Paths.NtpSecMigration,
+		NtpKeysEnv:          &c.Paths.NtpKeys,
+		NtsCaBundleEnv:      &c.Paths.NtsCaBundle,
+		LeapSecondsEnv:      &c.Paths.LeapSeconds,
+		HistoryEnv:          &c.Paths.History,
+		AccessPolicyEnv:     &c.Paths.AccessPolicy,
+		SocketOwnerEnv:      &c.Socket.Owner,
+		SocketGroupEnv:      &c.Socket.Group,
+		DaemonUnitEnv:       &c.Daemon.Unit,
+		BackendEnv:          &c.Daemon.Backend,
+		MetricsAddressEnv:   &c.Metrics.Address,
+		TLSCertEnv:          &c.TLS.Cert,
+		TLSKeyEnv:           &c.TLS.Key,
+		TLSClientCAEnv:      &c.TLS.ClientCA,
+	}
+	for name, field := range overrides {
+		if value, ok := lookup(name); ok {
+			*field = value
+		}
+	}
+	if value, ok := lookup(SocketModeEnv); ok {
+		mode, err := ParseFileMode(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, SocketModeEnv, err)
+		}
+		c.Socket.Mode = mode
+	}
+	if value, ok := lookup(StepTimeoutEnv); ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, StepTimeoutEnv, err)
+		}
+		c.Daemon.StepTimeout = timeout
+	}
+	if value, ok := lookup(RequireTLSEnv); ok {
+		require, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, RequireTLSEnv, err)
+		}
+		c.TLS.Require = require
+	}
+	if value, ok := lookup(HealthMaxOffsetEnv); ok {
+		maxOffsetMs, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, HealthMaxOffsetEnv, err)
+		}
+		c.Health.MaxOffsetMs = maxOffsetMs
+	}
+	return nil
+}
+
+// Validate checks that the paths are absolute, the socket has an owner and the daemon, metrics, TLS and health
+// settings are usable.
+func (c *Config) Validate() error {
+	paths := []struct {
+		name     string
+		value    string
+		optional bool
+	}{
+		{"paths.ntpConf", c.Paths.NtpConf, true},
+		{"paths.lastConfig", c.Paths.LastConfig, false},
+		{"paths.legacyLastConfig", c.Paths.LegacyLastConfig, false},
+		{"paths.classicNtpConf", c.Paths.ClassicNtpConf, false},
+		{"paths.ntpSecMigration", c.Paths.NtpSecMigration, false},
+		{"paths.ntpKeys", c.Paths.NtpKeys, false},
+		{"paths.ntsCaBundle", c.Paths.NtsCaBundle, false},
+		{"paths.leapSeconds", c.Paths.LeapSeconds, false},
+		{"paths.history", c.Paths.History, false},
+		{"paths.accessPolicy", c.Paths.AccessPolicy, false},
+		{"tls.cert", c.TLS.Cert, true},
+		{"tls.key", c.TLS.Key, true},
+		{"tls.clientCA", c.TLS.ClientCA, true},
+	}
+	for _, path := range paths {
+		if path.value == "" && path.optional {
+			continue
+		}
+		if !filepath.IsAbs(path.value) {
+			return fmt.Errorf("%w: %s %q is no absolute path", ErrInvalidConfig, path.name, path.value)
+		}
+	}
+	if c.Socket.Owner == "" || c.Socket.Group == "" {
+		return fmt.Errorf("%w: socket.owner and socket.group must not be empty", ErrInvalidConfig)
+	}
+	if c.Socket.Mode > 0777 {
+		return fmt.Errorf("%w: socket.mode %s is no permission between 0000 and 0777", ErrInvalidConfig, c.Socket.Mode)
+	}
+	if strings.ContainsAny(c.Daemon.Unit, "/ \t") || strings.HasSuffix(c.Daemon.Unit, ".service") {
+		return fmt.Errorf("%w: daemon.unit %q must be a service name without path and .service suffix", ErrInvalidConfig, c.Daemon.Unit)
+	}
+	if c.Daemon.StepTimeout <= 0 {
+		return fmt.Errorf("%w: daemon.stepTimeout %s must be positive", ErrInvalidConfig, c.Daemon.StepTimeout)
+	}
+	if !slices.Contains(backends, c.Daemon.Backend) {
+		return fmt.Errorf("%w: daemon.backend %q is none of ntpsec, chrony, timesyncd and auto", ErrInvalidConfig, c.Daemon.Backend)
+	}
+	if c.Metrics.Address != "" {
+		if _, _, err := net.SplitHostPort(c.Metrics.Address); err != nil {
+			return fmt.Errorf("%w: metrics.address %q: %v", ErrInvalidConfig, c.Metrics.Address, err)
+		}
+	}
+	if (c.TLS.Cert == "") != (c.TLS.Key == "") {
+		return fmt.Errorf("%w: tls.cert and tls.key must be set together", ErrInvalidConfig)
+	}
+	if c.TLS.ClientCA != "" && c.TLS.Cert == "" {
+		return fmt.Errorf("%w: tls.clientCA requires tls.cert and tls.key", ErrInvalidConfig)
+	}
+	if c.Health.MaxOffsetMs <= 0 {
+		return fmt.Errorf("%w: health.maxOffsetMs %v must be positive", ErrInvalidConfig, c.Health.MaxOffsetMs)
+	}
+	return nil
+}
+
+// NtpSecConfPath returns the ntp.conf of ntpsec, the configured one or the default.
+func (c *Config) NtpSecConfPath() string {
+	if c.Paths.NtpConf != "" {
+		return c.Paths.NtpConf
+	}
+	return DefaultNtpSecConfPath
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package serviceconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tConfig = `version: 1
+paths:
+  ntpConf: /data/ntpsec/ntp.conf
+  lastConfig: /data/lastntpconfigdate.rec
+  history: /data/history
+socket:
+  group: ntpclients
+  mode: 0640
+daemon:
+  backend: ntpsec
+  unit: ntpsec-plant
+  stepTimeout: 45s
+metrics:
+  address: ":9559"
+tls:
+  cert: /data/tls/server.pem
+  key: /data/tls/server.key
+health:
+  maxOffsetMs: 50
+`
+
+func tWrite(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "service.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func Test_Load(t *testing.T) {
+	config, err := Load(tWrite(t, tConfig))
+
+	assert.NoError(t, err)
+	expected := Default()
+	expected.Paths.NtpConf = "/data/ntpsec/ntp.conf"
+	expected.Paths.LastConfig = "/data/lastntpconfigdate.rec"
+	expected.Paths.History = "/data/history"
+	expected.Socket.Group = "ntpclients"
+	expected.Socket.Mode = 0640
+	expected.Daemon = Daemon{Backend: "ntpsec", Unit: "ntpsec-plant", StepTimeout: 45 * time.Second}
+	expected.Metrics = Metrics{Address: ":9559"}
+	expected.TLS = TLS{Cert: "/data/tls/server.pem", Key: "/data/tls/server.key"}
+	expected.Health = Health{MaxOffsetMs: 50}
+	assert.Equal(t, expected, config)
+	assert.Equal(t, "/data/ntpsec/ntp.conf", config.NtpSecConfPath())
+}
+
+func Test_Load_Missing(t *testing.T) {
+	config, err := Load(filepath.Join(t.TempDir(), "service.yaml"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, Default(), config, "Did not get expected result. Without file the defaults must be used")
+	assert.Equal(t, DefaultNtpSecConfPath, config.NtpSecConfPath())
+}
+
+func Test_Load_Env(t *testing.T) {
+	t.Setenv(SocketOwnerEnv, "ntp")
+	t.Setenv(SocketModeEnv, "0o600")
+	t.Setenv(StepTimeoutEnv, "1m")
+	t.Setenv(NtpSecMigrationEnv, "/data/ntpsec.migration")
+	t.Setenv(NtpKeysEnv, "/data/ntp.keys")
+	t.Setenv(AccessPolicyEnv, "/data/access.yaml")
+	t.Setenv(BackendEnv, "chrony")
+	t.Setenv(MetricsAddressEnv, "127.0.0.1:9559")
+	t.Setenv(TLSClientCAEnv, "/data/tls/client-ca.pem")
+	t.Setenv(RequireTLSEnv, "true")
+	t.Setenv(HealthMaxOffsetEnv, "2.5")
+
+	config, err := Load(tWrite(t, tConfig))
+
+	assert.NoError(t, err)
+	assert.Equal(t, Socket{Owner: "ntp", Group: "ntpclients", Mode: 0600}, config.Socket)
+	assert.Equal(t, time.Minute, config.Daemon.StepTimeout)
+	assert.Equal(t, "/data/ntpsec.migration", config.Paths.NtpSecMigration)
+	assert.Equal(t, "/data/ntp.keys", config.Paths.NtpKeys)
+	assert.Equal(t, "/data/access.yaml", config.Paths.AccessPolicy)
+	assert.Equal(t, "chrony", config.Daemon.Backend)
+	assert.Equal(t, Metrics{Address: "127.0.0.1:9559"}, config.Metrics)
+	assert.Equal(t, TLS{Cert: "/data/tls/server.pem", Key: "/data/tls/server.key", ClientCA: "/data/tls/client-ca.pem", Require: true}, config.TLS)
+	assert.Equal(t, 2.5, config.Health.MaxOffsetMs)
+}
+
+func Test_Load_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"version":      "version: 2\n",
+		"no version":   "socket:\n  group: ntpclients\n",
+		"unknown key":  "version: 1\nsocket:\n  permissions: 0640\n",
+		"mode":         "version: 1\nsocket:\n  mode: 0999\n",
+		"relative":     "version: 1\npaths:\n  lastConfig: lastntpconfigdate.rec\n",
+		"empty group":  "version: 1\nsocket:\n  group: \"\"\n",
+		"unit":         "version: 1\ndaemon:\n  unit: ntpsec.service\n",
+		"step timeout": "version: 1\ndaemon:\n  stepTimeout: 0s\n",
+		"duration":     "version: 1\ndaemon:\n  stepTimeout: 20\n",
+		"backend":      "version: 1\ndaemon:\n  backend: openntpd\n",
+		"history":      "version: 1\npaths:\n  history: history\n",
+		"metrics":      "version: 1\nmetrics:\n  address: 9559\n",
+		"tls key":      "version: 1\ntls:\n  cert: /data/tls/server.pem\n",
+		"tls ca":       "version: 1\ntls:\n  clientCA: /data/tls/client-ca.pem\n",
+		"tls relative": "version: 1\ntls:\n  cert: server.pem\n  key: server.key\n",
+		"max offset":   "version: 1\nhealth:\n  maxOffsetMs: -1\n",
+	} {
+		_, err := Load(tWrite(t, content))
+		assert.ErrorIs(t, err, ErrInvalidConfig, "Did not get expected result. The %s configuration must be refused", name)
+	}
+}
+
+func Test_Load_InvalidEnv(t *testing.T) {
+	for name, value := range map[string]string{StepTimeoutEnv: "soon", RequireTLSEnv: "yes please", HealthMaxOffsetEnv: "fast"} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(name, value)
+
+			_, err := Load(tWrite(t, tConfig))
+
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+			assert.ErrorContains(t, err, name)
+		})
+	}
+}
+
+func Test_FromEnv(t *testing.T) {
+	t.Setenv(PathEnv, tWrite(t, tConfig))
+
+	config, err := FromEnv()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ntpclients", config.Socket.Group)
+}
+
+func Test_ParseFileMode(t *testing.T) {
+	mode, err := ParseFileMode("0660")
+	assert.NoError(t, err)
+	assert.Equal(t, FileMode(0660), mode)
+	assert.Equal(t, "0660", mode.String())
+
+	_, err = ParseFileMode("1777")
+	assert.Error(t, err)
+	_, err = ParseFileMode("rw-rw----")
+	assert.Error(t, err)
+}
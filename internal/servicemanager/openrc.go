@@ -0,0 +1,57 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package servicemanager
+
+import (
+	"context"
+
+	"ntpservice/utils/executor"
+)
+
+const rcService = "/sbin/rc-service"
+
+// openRCServices maps the service names which differ in the OpenRC packages, e.g. of Alpine.
+var openRCServices = map[string]string{
+	"chrony": "chronyd",
+}
+
+// OpenRC controls the services with rc-service.
+type OpenRC struct {
+	Exec executor.Executor
+}
+
+func (o *OpenRC) Name() string {
+	return OpenRCName
+}
+
+func (o *OpenRC) Start(ctx context.Context, service string) error {
+	return run(ctx, o.Exec, OpenRCCommand(ActionStart, service))
+}
+
+func (o *OpenRC) Stop(ctx context.Context, service string) error {
+	return run(ctx, o.Exec, OpenRCCommand(ActionStop, service))
+}
+
+func (o *OpenRC) Restart(ctx context.Context, service string) error {
+	return run(ctx, o.Exec, OpenRCCommand(ActionRestart, service))
+}
+
+func (o *OpenRC) Active(ctx context.Context, service string) bool {
+	return active(ctx, o.Exec, OpenRCCommand(ActionStatus, service))
+}
+
+// OpenRCCommand returns the rc-service command of the action.
+func OpenRCCommand(action string, service string) executor.Command {
+	if name, ok := openRCServices[service]; ok {
+		service = name
+	}
+	timeout := actionTimeout
+	if action == ActionStatus {
+		timeout = statusTimeout
+	}
+	return executor.NewCommand(timeout, rcService, "--quiet", service, action)
+}
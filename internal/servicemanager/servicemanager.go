@@ -0,0 +1,131 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+// Package servicemanager starts, stops and checks the services of the time daemons with the init system of the device.
+package servicemanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"ntpservice/utils/executor"
+)
+
+// ServiceManager controls services by their name, e.g. ntpsec.
+type ServiceManager interface {
+	// Name returns the name of the init system, e.g. systemd.
+	Name() string
+	Start(ctx context.Context, service string) error
+	Stop(ctx context.Context, service string) error
+	Restart(ctx context.Context, service string) error
+	// Active reports whether the service is running, false if the init system does not answer.
+	Active(ctx context.Context, service string) bool
+}
+
+// Actions on a service, they are the arguments of systemctl, the init scripts and rc-service.
+const (
+	ActionStart   = "start"
+	ActionStop    = "stop"
+	ActionRestart = "restart"
+	ActionStatus  = "status"
+)
+
+// Names of the init systems accepted by New.
+const (
+	SystemdName  = "systemd"
+	SysvinitName = "sysvinit"
+	OpenRCName   = "openrc"
+	AutoName     = "auto"
+)
+
+// Env is the environment variable selecting the init system, without it the init system is detected.
+const Env = "NTPSERVICE_INIT"
+
+// Default timeouts of the commands, an earlier deadline of the request is kept.
+const (
+	actionTimeout = 30 * time.Second
+	statusTimeout = 5 * time.Second
+)
+
+// Paths which exist only when the device was booted by the init system.
+const (
+	systemdRuntimeDir = "/run/systemd/system"
+	openRCRuntimeDir  = "/run/openrc"
+	sysvinitScriptDir = "/etc/init.d"
+)
+
+// ErrUnknownInitSystem is returned by New for a name which is no init system.
+var ErrUnknownInitSystem = errors.New("unknown init system")
+
+// New returns the service manager with the name, the init system is detected for an empty name or auto.
+func New(name string, ex executor.Executor) (ServiceManager, error) {
+	switch name {
+	case SystemdName:
+		return &Systemd{Exec: ex}, nil
+	case SysvinitName:
+		return &Sysvinit{Exec: ex}, nil
+	case OpenRCName:
+		return &OpenRC{Exec: ex}, nil
+	case "", AutoName:
+		return detect(ex, systemdRuntimeDir, openRCRuntimeDir, sysvinitScriptDir), nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownInitSystem, name)
+}
+
+// FromEnv returns the service manager selected with the NTPSERVICE_INIT environment variable, without it or for an
+// unknown name the init system is detected.
+func FromEnv(ex executor.Executor) ServiceManager {
+	manager, err := New(os.Getenv(Env), ex)
+	if err != nil {
+		log.Println("Detecting the init system:", err.Error())
+		manager = detect(ex, systemdRuntimeDir, openRCRuntimeDir, sysvinitScriptDir)
+	}
+	log.Println("Init system:", manager.Name())
+	return manager
+}
+
+// detect selects systemd if it booted the device, otherwise OpenRC if it booted the device and sysvinit if there are
+// init scripts, systemd if nothing is found.
+func detect(ex executor.Executor, systemdDir string, openRCDir string, scriptDir string) ServiceManager {
+	if _, err := os.Stat(systemdDir); err == nil {
+		return &Systemd{Exec: ex}
+	}
+	if _, err := os.Stat(openRCDir); err == nil {
+		return &OpenRC{Exec: ex}
+	}
+	if _, err := os.Stat(scriptDir); err == nil {
+		return &Sysvinit{Exec: ex}
+	}
+	return &Systemd{Exec: ex}
+}
+
+// run runs the command of an action and logs a failure.
+func run(ctx context.Context, ex executor.Executor, command executor.Command) error {
+	if _, err := ex.Run(ctx, command); err != nil {
+		log.Println("Command(): Error command failed!", command, err)
+		return err
+	}
+	return nil
+}
+
+// active runs the status command, the service is running if it succeeds. systemctl is-active, LSB init scripts and
+// rc-service exit with a non-zero code for a stopped service.
+func active(ctx context.Context, ex executor.Executor, command executor.Command) bool {
+	if _, err := ex.Run(ctx, command); err != nil {
+		var commandErr *executor.Error
+		if errors.As(err, &commandErr) && commandErr.ExitCode >= 0 {
+			log.Println(command, "exit code is", commandErr.ExitCode)
+		} else {
+			log.Println("Command(): Error command failed!", command, err)
+		}
+		return false
+	}
+	return true
+}
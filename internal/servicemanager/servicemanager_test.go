@@ -0,0 +1,119 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package servicemanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ntpservice/utils/executor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tExecutor records the commands and fails the commands in failing with their exit code.
+type tExecutor struct {
+	failing  map[string]int
+	commands []string
+}
+
+func (o *tExecutor) Run(ctx context.Context, command executor.Command) ([]byte, error) {
+	o.commands = append(o.commands, command.String())
+	if code, ok := o.failing[command.String()]; ok {
+		return nil, &executor.Error{Command: command, ExitCode: code, Err: errors.New("exit status")}
+	}
+	return []byte{}, nil
+}
+
+func Test_New(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{SystemdName, SystemdName},
+		{SysvinitName, SysvinitName},
+		{OpenRCName, OpenRCName},
+	}
+	for _, tt := range tests {
+		manager, err := New(tt.name, &tExecutor{})
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, manager.Name())
+	}
+
+	_, err := New("upstart", &tExecutor{})
+	assert.ErrorIs(t, err, ErrUnknownInitSystem)
+}
+
+func Test_detect(t *testing.T) {
+	dir := t.TempDir()
+	systemdDir := filepath.Join(dir, "systemd")
+	openRCDir := filepath.Join(dir, "openrc")
+	scriptDir := filepath.Join(dir, "init.d")
+
+	assert.Equal(t, SystemdName, detect(&tExecutor{}, systemdDir, openRCDir, scriptDir).Name())
+
+	assert.NoError(t, os.Mkdir(scriptDir, 0755))
+	assert.Equal(t, SysvinitName, detect(&tExecutor{}, systemdDir, openRCDir, scriptDir).Name())
+
+	assert.NoError(t, os.Mkdir(openRCDir, 0755))
+	assert.Equal(t, OpenRCName, detect(&tExecutor{}, systemdDir, openRCDir, scriptDir).Name())
+
+	assert.NoError(t, os.Mkdir(systemdDir, 0755))
+	assert.Equal(t, SystemdName, detect(&tExecutor{}, systemdDir, openRCDir, scriptDir).Name())
+}
+
+func Test_Commands(t *testing.T) {
+	ctx := context.Background()
+	ex := &tExecutor{}
+
+	for _, manager := range []ServiceManager{&Systemd{Exec: ex}, &Sysvinit{Exec: ex}, &OpenRC{Exec: ex}} {
+		assert.NoError(t, manager.Stop(ctx, "chrony"))
+		assert.NoError(t, manager.Start(ctx, "chrony"))
+		assert.NoError(t, manager.Restart(ctx, "chrony"))
+		assert.True(t, manager.Active(ctx, "chrony"))
+	}
+
+	assert.Equal(t, []string{
+		"/usr/bin/systemctl stop chrony.service",
+		"/usr/bin/systemctl start chrony.service",
+		"/usr/bin/systemctl restart chrony.service",
+		"/usr/bin/systemctl is-active --quiet chrony",
+		"/etc/init.d/chrony stop",
+		"/etc/init.d/chrony start",
+		"/etc/init.d/chrony restart",
+		"/etc/init.d/chrony status",
+		"/sbin/rc-service --quiet chronyd stop",
+		"/sbin/rc-service --quiet chronyd start",
+		"/sbin/rc-service --quiet chronyd restart",
+		"/sbin/rc-service --quiet chronyd status",
+	}, ex.commands)
+}
+
+func Test_Active_Stopped(t *testing.T) {
+	ex := &tExecutor{failing: map[string]int{
+		"/usr/bin/systemctl is-active --quiet ntpsec": 3,
+		"/etc/init.d/ntpsec status":                   3,
+		"/sbin/rc-service --quiet ntpsec status":      3,
+	}}
+
+	for _, manager := range []ServiceManager{&Systemd{Exec: ex}, &Sysvinit{Exec: ex}, &OpenRC{Exec: ex}} {
+		assert.False(t, manager.Active(context.Background(), "ntpsec"), "Did not get expected result for %s", manager.Name())
+	}
+}
+
+func Test_Start_Failed(t *testing.T) {
+	ex := &tExecutor{failing: map[string]int{"/etc/init.d/ntpsec start": 1}}
+
+	err := (&Sysvinit{Exec: ex}).Start(context.Background(), "ntpsec")
+
+	var commandErr *executor.Error
+	assert.ErrorAs(t, err, &commandErr)
+	assert.Equal(t, 1, commandErr.ExitCode)
+}
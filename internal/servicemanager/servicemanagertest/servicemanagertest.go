@@ -0,0 +1,106 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+// Package servicemanagertest provides an in-memory service manager for tests.
+package servicemanagertest
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"ntpservice/internal/servicemanager"
+)
+
+// ErrFailing is returned for the actions set with Fail.
+var ErrFailing = errors.New("action failed")
+
+// ServiceManager keeps the state of the services in memory and records every action as "<action> <service>",
+// e.g. "start ntpsec". A started service is running until it is stopped.
+type ServiceManager struct {
+	mutex   sync.Mutex
+	running map[string]bool
+	failing map[string]bool
+	calls   []string
+}
+
+// New returns the service manager with the running services.
+func New(running ...string) *ServiceManager {
+	s := &ServiceManager{running: make(map[string]bool), failing: make(map[string]bool)}
+	for _, service := range running {
+		s.running[service] = true
+	}
+	return s
+}
+
+// Fail lets the action fail for the service, a failing status reports the service as not running.
+func (s *ServiceManager) Fail(action string, service string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.failing[Call(action, service)] = true
+}
+
+// Calls returns the recorded actions.
+func (s *ServiceManager) Calls() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+// Reset forgets the recorded actions.
+func (s *ServiceManager) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.calls = nil
+}
+
+// Running reports whether the service was started and not stopped since.
+func (s *ServiceManager) Running(service string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.running[service]
+}
+
+func (s *ServiceManager) Name() string {
+	return "fake"
+}
+
+func (s *ServiceManager) Start(ctx context.Context, service string) error {
+	return s.do(servicemanager.ActionStart, service, true)
+}
+
+func (s *ServiceManager) Stop(ctx context.Context, service string) error {
+	return s.do(servicemanager.ActionStop, service, false)
+}
+
+func (s *ServiceManager) Restart(ctx context.Context, service string) error {
+	return s.do(servicemanager.ActionRestart, service, true)
+}
+
+func (s *ServiceManager) Active(ctx context.Context, service string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	call := Call(servicemanager.ActionStatus, service)
+	s.calls = append(s.calls, call)
+	return s.running[service] && !s.failing[call]
+}
+
+func (s *ServiceManager) do(action string, service string, running bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	call := Call(action, service)
+	s.calls = append(s.calls, call)
+	if s.failing[call] {
+		return ErrFailing
+	}
+	s.running[service] = running
+	return nil
+}
+
+// Call returns the recorded form of an action.
+func Call(action string, service string) string {
+	return action + " " + service
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package servicemanagertest
+
+import (
+	"context"
+	"testing"
+
+	"ntpservice/internal/servicemanager"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ServiceManager(t *testing.T) {
+	ctx := context.Background()
+	services := New("ntpsec")
+
+	assert.True(t, services.Active(ctx, "ntpsec"))
+	assert.NoError(t, services.Stop(ctx, "ntpsec"))
+	assert.False(t, services.Active(ctx, "ntpsec"))
+
+	services.Fail(servicemanager.ActionStart, "ntpsec")
+	assert.ErrorIs(t, services.Start(ctx, "ntpsec"), ErrFailing)
+	assert.False(t, services.Running("ntpsec"))
+	assert.NoError(t, services.Restart(ctx, "ntpsec"))
+	assert.True(t, services.Running("ntpsec"))
+
+	services.Fail(servicemanager.ActionStatus, "ntpsec")
+	assert.False(t, services.Active(ctx, "ntpsec"), "Did not get expected result. A failing status must report the service as stopped")
+
+	assert.Equal(t, []string{"status ntpsec", "stop ntpsec", "status ntpsec", "start ntpsec", "restart ntpsec", "status ntpsec"}, services.Calls())
+	services.Reset()
+	assert.Empty(t, services.Calls())
+}
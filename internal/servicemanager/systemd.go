@@ -0,0 +1,48 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package servicemanager
+
+import (
+	"context"
+
+	"ntpservice/utils/executor"
+)
+
+const systemctl = "/usr/bin/systemctl"
+
+// Systemd controls the services with systemctl, a service is the unit name without the .service suffix.
+type Systemd struct {
+	Exec executor.Executor
+}
+
+func (s *Systemd) Name() string {
+	return SystemdName
+}
+
+func (s *Systemd) Start(ctx context.Context, service string) error {
+	return run(ctx, s.Exec, SystemdCommand(ActionStart, service))
+}
+
+func (s *Systemd) Stop(ctx context.Context, service string) error {
+	return run(ctx, s.Exec, SystemdCommand(ActionStop, service))
+}
+
+func (s *Systemd) Restart(ctx context.Context, service string) error {
+	return run(ctx, s.Exec, SystemdCommand(ActionRestart, service))
+}
+
+func (s *Systemd) Active(ctx context.Context, service string) bool {
+	return active(ctx, s.Exec, SystemdCommand(ActionStatus, service))
+}
+
+// SystemdCommand returns the systemctl command of the action, the status is checked with is-active.
+func SystemdCommand(action string, service string) executor.Command {
+	if action == ActionStatus {
+		return executor.NewCommand(statusTimeout, systemctl, "is-active", "--quiet", service)
+	}
+	return executor.NewCommand(actionTimeout, systemctl, action, service+".service")
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package servicemanager
+
+import (
+	"context"
+	"path/filepath"
+
+	"ntpservice/utils/executor"
+)
+
+// Sysvinit controls the services with their LSB init scripts in /etc/init.d.
+type Sysvinit struct {
+	Exec executor.Executor
+}
+
+func (s *Sysvinit) Name() string {
+	return SysvinitName
+}
+
+func (s *Sysvinit) Start(ctx context.Context, service string) error {
+	return run(ctx, s.Exec, SysvinitCommand(ActionStart, service))
+}
+
+func (s *Sysvinit) Stop(ctx context.Context, service string) error {
+	return run(ctx, s.Exec, SysvinitCommand(ActionStop, service))
+}
+
+func (s *Sysvinit) Restart(ctx context.Context, service string) error {
+	return run(ctx, s.Exec, SysvinitCommand(ActionRestart, service))
+}
+
+func (s *Sysvinit) Active(ctx context.Context, service string) bool {
+	return active(ctx, s.Exec, SysvinitCommand(ActionStatus, service))
+}
+
+// SysvinitCommand returns the init script call of the action.
+func SysvinitCommand(action string, service string) executor.Command {
+	timeout := actionTimeout
+	if action == ActionStatus {
+		timeout = statusTimeout
+	}
+	return executor.NewCommand(timeout, filepath.Join(sysvinitScriptDir, service), action)
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package sntp
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"strings"
+	"time"
+)
+
+// PacketSize is the size of an NTP packet without extension fields and MAC.
+const PacketSize = 48
+
+// Offsets of the timestamps in the NTP packet.
+const (
+	originTimestampOffset   = 24
+	transmitTimestampOffset = 40
+)
+
+// Modes of the NTP packet header.
+const (
+	ModeClient uint8 = 3
+	ModeServer uint8 = 4
+)
+
+// LeapNotSynchronized is the leap indicator of a server whose clock is not synchronized.
+const LeapNotSynchronized uint8 = 3
+
+// ntpEpochOffset is the number of seconds between the NTP epoch 1900 and the unix epoch 1970.
+const ntpEpochOffset = 2208988800
+
+// ErrShortPacket is returned when the received datagram is shorter than an NTP header.
+var ErrShortPacket = errors.New("ntp packet too short")
+
+// Packet is the header of an NTP packet (RFC 5905).
+type Packet struct {
+	Leap           uint8
+	Version        uint8
+	Mode           uint8
+	Stratum        uint8
+	Poll           int8
+	Precision      int8
+	RootDelay      time.Duration
+	RootDispersion time.Duration
+	ReferenceID    [4]byte
+	ReferenceTime  time.Time
+	OriginTime     time.Time
+	ReceiveTime    time.Time
+	TransmitTime   time.Time
+}
+
+// Marshal encodes the packet.
+func (p *Packet) Marshal() []byte {
+	buffer := make([]byte, PacketSize)
+	buffer[0] = p.Leap<<6 | (p.Version&0x7)<<3 | p.Mode&0x7
+	buffer[1] = p.Stratum
+	buffer[2] = byte(p.Poll)
+	buffer[3] = byte(p.Precision)
+	binary.BigEndian.PutUint32(buffer[4:], toShort(p.RootDelay))
+	binary.BigEndian.PutUint32(buffer[8:], toShort(p.RootDispersion))
+	copy(buffer[12:], p.ReferenceID[:])
+	binary.BigEndian.PutUint64(buffer[16:], TimeToTimestamp(p.ReferenceTime))
+	binary.BigEndian.PutUint64(buffer[originTimestampOffset:], TimeToTimestamp(p.OriginTime))
+	binary.BigEndian.PutUint64(buffer[32:], TimeToTimestamp(p.ReceiveTime))
+	binary.BigEndian.PutUint64(buffer[transmitTimestampOffset:], TimeToTimestamp(p.TransmitTime))
+	return buffer
+}
+
+// EchoTransmitTime copies the transmit timestamp of the request into the origin timestamp of the encoded
+// response, without losing precision by decoding it.
+func EchoTransmitTime(response []byte, request []byte) {
+	copy(response[originTimestampOffset:originTimestampOffset+8], request[transmitTimestampOffset:transmitTimestampOffset+8])
+}
+
+// Unmarshal decodes the header of an NTP packet, extension fields and MAC are ignored.
+func Unmarshal(buffer []byte) (*Packet, error) {
+	if len(buffer) < PacketSize {
+		return nil, ErrShortPacket
+	}
+	p := &Packet{
+		Leap:           buffer[0] >> 6,
+		Version:        (buffer[0] >> 3) & 0x7,
+		Mode:           buffer[0] & 0x7,
+		Stratum:        buffer[1],
+		Poll:           int8(buffer[2]),
+		Precision:      int8(buffer[3]),
+		RootDelay:      fromShort(binary.BigEndian.Uint32(buffer[4:])),
+		RootDispersion: fromShort(binary.BigEndian.Uint32(buffer[8:])),
+		ReferenceTime:  TimestampToTime(binary.BigEndian.Uint64(buffer[16:])),
+		OriginTime:     TimestampToTime(binary.BigEndian.Uint64(buffer[originTimestampOffset:])),
+		ReceiveTime:    TimestampToTime(binary.BigEndian.Uint64(buffer[32:])),
+		TransmitTime:   TimestampToTime(binary.BigEndian.Uint64(buffer[transmitTimestampOffset:])),
+	}
+	copy(p.ReferenceID[:], buffer[12:16])
+	return p, nil
+}
+
+// ReferenceIDString returns the reference id as ntpq shows it, ASCII for stratum 0 and 1, an IPv4 address otherwise.
+func (p *Packet) ReferenceIDString() string {
+	if p.Stratum <= 1 {
+		return strings.TrimRight(string(p.ReferenceID[:]), "\x00")
+	}
+	return net.IP(p.ReferenceID[:]).String()
+}
+
+// TimeToTimestamp converts the time into an NTP timestamp, the zero time is the zero timestamp.
+func TimeToTimestamp(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	seconds := uint32(t.Unix() + ntpEpochOffset)
+	fraction := (uint64(t.Nanosecond()) << 32) / uint64(time.Second)
+	return uint64(seconds)<<32 | fraction
+}
+
+// TimestampToTime converts an NTP timestamp into a time. Timestamps with the most significant bit cleared belong
+// to era 1 starting 2036 (RFC 4330).
+func TimestampToTime(timestamp uint64) time.Time {
+	if timestamp == 0 {
+		return time.Time{}
+	}
+	seconds := int64(timestamp >> 32)
+	if seconds&0x80000000 == 0 {
+		seconds += 1 << 32
+	}
+	nanoseconds := ((timestamp & 0xffffffff) * uint64(time.Second)) >> 32
+	return time.Unix(seconds-ntpEpochOffset, int64(nanoseconds))
+}
+
+// toShort converts the duration into the NTP short format, 16 bit seconds and 16 bit fraction.
+func toShort(d time.Duration) uint32 {
+	if d <= 0 {
+		return 0
+	}
+	return uint32((uint64(d) << 16) / uint64(time.Second))
+}
+
+func fromShort(value uint32) time.Duration {
+	return time.Duration((uint64(value) * uint64(time.Second)) >> 16)
+}
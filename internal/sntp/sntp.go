@@ -0,0 +1,203 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+// Package sntp implements an SNTP client (RFC 4330) which queries a server without changing the system clock,
+// similar to `ntpdate -q`.
+package sntp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// DefaultPort is the NTP port used when the address does not contain one.
+const DefaultPort = "123"
+
+// Defaults of the query options.
+const (
+	DefaultVersion  = 4
+	DefaultTimeout  = 2 * time.Second
+	DefaultInterval = time.Second
+	MaxSamples      = 8
+)
+
+var (
+	// ErrKissOfDeath is returned for a sample answered with a Kiss-o'-Death packet.
+	ErrKissOfDeath = errors.New("kiss-o'-death received")
+	// ErrInvalidResponse is returned for a sample whose response is not a valid answer to the request.
+	ErrInvalidResponse = errors.New("invalid ntp response")
+	// ErrInvalidOptions is returned when the query options are out of range.
+	ErrInvalidOptions = errors.New("invalid query options")
+)
+
+// Options of a query, zero values select the defaults.
+type Options struct {
+	Samples  int           // number of packets sent, 1-MaxSamples
+	Version  int           // NTP version of the request, 1-4
+	Timeout  time.Duration // time to wait for each response
+	Interval time.Duration // time between two packets
+}
+
+// Sample is the result of a single request.
+type Sample struct {
+	Offset         time.Duration // offset of the local clock to the server clock
+	Delay          time.Duration // round trip delay
+	Stratum        uint8
+	ReferenceID    string
+	Leap           uint8
+	RootDelay      time.Duration
+	RootDispersion time.Duration
+	KissCode       string // code of a Kiss-o'-Death packet, e.g. RATE or DENY
+	Version        uint8
+	Poll           int8
+	Precision      int8
+	Err            error // reason why the sample is not usable
+}
+
+// Result of a query.
+type Result struct {
+	Address string   // resolved address of the server
+	Samples []Sample // all samples in the order they were sent
+	Best    *Sample  // usable sample with the lowest delay, nil if there is none
+}
+
+// Query sends the requests to the server and waits for the responses. An error is returned when the options
+// are invalid or the server address can not be used, failed samples are reported in the result.
+func Query(ctx context.Context, address string, options Options) (*Result, error) {
+	options, err := withDefaults(options)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, DefaultPort)
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	result := &Result{Address: conn.RemoteAddr().String()}
+	for i := 0; i < options.Samples; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return result, nil
+			case <-time.After(options.Interval):
+			}
+		}
+		sample := exchange(ctx, conn, options)
+		result.Samples = append(result.Samples, sample)
+		if sample.Err == nil && (result.Best == nil || sample.Delay < result.Best.Delay) {
+			best := sample
+			result.Best = &best
+		}
+		if sample.KissCode != "" {
+			// The server asked to stop sending.
+			break
+		}
+	}
+	return result, nil
+}
+
+func withDefaults(options Options) (Options, error) {
+	if options.Samples == 0 {
+		options.Samples = 1
+	}
+	if options.Version == 0 {
+		options.Version = DefaultVersion
+	}
+	if options.Timeout == 0 {
+		options.Timeout = DefaultTimeout
+	}
+	if options.Interval == 0 {
+		options.Interval = DefaultInterval
+	}
+	if options.Samples < 1 || options.Samples > MaxSamples {
+		return options, fmt.Errorf("%w: samples %d is out of range 1-%d", ErrInvalidOptions, options.Samples, MaxSamples)
+	}
+	if options.Version < 1 || options.Version > 4 {
+		return options, fmt.Errorf("%w: version %d is out of range 1-4", ErrInvalidOptions, options.Version)
+	}
+	if options.Timeout < 0 || options.Interval < 0 {
+		return options, fmt.Errorf("%w: negative timeout or interval", ErrInvalidOptions)
+	}
+	return options, nil
+}
+
+// exchange sends one request and reads its response, responses to earlier requests are skipped.
+func exchange(ctx context.Context, conn net.Conn, options Options) Sample {
+	deadline := time.Now().Add(options.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return Sample{Err: err}
+	}
+	request := Packet{Version: uint8(options.Version), Mode: ModeClient, TransmitTime: time.Now()}
+	requestBytes := request.Marshal()
+	t1 := time.Now()
+	if _, err := conn.Write(requestBytes); err != nil {
+		return Sample{Err: err}
+	}
+	buffer := make([]byte, 1024)
+	for {
+		n, err := conn.Read(buffer)
+		t4 := time.Now()
+		if err != nil {
+			return Sample{Err: err}
+		}
+		response, err := Unmarshal(buffer[:n])
+		// The origin timestamp of the response must echo the transmit timestamp of the request.
+		if err != nil || !bytes.Equal(buffer[originTimestampOffset:originTimestampOffset+8], requestBytes[transmitTimestampOffset:transmitTimestampOffset+8]) {
+			continue
+		}
+		return newSample(response, t1, t4)
+	}
+}
+
+// newSample checks the response (RFC 4330 section 5) and calculates offset and delay.
+func newSample(response *Packet, t1 time.Time, t4 time.Time) Sample {
+	sample := Sample{
+		Stratum:        response.Stratum,
+		ReferenceID:    response.ReferenceIDString(),
+		Leap:           response.Leap,
+		RootDelay:      response.RootDelay,
+		RootDispersion: response.RootDispersion,
+		Version:        response.Version,
+		Poll:           response.Poll,
+		Precision:      response.Precision,
+	}
+	if response.Stratum == 0 {
+		sample.KissCode = sample.ReferenceID
+		sample.Err = fmt.Errorf("%w: %s", ErrKissOfDeath, sample.KissCode)
+		return sample
+	}
+	switch {
+	case response.Mode != ModeServer:
+		sample.Err = fmt.Errorf("%w: mode %d", ErrInvalidResponse, response.Mode)
+		return sample
+	case response.TransmitTime.IsZero() || response.ReceiveTime.IsZero():
+		sample.Err = fmt.Errorf("%w: zero timestamps", ErrInvalidResponse)
+		return sample
+	case response.Leap == LeapNotSynchronized:
+		sample.Err = fmt.Errorf("%w: server clock is not synchronized", ErrInvalidResponse)
+		return sample
+	}
+	t2, t3 := response.ReceiveTime, response.TransmitTime
+	// t1 and t4 are compared with their monotonic clock readings, the server timestamps with the wall clock.
+	sample.Delay = t4.Sub(t1) - t3.Sub(t2)
+	if sample.Delay < 0 {
+		sample.Delay = 0
+	}
+	sample.Offset = (t2.Sub(t1) + t3.Sub(t4)) / 2
+	return sample
+}
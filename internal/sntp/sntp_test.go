@@ -0,0 +1,134 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package sntp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ntpservice/internal/sntp"
+	"ntpservice/internal/sntp/sntptest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var tOptions = sntp.Options{Timeout: time.Second, Interval: time.Millisecond}
+
+func startServer(t *testing.T, response sntp.Packet, offset time.Duration) *sntptest.Server {
+	server, err := sntptest.NewServerWithResponse(response, offset)
+	assert.NoError(t, err)
+	t.Cleanup(func() { server.Close() })
+	return server
+}
+
+func Test_Query(t *testing.T) {
+	server := startServer(t, sntptest.DefaultResponse(), 250*time.Millisecond)
+	options := tOptions
+	options.Samples = 3
+
+	result, err := sntp.Query(context.Background(), server.Addr, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, server.Addr, result.Address)
+	assert.Len(t, result.Samples, 3)
+	assert.NotNil(t, result.Best)
+	assert.InDelta(t, float64(250*time.Millisecond), float64(result.Best.Offset), float64(20*time.Millisecond))
+	assert.Less(t, result.Best.Delay, 20*time.Millisecond)
+	assert.Equal(t, uint8(1), result.Best.Stratum)
+	assert.Equal(t, "GPS", result.Best.ReferenceID)
+	assert.InDelta(t, float64(time.Millisecond), float64(result.Best.RootDelay), float64(20*time.Microsecond))
+	assert.InDelta(t, float64(2*time.Millisecond), float64(result.Best.RootDispersion), float64(20*time.Microsecond))
+	assert.Equal(t, int8(-20), result.Best.Precision)
+	requests := server.Requests()
+	assert.Len(t, requests, 3)
+	assert.Equal(t, sntp.ModeClient, requests[0].Mode)
+	assert.Equal(t, uint8(4), requests[0].Version)
+}
+
+func Test_Query_ReferenceIdOfStratum2(t *testing.T) {
+	response := sntptest.DefaultResponse()
+	response.Stratum = 2
+	response.ReferenceID = [4]byte{192, 0, 2, 1}
+	response.Leap = 1
+	server := startServer(t, response, 0)
+
+	result, err := sntp.Query(context.Background(), server.Addr, tOptions)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "192.0.2.1", result.Best.ReferenceID)
+	assert.Equal(t, uint8(1), result.Best.Leap)
+}
+
+func Test_Query_KissOfDeath(t *testing.T) {
+	response := sntptest.DefaultResponse()
+	response.Stratum = 0
+	response.ReferenceID = [4]byte{'R', 'A', 'T', 'E'}
+	server := startServer(t, response, 0)
+	options := tOptions
+	options.Samples = 4
+
+	result, err := sntp.Query(context.Background(), server.Addr, options)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.Best)
+	assert.Len(t, result.Samples, 1, "Did not get expected result. Query must stop after a kiss-o'-death")
+	assert.Equal(t, "RATE", result.Samples[0].KissCode)
+	assert.ErrorIs(t, result.Samples[0].Err, sntp.ErrKissOfDeath)
+}
+
+func Test_Query_Unsynchronized(t *testing.T) {
+	response := sntptest.DefaultResponse()
+	response.Leap = sntp.LeapNotSynchronized
+	server := startServer(t, response, 0)
+
+	result, err := sntp.Query(context.Background(), server.Addr, tOptions)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.Best)
+	assert.ErrorIs(t, result.Samples[0].Err, sntp.ErrInvalidResponse)
+}
+
+func Test_Query_NoResponse(t *testing.T) {
+	server := startServer(t, sntptest.DefaultResponse(), 0)
+	assert.NoError(t, server.Close())
+	options := tOptions
+	options.Timeout = 50 * time.Millisecond
+
+	result, err := sntp.Query(context.Background(), server.Addr, options)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.Best)
+	assert.Error(t, result.Samples[0].Err)
+}
+
+func Test_Query_InvalidOptions(t *testing.T) {
+	for _, options := range []sntp.Options{{Samples: 9}, {Samples: -1}, {Version: 5}, {Timeout: -time.Second}} {
+		_, err := sntp.Query(context.Background(), "127.0.0.1", options)
+		assert.ErrorIs(t, err, sntp.ErrInvalidOptions, "%v", options)
+	}
+}
+
+func Test_PacketRoundTrip(t *testing.T) {
+	now := time.Date(2040, 2, 7, 6, 28, 16, 500_000_000, time.UTC)
+	packet := sntp.Packet{Leap: 2, Version: 3, Mode: sntp.ModeServer, Stratum: 3, Poll: 10, Precision: -18,
+		RootDelay: 500 * time.Millisecond, ReferenceID: [4]byte{10, 0, 0, 1}, ReceiveTime: now, TransmitTime: now.Add(time.Second)}
+
+	decoded, err := sntp.Unmarshal(packet.Marshal())
+
+	assert.NoError(t, err)
+	assert.Equal(t, packet.Leap, decoded.Leap)
+	assert.Equal(t, packet.Version, decoded.Version)
+	assert.Equal(t, packet.Poll, decoded.Poll)
+	assert.Equal(t, packet.Precision, decoded.Precision)
+	assert.Equal(t, packet.RootDelay, decoded.RootDelay)
+	assert.True(t, decoded.ReceiveTime.Equal(now), "Did not get expected result. Wanted: %v, got: %v", now, decoded.ReceiveTime)
+	assert.True(t, decoded.OriginTime.IsZero())
+
+	_, err = sntp.Unmarshal(make([]byte, 47))
+	assert.ErrorIs(t, err, sntp.ErrShortPacket)
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+// Package sntptest provides a local NTP server for tests.
+package sntptest
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"ntpservice/internal/sntp"
+)
+
+// Server is an NTP server listening on the loopback interface. It answers with the fields of the response
+// template, the receive and transmit timestamps are taken from its clock shifted by offset.
+type Server struct {
+	Addr string
+
+	response sntp.Packet
+	offset   time.Duration
+	mutex    sync.Mutex
+	requests []sntp.Packet
+	conn     net.PacketConn
+}
+
+// DefaultResponse returns the response template of a stratum 1 server with reference id GPS.
+func DefaultResponse() sntp.Packet {
+	return sntp.Packet{
+		Version:        4,
+		Mode:           sntp.ModeServer,
+		Stratum:        1,
+		Poll:           6,
+		Precision:      -20,
+		RootDelay:      time.Millisecond,
+		RootDispersion: 2 * time.Millisecond,
+		ReferenceID:    [4]byte{'G', 'P', 'S', 0},
+	}
+}
+
+// NewServer starts a server answering with the default response and no offset.
+func NewServer() (*Server, error) {
+	return NewServerWithResponse(DefaultResponse(), 0)
+}
+
+// NewServerWithResponse starts a server answering with the response template and clock offset.
+func NewServerWithResponse(response sntp.Packet, offset time.Duration) (*Server, error) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	server := &Server{
+		Addr:     conn.LocalAddr().String(),
+		response: response,
+		offset:   offset,
+		conn:     conn,
+	}
+	go server.serve()
+	return server, nil
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []sntp.Packet {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]sntp.Packet(nil), s.requests...)
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	return s.conn.Close()
+}
+
+func (s *Server) serve() {
+	buffer := make([]byte, 1024)
+	for {
+		n, address, err := s.conn.ReadFrom(buffer)
+		if err != nil {
+			return
+		}
+		received := time.Now()
+		request, err := sntp.Unmarshal(buffer[:n])
+		if err != nil {
+			continue
+		}
+		s.mutex.Lock()
+		s.requests = append(s.requests, *request)
+		s.mutex.Unlock()
+		response := s.response
+		response.ReferenceTime = received.Add(s.offset - time.Minute)
+		response.ReceiveTime = received.Add(s.offset)
+		response.TransmitTime = time.Now().Add(s.offset)
+		out := response.Marshal()
+		sntp.EchoTransmitTime(out, buffer[:n])
+		_, _ = s.conn.WriteTo(out, address)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+// Package tlsreload provides the TLS configuration of a server whose certificate, key and client CA files are
+// reloaded when they change, e.g. after a renewal, without restarting the server.
+package tlsreload
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// CheckInterval is the minimum time between two checks of the files, the check is done with a handshake.
+const CheckInterval = 5 * time.Second
+
+// alpnProtocols are the protocols negotiated with the clients, gRPC requires h2.
+var alpnProtocols = []string{"h2"}
+
+// ErrNoCertificate is returned when the certificate or the key file is missing in the options.
+var ErrNoCertificate = errors.New("tls certificate and key are required")
+
+// Options are the files of the server certificate, its key and the CA of the client certificates.
+// Without ClientCAFile client certificates are not requested.
+type Options struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+}
+
+// Reloader holds the TLS configuration loaded from the files of the options.
+type Reloader struct {
+	options   Options
+	interval  time.Duration
+	mutex     sync.Mutex
+	config    *tls.Config
+	files     map[string]fileState
+	lastCheck time.Time
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// New loads the files of the options, an error is returned if they can not be used.
+func New(options Options) (*Reloader, error) {
+	if options.CertFile == "" || options.KeyFile == "" {
+		return nil, ErrNoCertificate
+	}
+	r := &Reloader{options: options, interval: CheckInterval}
+	config, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.config = config
+	r.files = r.stat()
+	r.lastCheck = time.Now()
+	return r, nil
+}
+
+// Config returns the server configuration, every handshake uses the currently loaded files.
+func (r *Reloader) Config() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		NextProtos:         alpnProtocols,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) { return r.current(), nil },
+	}
+}
+
+// current returns the loaded configuration, the files are reloaded if they changed since the last check.
+// A failed reload is logged and the previous configuration is kept, e.g. while the key is written after the certificate.
+func (r *Reloader) current() *tls.Config {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if time.Since(r.lastCheck) < r.interval {
+		return r.config
+	}
+	r.lastCheck = time.Now()
+	files := r.stat()
+	if sameFiles(files, r.files) {
+		return r.config
+	}
+	config, err := r.load()
+	if err != nil {
+		log.Println("Cannot reload the TLS certificates, keeping the previous ones:", err.Error())
+		return r.config
+	}
+	log.Println("Reloaded the TLS certificates")
+	r.config = config
+	r.files = files
+	return r.config
+}
+
+func (r *Reloader) load() (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(r.options.CertFile, r.options.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading certificate %s and key %s: %w", r.options.CertFile, r.options.KeyFile, err)
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   alpnProtocols,
+		Certificates: []tls.Certificate{certificate},
+	}
+	if r.options.ClientCAFile == "" {
+		return config, nil
+	}
+	bundle, err := os.ReadFile(r.options.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading client ca %s: %w", r.options.ClientCAFile, err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("loading client ca %s: no PEM certificate found", r.options.ClientCAFile)
+	}
+	config.ClientCAs = clientCAs
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+	return config, nil
+}
+
+// stat returns the modification time and size of the files, a missing file has the zero state.
+func (r *Reloader) stat() map[string]fileState {
+	files := make(map[string]fileState)
+	for _, path := range []string{r.options.CertFile, r.options.KeyFile, r.options.ClientCAFile} {
+		if path == "" {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil {
+			files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		} else {
+			files[path] = fileState{}
+		}
+	}
+	return files
+}
+
+func sameFiles(a map[string]fileState, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, state := range a {
+		if other, ok := b[path]; !ok || !other.modTime.Equal(state.modTime) || other.size != state.size {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package tlsreload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tIssuer struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	pem         []byte
+}
+
+// newIssuer creates a self-signed CA.
+func newIssuer(t *testing.T, name string) tIssuer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return tIssuer{certificate: certificate, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue writes a certificate for 127.0.0.1 signed by the issuer and its key to the files.
+func (i tIssuer) issue(t *testing.T, serial int64, usage x509.ExtKeyUsage, certFile string, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "ntpservice"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, i.certificate, &key.PublicKey, i.key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+}
+
+func tOptions(t *testing.T) (Options, tIssuer) {
+	dir := t.TempDir()
+	issuer := newIssuer(t, "plant ca")
+	options := Options{
+		CertFile:     filepath.Join(dir, "server.pem"),
+		KeyFile:      filepath.Join(dir, "server.key"),
+		ClientCAFile: filepath.Join(dir, "client-ca.pem"),
+	}
+	issuer.issue(t, 2, x509.ExtKeyUsageServerAuth, options.CertFile, options.KeyFile)
+	assert.NoError(t, os.WriteFile(options.ClientCAFile, issuer.pem, 0644))
+	return options, issuer
+}
+
+// handshake connects to a TLS listener with the config and returns the serial of the server certificate.
+func handshake(t *testing.T, server *tls.Config, client *tls.Config) (*big.Int, error) {
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", server)
+	assert.NoError(t, err)
+	defer lis.Close()
+	go func() {
+		if conn, err := lis.Accept(); err == nil {
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+	conn, err := tls.Dial("tcp", lis.Addr().String(), client)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// the client certificate is verified after the client finished its handshake, a read returns the alert
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := conn.Read(make([]byte, 1)); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber, nil
+}
+
+func Test_New(t *testing.T) {
+	options, _ := tOptions(t)
+
+	_, err := New(Options{CertFile: options.CertFile})
+	assert.ErrorIs(t, err, ErrNoCertificate)
+	_, err = New(Options{CertFile: options.CertFile, KeyFile: options.ClientCAFile})
+	assert.Error(t, err)
+	_, err = New(Options{CertFile: options.CertFile, KeyFile: options.KeyFile, ClientCAFile: options.KeyFile})
+	assert.Error(t, err)
+	_, err = New(options)
+	assert.NoError(t, err)
+}
+
+func Test_ClientCertificate(t *testing.T) {
+	options, issuer := tOptions(t)
+	reloader, err := New(options)
+	assert.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(issuer.pem)
+	dir := t.TempDir()
+	issuer.issue(t, 3, x509.ExtKeyUsageClientAuth, filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key"))
+	clientCertificate, err := tls.LoadX509KeyPair(filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key"))
+	assert.NoError(t, err)
+
+	serial, err := handshake(t, reloader.Config(), &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCertificate}, NextProtos: []string{"h2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), serial.Int64())
+
+	_, err = handshake(t, reloader.Config(), &tls.Config{RootCAs: roots, NextProtos: []string{"h2"}})
+	assert.Error(t, err, "Did not get expected result. A client without certificate must be refused")
+}
+
+func Test_WithoutClientCA(t *testing.T) {
+	options, issuer := tOptions(t)
+	options.ClientCAFile = ""
+	reloader, err := New(options)
+	assert.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(issuer.pem)
+
+	serial, err := handshake(t, reloader.Config(), &tls.Config{RootCAs: roots, NextProtos: []string{"h2"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), serial.Int64())
+}
+
+func Test_Reload(t *testing.T) {
+	options, issuer := tOptions(t)
+	reloader, err := New(options)
+	assert.NoError(t, err)
+	reloader.interval = 0
+
+	issuer.issue(t, 4, x509.ExtKeyUsageServerAuth, options.CertFile, options.KeyFile)
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(options.CertFile, later, later))
+
+	assert.Equal(t, int64(4), serialOf(t, reloader.current()))
+
+	assert.NoError(t, os.WriteFile(options.KeyFile, []byte("half written"), 0600))
+	assert.Equal(t, int64(4), serialOf(t, reloader.current()), "Did not get expected result. A broken key must keep the loaded certificate")
+}
+
+func Test_Reload_CheckInterval(t *testing.T) {
+	options, issuer := tOptions(t)
+	reloader, err := New(options)
+	assert.NoError(t, err)
+
+	issuer.issue(t, 5, x509.ExtKeyUsageServerAuth, options.CertFile, options.KeyFile)
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(options.CertFile, later, later))
+
+	assert.Equal(t, int64(2), serialOf(t, reloader.current()))
+}
+
+func serialOf(t *testing.T, config *tls.Config) int64 {
+	certificate, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	assert.NoError(t, err)
+	return certificate.SerialNumber.Int64()
+}
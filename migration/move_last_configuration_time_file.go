@@ -11,12 +11,11 @@ import (
 	"io/fs"
 	"log"
 	ntpcf "ntpservice/internal/ntpconfigurator"
+	"ntpservice/internal/serviceconfig"
 	. "ntpservice/utils/files"
 	"path/filepath"
 )
 
-const oldPath = "/opt/lastntpconfigdate.rec"
-const newPath = ntpcf.NtpLastConfigPath
 const resourcePermissions = ntpcf.DefaultResourcePermissions
 
 // LastConfigurationTimeOfNTPClientMigration
@@ -27,22 +26,23 @@ const resourcePermissions = ntpcf.DefaultResourcePermissions
 type LastConfigurationTimeOfNTPClientMigration struct {
 	FileSystemOperations
 	FileUtil
+	Paths serviceconfig.Paths
 }
 
-func NewLastConfigurationTimeOfNTPClientMigration() LastConfigurationTimeOfNTPClientMigration {
+func NewLastConfigurationTimeOfNTPClientMigration(paths serviceconfig.Paths) LastConfigurationTimeOfNTPClientMigration {
 	fileSystem := &OsFileSystemOperations{}
 	fileUtils := &OsFileUtils{FileSystemOperations: fileSystem}
 
-	return LastConfigurationTimeOfNTPClientMigration{fileSystem, fileUtils}
+	return LastConfigurationTimeOfNTPClientMigration{fileSystem, fileUtils, paths}
 }
 
 func (migration *LastConfigurationTimeOfNTPClientMigration) Start() {
 	log.Println("Checking Migration requirement for `last configuration time of ntp client`")
 
 	if migration.isRequired() {
-		err := migration.MkdirAll(filepath.Dir(newPath), resourcePermissions)
+		err := migration.MkdirAll(filepath.Dir(migration.Paths.LastConfig), resourcePermissions)
 		if err == nil {
-			err = migration.Move(oldPath, newPath)
+			err = migration.Move(migration.Paths.LegacyLastConfig, migration.Paths.LastConfig)
 		}
 
 		if err != nil {
@@ -56,8 +56,8 @@ func (migration *LastConfigurationTimeOfNTPClientMigration) Start() {
 }
 
 func (migration *LastConfigurationTimeOfNTPClientMigration) isRequired() bool {
-	isNewFileExist := migration.isFileExist(newPath)
-	isOldFileExist := migration.isFileExist(oldPath)
+	isNewFileExist := migration.isFileExist(migration.Paths.LastConfig)
+	isOldFileExist := migration.isFileExist(migration.Paths.LegacyLastConfig)
 
 	return !isNewFileExist && isOldFileExist
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"io/fs"
+	"ntpservice/internal/serviceconfig"
 	. "ntpservice/utils/files"
 	. "ntpservice/utils/mocks"
 	"path/filepath"
@@ -79,6 +80,21 @@ func Test_MigrationRequired(t *testing.T) {
 	mocks.fs.AssertNumberOfCalls(t, "Move", 1)
 }
 
+func Test_MigrationRequired_ConfiguredPaths(t *testing.T) {
+	mocks, migration := generateMocks()
+	migration.Paths.LegacyLastConfig = "/data/old/lastntpconfigdate.rec"
+	migration.Paths.LastConfig = "/data/ntp/lastntpconfigdate.rec"
+
+	mocks.fu.On("IsFileExist", "/data/old/lastntpconfigdate.rec").Return(true, nil)
+	mocks.fu.On("IsFileExist", "/data/ntp/lastntpconfigdate.rec").Return(false, fs.ErrNotExist)
+	mocks.fs.On("MkdirAll", "/data/ntp", fs.FileMode(0666)).Return(nil)
+	mocks.fs.On("Move", "/data/old/lastntpconfigdate.rec", "/data/ntp/lastntpconfigdate.rec").Return(nil)
+
+	migration.Start()
+
+	mocks.fs.AssertCalled(t, "Move", "/data/old/lastntpconfigdate.rec", "/data/ntp/lastntpconfigdate.rec")
+}
+
 func Test_MigrationRequired_CantRun(t *testing.T) {
 	mocks, migration := generateMocks()
 
@@ -93,7 +109,7 @@ func Test_MigrationRequired_CantRun(t *testing.T) {
 }
 
 func Test_OsPackageIntegratedToLastConfigurationTimeOfNTPClientMigration(t *testing.T) {
-	migration := NewLastConfigurationTimeOfNTPClientMigration()
+	migration := NewLastConfigurationTimeOfNTPClientMigration(serviceconfig.Default().Paths)
 
 	fileUtils := migration.FileUtil.(*OsFileUtils)
 	_ = migration.FileSystemOperations.(*OsFileSystemOperations)
@@ -111,7 +127,7 @@ func generateMocks() (mocks, LastConfigurationTimeOfNTPClientMigration) {
 	mockFsOp := new(MockFileSystem)
 	mockFileUtil := new(MockFileUtil)
 
-	migration := LastConfigurationTimeOfNTPClientMigration{mockFsOp, mockFileUtil}
+	migration := LastConfigurationTimeOfNTPClientMigration{mockFsOp, mockFileUtil, serviceconfig.Default().Paths}
 	mocks := mocks{mockFsOp, mockFileUtil}
 
 	return mocks, migration
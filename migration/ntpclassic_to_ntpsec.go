@@ -1,5 +1,5 @@
 /*
- * Copyright © Siemens 2023 - 2025. ALL RIGHTS RESERVED.
+ * Copyright © Siemens 2023 - 2026. ALL RIGHTS RESERVED.
  * Licensed under the MIT license
  * See LICENSE file in the top-level directory
  */
@@ -8,20 +8,22 @@ package migration
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"log"
 	ntpcf "ntpservice/internal/ntpconfigurator"
+	"ntpservice/internal/serviceconfig"
+	"ntpservice/internal/servicemanager"
+	"ntpservice/utils/executor"
 	. "ntpservice/utils/files"
-	"os"
 	"path/filepath"
 	"strings"
 )
 
-const NTPClassicConfPath = "/etc/ntp.conf"
-const NTPSecConfPath = "/etc/ntpsec/ntp.conf"
-const ntpSecBackupConfPath = "/etc/ntpsec/ntp.conf.backup"
-const ntpSecMigrationFilePath = "/etc/iedk/ntp/migration/ntpsec.migration"
+// NTPSecConfPath is the default ntp.conf of ntpsec, it is replaced by the path of the service configuration.
+const NTPSecConfPath = serviceconfig.DefaultNtpSecConfPath
+const backupSuffix = ".backup"
 const iedkMigrationTag = "#iedk-migration"
 const ntpSecVersion = "1.2.x"
 
@@ -30,23 +32,44 @@ var ntpMigrationCommands = []string{"server", "tos", "pool"}
 type NTPClassicToNTPSecMigration struct {
 	FileSystemOperations
 	FileUtil
-	ntpcf.Utils
+	executor.Executor
+	Services servicemanager.ServiceManager
+	Config   *serviceconfig.Config
 }
 
-func NewNTPClassicToNTPSecMigration() NTPClassicToNTPSecMigration {
+func NewNTPClassicToNTPSecMigration(config *serviceconfig.Config) NTPClassicToNTPSecMigration {
 	fileSystem := OsFileSystemOperations{}
 	fileUtil := OsFileUtils{FileSystemOperations: &fileSystem}
+	ex := executor.OsExecutor{}
 
 	return NTPClassicToNTPSecMigration{
 		&fileSystem,
 		&fileUtil,
-		&ntpcf.OsUtils{}}
+		ex,
+		servicemanager.FromEnv(ex),
+		config}
+}
+
+func (migration *NTPClassicToNTPSecMigration) classicConfPath() string {
+	return migration.Config.Paths.ClassicNtpConf
+}
+
+func (migration *NTPClassicToNTPSecMigration) ntpSecConfPath() string {
+	return migration.Config.NtpSecConfPath()
+}
+
+func (migration *NTPClassicToNTPSecMigration) backupConfPath() string {
+	return migration.ntpSecConfPath() + backupSuffix
+}
+
+func (migration *NTPClassicToNTPSecMigration) migrationFilePath() string {
+	return migration.Config.Paths.NtpSecMigration
 }
 
 func (migration *NTPClassicToNTPSecMigration) isRequired() (bool, error) {
 	log.Println("Checking migration requirement for `ntp-classic to ntp-sec`.")
-	migrationFileExists, err1 := migration.IsFileExist(ntpSecMigrationFilePath)
-	oldNtpConfExists, err2 := migration.IsFileExist(NTPClassicConfPath)
+	migrationFileExists, err1 := migration.IsFileExist(migration.migrationFilePath())
+	oldNtpConfExists, err2 := migration.IsFileExist(migration.classicConfPath())
 
 	if err1 != nil {
 		return false, err1
@@ -59,7 +82,7 @@ func (migration *NTPClassicToNTPSecMigration) isRequired() (bool, error) {
 
 func (migration *NTPClassicToNTPSecMigration) Start() error {
 	if isRequired, err := migration.isRequired(); err != nil {
-		log.Printf("Error: Cannot check is ntp-classic to ntp-sec migration is required, reason: %s, related path: %s", err.Error(), ntpSecMigrationFilePath)
+		log.Printf("Error: Cannot check is ntp-classic to ntp-sec migration is required, reason: %s, related path: %s", err.Error(), migration.migrationFilePath())
 
 		return err
 	} else if isRequired {
@@ -67,14 +90,14 @@ func (migration *NTPClassicToNTPSecMigration) Start() error {
 
 		return migration.run()
 	} else {
-		if migrationFileExists, err := migration.IsFileExist(ntpSecMigrationFilePath); !migrationFileExists {
+		if migrationFileExists, err := migration.IsFileExist(migration.migrationFilePath()); !migrationFileExists {
 			if err := migration.commentOutNTPSecDefaultsConfigurations(); err != nil {
 				return fmt.Errorf("Cannot disable defaults of ntpsec configuration: %s\n", err.Error())
 			}
 			if err != nil {
-				return fmt.Errorf("Cannot read migration file (%s), err: %s\n", ntpSecMigrationFilePath, err.Error())
+				return fmt.Errorf("Cannot read migration file (%s), err: %s\n", migration.migrationFilePath(), err.Error())
 			} else if err := migration.createNtpSecMigrationFile(false); err != nil {
-				return fmt.Errorf("Creating file  %s failed: %s\n", ntpSecMigrationFilePath, err.Error())
+				return fmt.Errorf("Creating file  %s failed: %s\n", migration.migrationFilePath(), err.Error())
 			}
 			log.Printf("Skipping `ntp-classic to ntp-sec` migration since it is not required !")
 		} else {
@@ -110,74 +133,55 @@ func (migration *NTPClassicToNTPSecMigration) migrateNtpSecToNtpClassic() error
 	}
 
 	if err := migration.createNtpSecMigrationFile(true); err != nil {
-		return fmt.Errorf("Creating file  %s failed: %s\n", ntpSecMigrationFilePath, err.Error())
+		return fmt.Errorf("Creating file  %s failed: %s\n", migration.migrationFilePath(), err.Error())
 	}
 
 	return nil
 }
 
 func (migration *NTPClassicToNTPSecMigration) copyNTPClassicCommandsToNTPSec() error {
-	oldConfig, err := migration.Open(NTPClassicConfPath)
+	oldConfig, err := migration.Open(migration.classicConfPath())
 	if err != nil {
-		log.Printf("File %s not found. Migration ends.", NTPClassicConfPath)
+		log.Printf("File %s not found. Migration ends.", migration.classicConfPath())
 		return nil
 	}
 	defer oldConfig.Close()
 
-	newConfig, err := migration.OpenFile(NTPSecConfPath, os.O_APPEND|os.O_WRONLY, ntpcf.DefaultResourcePermissions)
-	if err != nil {
-		return err
-	}
-	defer newConfig.Close()
-
 	previousCommands, err := migration.fetchNTPClassicCommands(oldConfig)
 	if err != nil {
-		return fmt.Errorf("Fetching ntp-classic commands from %s failed: %s\n", NTPClassicConfPath, err.Error())
+		return fmt.Errorf("Fetching ntp-classic commands from %s failed: %s\n", migration.classicConfPath(), err.Error())
 	}
 
-	if err := migration.appendCommandsToConfigFile(previousCommands, newConfig); err != nil {
+	if err := migration.AppendToFile(migration.ntpSecConfPath(), strings.Join(previousCommands, "\n")); err != nil {
 		return fmt.Errorf("Injecting commands failed: %s\n", err.Error())
 	}
 	return nil
 }
 
 func (migration *NTPClassicToNTPSecMigration) createNtpSecMigrationFile(isUpgraded bool) error {
-	if err := migration.MkdirAll(filepath.Dir(ntpSecMigrationFilePath), resourcePermissions); err != nil {
+	if err := migration.MkdirAll(filepath.Dir(migration.migrationFilePath()), resourcePermissions); err != nil {
 		return err
 	}
-	if migrationFile, err := migration.Create(ntpSecMigrationFilePath); err != nil {
-		return err
-	} else {
-		migrationFileWriter := bufio.NewWriter(migrationFile)
-		content := ntpSecVersion
-		if !isUpgraded {
-			content += " #not-upgraded"
-		}
-
-		if _, err := migrationFileWriter.WriteString(content); err != nil {
-			return err
-		}
-		if err = migrationFileWriter.Flush(); err != nil {
-			return err
-		}
-		defer migrationFile.Close()
+	content := ntpSecVersion
+	if !isUpgraded {
+		content += " #not-upgraded"
 	}
-	return nil
+	return migration.CreateOrUpdateFile(migration.migrationFilePath(), content)
 }
 
 func (migration *NTPClassicToNTPSecMigration) commentOutNTPSecDefaultsConfigurations() error {
-	if file, err := migration.Open(NTPSecConfPath); err == nil {
+	if file, err := migration.Open(migration.ntpSecConfPath()); err == nil {
 		scanner := bufio.NewScanner(file)
 		configBuilder := strings.Builder{}
 
 		migration.findAndCommentOut(scanner, &configBuilder)
 
-		if err = migration.CreateOrUpdateFile(NTPSecConfPath, configBuilder.String()); err != nil {
-			return fmt.Errorf("Cannot update file content %s, error:%s\n", NTPSecConfPath, err.Error())
+		if err = migration.CreateOrUpdateFile(migration.ntpSecConfPath(), configBuilder.String()); err != nil {
+			return fmt.Errorf("Cannot update file content %s, error:%s\n", migration.ntpSecConfPath(), err.Error())
 		}
 		defer file.Close()
 	} else {
-		return fmt.Errorf("%s not found. Migration Failed: %s", NTPSecConfPath, err.Error())
+		return fmt.Errorf("%s not found. Migration Failed: %s", migration.ntpSecConfPath(), err.Error())
 	}
 
 	return nil
@@ -196,20 +200,6 @@ func (migration *NTPClassicToNTPSecMigration) findAndCommentOut(scanner *bufio.S
 	}
 }
 
-func (migration *NTPClassicToNTPSecMigration) appendCommandsToConfigFile(commands []string, file io.Writer) error {
-	writer := bufio.NewWriter(file)
-
-	if _, err := writer.WriteString(strings.Join(commands, "\n")); err != nil {
-		return err
-	}
-
-	if err := writer.Flush(); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (migration *NTPClassicToNTPSecMigration) fetchNTPClassicCommands(reader io.Reader) ([]string, error) {
 	var ntpCommandList []string
 
@@ -231,28 +221,29 @@ func (migration *NTPClassicToNTPSecMigration) fetchNTPClassicCommands(reader io.
 }
 
 func (migration *NTPClassicToNTPSecMigration) backupDefaultNTPSecConf() error {
-	if err := migration.Copy(NTPSecConfPath, ntpSecBackupConfPath); err != nil {
+	if err := migration.Copy(migration.ntpSecConfPath(), migration.backupConfPath()); err != nil {
 		return fmt.Errorf("cannot back up ntpsec configuration file: %w", err)
 	} else {
-		log.Printf("Backed up of ntpsec conf: %s for migration in to: %s", ntpSecBackupConfPath, ntpSecBackupConfPath)
+		log.Printf("Backed up of ntpsec conf: %s for migration in to: %s", migration.backupConfPath(), migration.backupConfPath())
 		return nil
 	}
 }
 
 func (migration *NTPClassicToNTPSecMigration) rollback() {
-	if err := migration.Move(ntpSecBackupConfPath, NTPSecConfPath); err != nil {
+	if err := migration.Move(migration.backupConfPath(), migration.ntpSecConfPath()); err != nil {
 		log.Printf("Error: Cannot rollback the changes made during migration, err: %s", err.Error())
 	} else {
-		log.Printf("Rollbacked ntpsec conf file %s  via backup:  %s", NTPSecConfPath, ntpSecBackupConfPath)
+		log.Printf("Rollbacked ntpsec conf file %s  via backup:  %s", migration.ntpSecConfPath(), migration.backupConfPath())
 	}
 }
 
 func (migration *NTPClassicToNTPSecMigration) finalize() {
-	if err := migration.Remove(ntpSecBackupConfPath); err != nil {
-		log.Printf("Warning: Cannot remove ntp sec config backup file: %s, reason: %s", ntpSecBackupConfPath, err.Error())
+	if err := migration.Remove(migration.backupConfPath()); err != nil {
+		log.Printf("Warning: Cannot remove ntp sec config backup file: %s, reason: %s", migration.backupConfPath(), err.Error())
 	}
 
-	if err := ntpcf.UpdateSystemTime(migration); err != nil {
+	if err := ntpcf.UpdateSystemTime(context.Background(), migration, migration.Services,
+		ntpcf.Daemon{Service: migration.Config.Daemon.Unit, StepTimeout: migration.Config.Daemon.StepTimeout}); err != nil {
 		log.Printf("Warning: Couldn't update system time: %s ", err.Error())
 	}
 }
@@ -1,5 +1,5 @@
 /*
- * Copyright © Siemens 2023 - 2025. ALL RIGHTS RESERVED.
+ * Copyright © Siemens 2023 - 2026. ALL RIGHTS RESERVED.
  * Licensed under the MIT license
  * See LICENSE file in the top-level directory
  */
@@ -12,10 +12,12 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"io"
 	"io/fs"
 	"log"
 	"ntpservice/internal/ntpconfigurator"
+	"ntpservice/internal/serviceconfig"
+	"ntpservice/internal/servicemanager/servicemanagertest"
+	"ntpservice/utils/executor"
 	"ntpservice/utils/files"
 	. "ntpservice/utils/mocks"
 	"os"
@@ -23,6 +25,13 @@ import (
 	"testing"
 )
 
+// Paths of the migration with the default service configuration.
+const (
+	tNtpClassicConfPath      = "/etc/ntp.conf"
+	tNtpSecBackupConfPath    = "/etc/ntpsec/ntp.conf.backup"
+	tNtpSecMigrationFilePath = "/etc/iedk/ntp/migration/ntpsec.migration"
+)
+
 const defaultNtpSecCommands = "" +
 	"tos maxclock 9\n" +
 	"server 1.tr.pool.ntp.org\n" +
@@ -40,33 +49,28 @@ const ntpClassicCommands = "" +
 func Test_MigrationSkipped_IfMigrationNotRequired(t *testing.T) {
 	mocks, migration := generateMockNtpSecMigration()
 
-	mocks.fu.On("IsFileExist", ntpSecMigrationFilePath).Return(true, nil)
-	mocks.fu.On("IsFileExist", NTPClassicConfPath).Return(false, nil)
+	mocks.fu.On("IsFileExist", tNtpSecMigrationFilePath).Return(true, nil)
+	mocks.fu.On("IsFileExist", tNtpClassicConfPath).Return(false, nil)
 
 	err := migration.Start()
 	assert.NoError(t, err)
 
 	mocks.fs.AssertNotCalled(t, "Create", mock.Anything)
-	mocks.cmd.AssertNotCalled(t, "Commander")
+	mocks.cmd.AssertNotCalled(t, "Run")
 }
 func Test_MigrationDoneWithoutUpgrading_IfMigrationNotRequired(t *testing.T) {
 	mocks, migration := generateMockNtpSecMigration()
 
-	ntpMigrationContent := NewEmptyMockFile()
-
-	mocks.fu.On("IsFileExist", ntpSecMigrationFilePath).Return(false, nil)
-	mocks.fu.On("IsFileExist", NTPClassicConfPath).Return(false, nil)
-	mocks.fs.On("MkdirAll", filepath.Dir(ntpSecMigrationFilePath), fs.FileMode(0666)).Return(nil)
-	mocks.fs.On("Create", ntpSecMigrationFilePath).Return(ntpMigrationContent, nil)
+	mocks.fu.On("IsFileExist", tNtpSecMigrationFilePath).Return(false, nil)
+	mocks.fu.On("IsFileExist", tNtpClassicConfPath).Return(false, nil)
+	mocks.fs.On("MkdirAll", filepath.Dir(tNtpSecMigrationFilePath), fs.FileMode(0666)).Return(nil)
 	mockRemainingMethodsWithSuccessfulResults(mocks)
 
 	err := migration.Start()
-	actual, err := io.ReadAll(ntpMigrationContent)
 	assert.NoError(t, err)
 
-	mocks.fs.AssertCalled(t, "Create", ntpSecMigrationFilePath)
-	mocks.cmd.AssertNotCalled(t, "Commander")
-	assert.Equal(t, "1.2.x #not-upgraded", string(actual))
+	mocks.fu.AssertCalled(t, "CreateOrUpdateFile", tNtpSecMigrationFilePath, "1.2.x #not-upgraded")
+	mocks.cmd.AssertNotCalled(t, "Run")
 }
 
 func Test_NTPSecDefaultConfWillBeDisabled_NTPClassicConfNotExists(t *testing.T) {
@@ -74,8 +78,8 @@ func Test_NTPSecDefaultConfWillBeDisabled_NTPClassicConfNotExists(t *testing.T)
 	defaultNtpSecCommands := "server 2.tr.pool.ntp.org\n" + defaultNtpSecCommands
 	ntpSecDefaultConf := NewMockFile(defaultNtpSecCommands)
 
-	mocks.fu.On("IsFileExist", ntpSecMigrationFilePath).Return(false, nil)
-	mocks.fu.On("IsFileExist", NTPClassicConfPath).Return(false, nil)
+	mocks.fu.On("IsFileExist", tNtpSecMigrationFilePath).Return(false, nil)
+	mocks.fu.On("IsFileExist", tNtpClassicConfPath).Return(false, nil)
 	mocks.fs.On("Open", NTPSecConfPath).Return(ntpSecDefaultConf, nil).Once()
 	mocks.fs.On("Create", NTPSecConfPath).Return(ntpSecDefaultConf, nil).Once()
 	mockRemainingMethodsWithSuccessfulResults(mocks)
@@ -95,30 +99,27 @@ func Test_NTPSecDefaultConfWillBeDisabled_NTPClassicConfNotExists(t *testing.T)
 
 func Test_MigrationRuns_IfMigrationRequired(t *testing.T) {
 	mocks, migration := generateMockNtpSecMigration()
-	mocks.fu.On("IsFileExist", ntpSecMigrationFilePath).Return(false, nil)
-	mocks.fu.On("IsFileExist", NTPClassicConfPath).Return(true, nil)
+	mocks.fu.On("IsFileExist", tNtpSecMigrationFilePath).Return(false, nil)
+	mocks.fu.On("IsFileExist", tNtpClassicConfPath).Return(true, nil)
 	mockRemainingMethodsWithSuccessfulResults(mocks)
 
 	err := migration.Start()
 	assert.NoError(t, err)
 
 	mocks.fs.AssertCalled(t, "Open", NTPSecConfPath)
-	mocks.fs.AssertCalled(t, "OpenFile", NTPSecConfPath, os.O_APPEND|os.O_WRONLY, fs.FileMode(0666))
-	mocks.fs.AssertCalled(t, "Create", ntpSecMigrationFilePath)
-	mocks.fs.AssertCalled(t, "Remove", ntpSecBackupConfPath)
-	mocks.cmd.AssertCalled(t, "Commander", ntpconfigurator.UpdateSystemTimeCmd)
+	mocks.fu.AssertCalled(t, "AppendToFile", NTPSecConfPath, mock.Anything)
+	mocks.fu.AssertCalled(t, "CreateOrUpdateFile", tNtpSecMigrationFilePath, ntpSecVersion)
+	mocks.fs.AssertCalled(t, "Remove", tNtpSecBackupConfPath)
+	mocks.cmd.AssertCalled(t, "Run", ntpconfigurator.UpdateSystemTimeCmd)
+	assert.Equal(t, []string{"stop ntpsec", "start ntpsec"}, mocks.services.Calls())
 }
 
 func Test_CommandsCopied_WhenMigrationRuns(t *testing.T) {
 	mocks, migration := generateMockNtpSecMigration()
-	ntpSecConf := NewEmptyMockFile()
-	mocks.fs.On("Open", NTPClassicConfPath).Return(NewMockFile(ntpClassicCommands), nil).Once()
-	mocks.fs.On("OpenFile", NTPSecConfPath, os.O_APPEND|os.O_WRONLY, fs.FileMode(0666)).Return(ntpSecConf, nil).Once()
+	mocks.fs.On("Open", tNtpClassicConfPath).Return(NewMockFile(ntpClassicCommands), nil).Once()
 	mockRemainingMethodsWithSuccessfulResults(mocks)
 
 	err := migration.Start()
-
-	actual, err := io.ReadAll(ntpSecConf)
 	assert.NoError(t, err)
 
 	const expectedCommands = "" +
@@ -127,16 +128,15 @@ func Test_CommandsCopied_WhenMigrationRuns(t *testing.T) {
 		"server 0.tr.pool.ntp.org #iedk-migration\n" +
 		"server 2.tr.pool.ntp.org #iedk-migration\n" +
 		"pool 1.tr.pool.ntp.org #iedk-migration"
-	assert.Equal(t, expectedCommands, string(actual))
+	mocks.fu.AssertCalled(t, "AppendToFile", NTPSecConfPath, expectedCommands)
 }
 
 func Test_MigrationDisablesNTPSecDefaults(t *testing.T) {
 	mocks, migration := generateMockNtpSecMigration()
 	ntpSecConf := NewMockFile(defaultNtpSecCommands)
-	mocks.fs.On("Open", NTPClassicConfPath).Return(NewMockFile(ntpClassicCommands), nil).Once()
+	mocks.fs.On("Open", tNtpClassicConfPath).Return(NewMockFile(ntpClassicCommands), nil).Once()
 	mocks.fs.On("Open", NTPSecConfPath).Return(ntpSecConf, nil).Once()
 	mocks.fs.On("Create", NTPSecConfPath).Return(ntpSecConf, nil).Once()
-	mocks.fs.On("OpenFile", NTPSecConfPath, os.O_APPEND|os.O_WRONLY, fs.FileMode(0666)).Return(ntpSecConf, nil).Once()
 	mockRemainingMethodsWithSuccessfulResults(mocks)
 
 	err := migration.Start()
@@ -152,14 +152,14 @@ func Test_MigrationDisablesNTPSecDefaults(t *testing.T) {
 
 func Test_MigrationSkipped_MigrationFileError(t *testing.T) {
 	mocks, migration := generateMockNtpSecMigration()
-	mocks.fu.On("IsFileExist", ntpSecMigrationFilePath).Return(false, errors.New("an error occurred"))
-	mocks.fu.On("IsFileExist", NTPClassicConfPath).Return(true, errors.New("an error occurred"))
+	mocks.fu.On("IsFileExist", tNtpSecMigrationFilePath).Return(false, errors.New("an error occurred"))
+	mocks.fu.On("IsFileExist", tNtpClassicConfPath).Return(true, errors.New("an error occurred"))
 
 	err := migration.Start()
 
-	mocks.fs.AssertNotCalled(t, "Create", mock.Anything)
-	mocks.fs.AssertNotCalled(t, "MkdirAll", filepath.Dir(ntpSecMigrationFilePath), fs.FileMode(0666))
-	mocks.cmd.AssertNotCalled(t, "Commander", ntpconfigurator.StartNtpSecService)
+	mocks.fu.AssertNotCalled(t, "CreateOrUpdateFile", mock.Anything, mock.Anything)
+	mocks.fs.AssertNotCalled(t, "MkdirAll", filepath.Dir(tNtpSecMigrationFilePath), fs.FileMode(0666))
+	assert.NotContains(t, mocks.services.Calls(), "start ntpsec")
 	assert.ErrorContains(t, err, "an error occurred")
 }
 
@@ -171,68 +171,68 @@ func Test_MigrationSkipped_OpeningNewNTPConfError(t *testing.T) {
 	err := migration.Start()
 
 	mocks.fs.AssertNotCalled(t, "Create", NTPSecConfPath)
-	mocks.cmd.AssertNotCalled(t, "Commander", ntpconfigurator.StartNtpSecService)
-	mocks.fs.AssertCalled(t, "Move", ntpSecBackupConfPath, NTPSecConfPath)
+	assert.NotContains(t, mocks.services.Calls(), "start ntpsec")
+	mocks.fs.AssertCalled(t, "Move", tNtpSecBackupConfPath, NTPSecConfPath)
 	assert.ErrorContains(t, err, " error occurred: cannot open new ntp.conf")
 }
 
 func Test_MigrationSkipped_CopyNTPClassicCommandsError(t *testing.T) {
 	mocks, migration := generateMockNtpSecMigration()
-	mocks.fs.On("OpenFile", NTPSecConfPath, os.O_APPEND|os.O_WRONLY, fs.FileMode(0666)).Return(NewEmptyMockFile(), errors.New("error occurred while copying NTP classic commands"))
-	mocks.fs.On("Move", ntpSecBackupConfPath, NTPSecConfPath).Return(nil)
+	mocks.fu.On("AppendToFile", NTPSecConfPath, mock.Anything).Return(errors.New("error occurred while copying NTP classic commands"))
+	mocks.fs.On("Move", tNtpSecBackupConfPath, NTPSecConfPath).Return(nil)
 	mocks.fs.On("CreateOrUpdateFile", NTPSecConfPath, "").Return(nil).Once()
 	mockRemainingMethodsWithSuccessfulResults(mocks)
 
 	err := migration.Start()
 
-	mocks.fs.AssertNotCalled(t, "Create", ntpSecMigrationFilePath)
-	mocks.cmd.AssertNotCalled(t, "Commander", ntpconfigurator.StartNtpSecService)
+	mocks.fs.AssertNotCalled(t, "Create", tNtpSecMigrationFilePath)
+	assert.NotContains(t, mocks.services.Calls(), "start ntpsec")
 	assert.ErrorContains(t, err, "error occurred while copying NTP classic commands")
 }
 
 func Test_MigrationSkipped_RollbackRuns_WritingCommandsToNtpSecError(t *testing.T) {
 	mocks, migration := generateMockNtpSecMigration()
-	mocks.fs.On("OpenFile", NTPSecConfPath, os.O_APPEND|os.O_WRONLY, fs.FileMode(0666)).Return(NewEmptyMockFile(), errors.New("error occurred"))
+	mocks.fu.On("AppendToFile", NTPSecConfPath, mock.Anything).Return(errors.New("error occurred"))
 	mocks.fu.On("CreateOrUpdateFile", NTPSecConfPath, "").Return(nil).Once()
 	mockRemainingMethodsWithSuccessfulResults(mocks)
 
 	err := migration.Start()
 
-	mocks.fs.AssertNotCalled(t, "Create", ntpSecMigrationFilePath)
-	mocks.fs.AssertCalled(t, "Move", ntpSecBackupConfPath, NTPSecConfPath)
-	mocks.cmd.AssertNotCalled(t, "Commander", ntpconfigurator.StartNtpSecService)
+	mocks.fs.AssertNotCalled(t, "Create", tNtpSecMigrationFilePath)
+	mocks.fs.AssertCalled(t, "Move", tNtpSecBackupConfPath, NTPSecConfPath)
+	assert.NotContains(t, mocks.services.Calls(), "start ntpsec")
 	assert.ErrorContains(t, err, "error occurred")
 }
 
 func Test_MigrationSkipped_RollbackNotRuns_NtpClassicConfNotFound(t *testing.T) {
 	mocks, migration := generateMockNtpSecMigration()
-	mocks.fs.On("Open", NTPClassicConfPath).Return(NewEmptyMockFile(), errors.New("error occurred"))
+	mocks.fs.On("Open", tNtpClassicConfPath).Return(NewEmptyMockFile(), errors.New("error occurred"))
 	mockRemainingMethodsWithSuccessfulResults(mocks)
 
 	err := migration.Start()
 
-	mocks.fs.AssertCalled(t, "Create", ntpSecMigrationFilePath)
-	mocks.fs.AssertNotCalled(t, "Move", ntpSecBackupConfPath, NTPSecConfPath)
+	mocks.fu.AssertCalled(t, "CreateOrUpdateFile", tNtpSecMigrationFilePath, ntpSecVersion)
+	mocks.fs.AssertNotCalled(t, "Move", tNtpSecBackupConfPath, NTPSecConfPath)
 	assert.NoError(t, err)
 }
 
 func Test_MigrationSkipped_CreatingNtpSecMigrationFileError(t *testing.T) {
 	mocks, migration := generateMockNtpSecMigration()
-	mocks.fs.On("Create", ntpSecMigrationFilePath).Return(NewEmptyMockFile(), errors.New("error occurred when creating ntp-Sec.migration file"))
+	mocks.fu.On("CreateOrUpdateFile", tNtpSecMigrationFilePath, mock.Anything).Return(errors.New("error occurred when creating ntp-Sec.migration file"))
 	mockRemainingMethodsWithSuccessfulResults(mocks)
 
 	err := migration.Start()
 
-	mocks.cmd.AssertNotCalled(t, "Commander", ntpconfigurator.StartNtpSecService)
+	assert.NotContains(t, mocks.services.Calls(), "start ntpsec")
 	assert.ErrorContains(t, err, "error occurred when creating ntp-Sec.migration file")
 }
 
 func Test_OsPackageIntegratedToNptClassicToNtpSecMigration(t *testing.T) {
-	migration := NewNTPClassicToNTPSecMigration()
+	migration := NewNTPClassicToNTPSecMigration(serviceconfig.Default())
 
 	_ = migration.FileSystemOperations.(*files.OsFileSystemOperations)
 	_ = migration.FileUtil.(*files.OsFileUtils)
-	_ = migration.Utils.(*ntpconfigurator.OsUtils)
+	_ = migration.Executor.(executor.OsExecutor)
 
 	assert.NoError(t, nil)
 }
@@ -243,8 +243,8 @@ func Test_UserInformed_IfUpdateSystemTimeFails(t *testing.T) {
 	defer log.SetOutput(os.Stdout)
 
 	mocks, migration := generateMockNtpSecMigration()
-	mocks.fs.On("Remove", ntpSecBackupConfPath).Return(fmt.Errorf("cannot remove backup file")).Once()
-	mocks.cmd.On("Commander", ntpconfigurator.UpdateSystemTimeCmd).Return([]byte{}, fmt.Errorf("cannot update system time"))
+	mocks.fs.On("Remove", tNtpSecBackupConfPath).Return(fmt.Errorf("cannot remove backup file")).Once()
+	mocks.cmd.On("Run", ntpconfigurator.UpdateSystemTimeCmd).Return([]byte{}, fmt.Errorf("cannot update system time"))
 	mockRemainingMethodsWithSuccessfulResults(mocks)
 
 	err := migration.Start()
@@ -255,27 +255,30 @@ func Test_UserInformed_IfUpdateSystemTimeFails(t *testing.T) {
 }
 
 func Test_ValuesOfConstantsAreValid(t *testing.T) {
-	assert.Equal(t, NTPClassicConfPath, "/etc/ntp.conf")
+	_, migration := generateMockNtpSecMigration()
+	assert.Equal(t, migration.classicConfPath(), "/etc/ntp.conf")
 	assert.Equal(t, NTPSecConfPath, "/etc/ntpsec/ntp.conf")
-	assert.Equal(t, ntpSecBackupConfPath, "/etc/ntpsec/ntp.conf.backup")
-	assert.Equal(t, ntpSecMigrationFilePath, "/etc/iedk/ntp/migration/ntpsec.migration")
+	assert.Equal(t, migration.backupConfPath(), "/etc/ntpsec/ntp.conf.backup")
+	assert.Equal(t, migration.migrationFilePath(), "/etc/iedk/ntp/migration/ntpsec.migration")
 	assert.Equal(t, iedkMigrationTag, "#iedk-migration")
 	assert.Equal(t, ntpSecVersion, "1.2.x")
 }
 
 type NtpToNtpSecMocks struct {
-	fs  *MockFileSystem
-	fu  *MockFileUtil
-	cmd *MockCommander
+	fs       *MockFileSystem
+	fu       *MockFileUtil
+	cmd      *MockExecutor
+	services *servicemanagertest.ServiceManager
 }
 
 func generateMockNtpSecMigration() (*NtpToNtpSecMocks, NTPClassicToNTPSecMigration) {
 	mockFsOp := new(MockFileSystem)
-	mockCommanderOp := new(MockCommander)
+	mockExecutorOp := new(MockExecutor)
 	mockFuOp := new(MockFileUtil)
-	migration := NTPClassicToNTPSecMigration{mockFsOp, mockFuOp, mockCommanderOp}
+	services := servicemanagertest.New(ntpconfigurator.NtpSecService)
+	migration := NTPClassicToNTPSecMigration{mockFsOp, mockFuOp, mockExecutorOp, services, serviceconfig.Default()}
 
-	return &NtpToNtpSecMocks{fs: mockFsOp, fu: mockFuOp, cmd: mockCommanderOp}, migration
+	return &NtpToNtpSecMocks{fs: mockFsOp, fu: mockFuOp, cmd: mockExecutorOp, services: services}, migration
 }
 
 // Important!
@@ -285,29 +288,27 @@ func mockRemainingMethodsWithSuccessfulResults(m *NtpToNtpSecMocks) {
 	//#
 	//File System mock
 	//mocks for old ntp conf file
-	m.fs.On("Open", NTPClassicConfPath).Return(NewEmptyMockFile(), nil).Once()
+	m.fs.On("Open", tNtpClassicConfPath).Return(NewEmptyMockFile(), nil).Once()
 	//mocks for new ntp conf file
 	m.fs.On("Open", NTPSecConfPath).Return(NewEmptyMockFile(), nil).Once()
-	m.fs.On("OpenFile", NTPSecConfPath, os.O_APPEND|os.O_WRONLY, fs.FileMode(0666)).Return(NewEmptyMockFile(), nil).Once()
 	m.fs.On("Create", NTPSecConfPath).Return(NewEmptyMockFile(), nil).Once()
 	//mocks for migration file
-	m.fs.On("Create", ntpSecMigrationFilePath).Return(NewEmptyMockFile(), nil).Once()
-	m.fs.On("MkdirAll", filepath.Dir(ntpSecMigrationFilePath), fs.FileMode(0666)).Return(nil).Once()
+	m.fs.On("MkdirAll", filepath.Dir(tNtpSecMigrationFilePath), fs.FileMode(0666)).Return(nil).Once()
 	//mocks for backup file
-	m.fs.On("Remove", ntpSecBackupConfPath).Return(nil).Once()
+	m.fs.On("Remove", tNtpSecBackupConfPath).Return(nil).Once()
 	//mocks for move
-	m.fs.On("Move", ntpSecBackupConfPath, NTPSecConfPath).Return(nil)
+	m.fs.On("Move", tNtpSecBackupConfPath, NTPSecConfPath).Return(nil)
 
 	//#
 	//File Util mock
-	m.fu.On("IsFileExist", NTPClassicConfPath).Return(true, nil)
-	m.fu.On("IsFileExist", ntpSecMigrationFilePath).Return(false, nil).Once()
-	m.fu.On("Copy", NTPSecConfPath, ntpSecBackupConfPath).Return(nil).Once()
+	m.fu.On("IsFileExist", tNtpClassicConfPath).Return(true, nil)
+	m.fu.On("IsFileExist", tNtpSecMigrationFilePath).Return(false, nil).Once()
+	m.fu.On("Copy", NTPSecConfPath, tNtpSecBackupConfPath).Return(nil).Once()
 	m.fu.On("CreateOrUpdateFile", NTPSecConfPath, mock.Anything).Return(nil).Once()
+	m.fu.On("AppendToFile", NTPSecConfPath, mock.Anything).Return(nil).Once()
+	m.fu.On("CreateOrUpdateFile", tNtpSecMigrationFilePath, mock.Anything).Return(nil).Once()
 
 	//#
 	//CMD executions mock
-	m.cmd.On("Commander", ntpconfigurator.StopNtpSecService).Return([]byte("NTPD Stopped!"), nil)
-	m.cmd.On("Commander", ntpconfigurator.UpdateSystemTimeCmd).Return([]byte("System Time Updated!"), nil)
-	m.cmd.On("Commander", ntpconfigurator.StartNtpSecService).Return([]byte("NTPD Started!"), nil)
+	m.cmd.On("Run", ntpconfigurator.UpdateSystemTimeCmd).Return([]byte("System Time Updated!"), nil)
 }
@@ -0,0 +1,102 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds a command without an own timeout.
+const DefaultTimeout = 10 * time.Second
+
+// waitDelay is the time the output pipes are drained after the command was killed.
+const waitDelay = time.Second
+
+// ErrEmptyCommand is returned for a command without a program.
+var ErrEmptyCommand = errors.New("empty command")
+
+// Command is a program with its arguments, it is started without a shell.
+type Command struct {
+	Argv    []string
+	Timeout time.Duration // deadline of the command, an earlier deadline of the context is kept, 0 is DefaultTimeout
+}
+
+// NewCommand returns the command with the timeout.
+func NewCommand(timeout time.Duration, argv ...string) Command {
+	return Command{Argv: argv, Timeout: timeout}
+}
+
+func (c Command) String() string {
+	return strings.Join(c.Argv, " ")
+}
+
+// Executor runs commands, the context cancels the command and its deadline is kept if it is before the timeout.
+type Executor interface {
+	Run(ctx context.Context, command Command) ([]byte, error)
+}
+
+// Error is returned when a command could not be started, failed or was killed.
+type Error struct {
+	Command  Command
+	ExitCode int    // exit code of the command, -1 if it did not exit by itself
+	Stderr   string // standard error output of the command
+	Err      error  // *exec.ExitError, context.DeadlineExceeded, context.Canceled or the error starting the command
+}
+
+func (e *Error) Error() string {
+	message := fmt.Sprintf("%s: %v", e.Command, e.Err)
+	if e.ExitCode >= 0 {
+		message = fmt.Sprintf("%s: exit code %d", e.Command, e.ExitCode)
+	}
+	if e.Stderr != "" {
+		message += ": " + e.Stderr
+	}
+	return message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// OsExecutor starts the commands as processes.
+type OsExecutor struct{}
+
+// Run starts the command and returns its standard output, the standard error output is returned in the Error.
+func (o OsExecutor) Run(ctx context.Context, command Command) ([]byte, error) {
+	if len(command.Argv) == 0 {
+		return nil, &Error{Command: command, ExitCode: -1, Err: ErrEmptyCommand}
+	}
+	timeout := command.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, command.Argv[0], command.Argv[1:]...)
+	cmd.WaitDelay = waitDelay
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err == nil {
+		return stdout.Bytes(), nil
+	}
+	commandErr := &Error{Command: command, ExitCode: -1, Stderr: strings.TrimSpace(stderr.String()), Err: err}
+	var exitErr *exec.ExitError
+	if ctx.Err() != nil {
+		commandErr.Err = ctx.Err()
+	} else if errors.As(err, &exitErr) {
+		commandErr.ExitCode = exitErr.ExitCode()
+	}
+	return stdout.Bytes(), commandErr
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Run(t *testing.T) {
+	out, err := OsExecutor{}.Run(context.Background(), NewCommand(0, "echo", "a b", "$HOME"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a b $HOME\n", string(out), "Did not get expected result. The arguments must not be expanded by a shell")
+}
+
+func Test_Run_ExitCode(t *testing.T) {
+	_, err := OsExecutor{}.Run(context.Background(), NewCommand(0, "sh", "-c", "echo failed >&2; exit 3"))
+
+	var commandErr *Error
+	assert.True(t, errors.As(err, &commandErr), "Did not get expected result. Wanted: *Error, got: %T", err)
+	assert.Equal(t, 3, commandErr.ExitCode)
+	assert.Equal(t, "failed", commandErr.Stderr)
+	var exitErr *exec.ExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, "sh -c echo failed >&2; exit 3: exit code 3: failed", err.Error())
+}
+
+func Test_Run_Timeout(t *testing.T) {
+	start := time.Now()
+	_, err := OsExecutor{}.Run(context.Background(), NewCommand(100*time.Millisecond, "sleep", "5"))
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 3*time.Second)
+	var commandErr *Error
+	assert.True(t, errors.As(err, &commandErr))
+	assert.Equal(t, -1, commandErr.ExitCode)
+}
+
+func Test_Run_ContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := OsExecutor{}.Run(ctx, NewCommand(time.Minute, "sleep", "5"))
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Did not get expected result. The earlier deadline of the context must be kept")
+}
+
+func Test_Run_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := OsExecutor{}.Run(ctx, NewCommand(0, "sleep", "5"))
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_Run_NotFound(t *testing.T) {
+	_, err := OsExecutor{}.Run(context.Background(), NewCommand(0, "/nonexistent/ntpq"))
+
+	var commandErr *Error
+	assert.True(t, errors.As(err, &commandErr))
+	assert.Equal(t, -1, commandErr.ExitCode)
+
+	_, err = OsExecutor{}.Run(context.Background(), Command{})
+	assert.ErrorIs(t, err, ErrEmptyCommand)
+}
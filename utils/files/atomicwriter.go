@@ -0,0 +1,110 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package files
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"path/filepath"
+)
+
+// WriteFileAtomic replaces the content of path so that a power cut leaves either the old or the new content.
+// The content is written to a temporary file in the same directory, synced and renamed over path, then the
+// directory is synced. An existing file keeps its mode and ownership, a new file is created with perm.
+func (fileUtils *OsFileUtils) WriteFileAtomic(path string, content []byte, perm fs.FileMode) error {
+	return fileUtils.writeFileAtomic(path, content, perm, true)
+}
+
+// WriteFileAtomicWithMode is WriteFileAtomic for files whose mode must not be kept, e.g. secrets in a file which may
+// have been created world-readable. The file always has perm, an existing file keeps its ownership.
+func (fileUtils *OsFileUtils) WriteFileAtomicWithMode(path string, content []byte, perm fs.FileMode) error {
+	return fileUtils.writeFileAtomic(path, content, perm, false)
+}
+
+func (fileUtils *OsFileUtils) writeFileAtomic(path string, content []byte, perm fs.FileMode, keepMode bool) error {
+	mode := perm
+	uid, gid, hasOwner := -1, -1, false
+	if info, err := fileUtils.Stat(path); err == nil {
+		if keepMode {
+			mode = info.Mode().Perm()
+		}
+		uid, gid, hasOwner = fileOwner(info)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	temp, err := fileUtils.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tempPath := temp.Name()
+	if err := fileUtils.writeTemp(temp, content, mode, uid, gid, hasOwner); err != nil {
+		if removeErr := fileUtils.Remove(tempPath); removeErr != nil {
+			log.Printf("Cannot remove temporary file %s: %s\n", tempPath, removeErr.Error())
+		}
+		return fmt.Errorf("writing %s failed: %w", path, err)
+	}
+	if err := fileUtils.Move(tempPath, path); err != nil {
+		if removeErr := fileUtils.Remove(tempPath); removeErr != nil {
+			log.Printf("Cannot remove temporary file %s: %s\n", tempPath, removeErr.Error())
+		}
+		return fmt.Errorf("replacing %s failed: %w", path, err)
+	}
+	return fileUtils.syncDir(dir)
+}
+
+// writeTemp sets the mode and ownership of the temporary file, then writes and syncs its content, the file is closed.
+// The mode is set first so the content is never readable with other permissions than the ones of the result.
+func (fileUtils *OsFileUtils) writeTemp(temp FileIO, content []byte, mode fs.FileMode, uid int, gid int, hasOwner bool) error {
+	if err := fileUtils.Chmod(temp.Name(), mode); err != nil {
+		temp.Close()
+		return err
+	}
+	if hasOwner {
+		if err := fileUtils.Chown(temp.Name(), uid, gid); err != nil {
+			temp.Close()
+			return err
+		}
+	}
+	if _, err := temp.Write(content); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return err
+	}
+	return temp.Close()
+}
+
+// syncDir syncs the directory so that the rename survives a power cut.
+func (fileUtils *OsFileUtils) syncDir(dir string) error {
+	directory, err := fileUtils.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer directory.Close()
+	return directory.Sync()
+}
+
+// AppendToFile appends the content to the file, the file is replaced atomically.
+func (fileUtils *OsFileUtils) AppendToFile(path string, content string) error {
+	file, err := fileUtils.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	existing, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	return fileUtils.WriteFileAtomic(path, append(existing, content...), newFilePermissions)
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package files_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ntpservice/utils/files"
+	. "ntpservice/utils/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const tConfPath = "/etc/ntpsec/ntp.conf"
+const tTempPath = "/etc/ntpsec/.ntp.conf.tmp-1"
+
+func prepareAtomicWriter() (*MockFileSystem, *files.OsFileUtils, *MockFileIO) {
+	fileSystem := new(MockFileSystem)
+	temp := NewNamedMockFile(tTempPath)
+	fileSystem.On("CreateTemp", "/etc/ntpsec", ".ntp.conf.tmp-*").Return(temp, nil)
+	return fileSystem, &files.OsFileUtils{FileSystemOperations: fileSystem}, temp
+}
+
+func notExisting(fileSystem *MockFileSystem) {
+	fileSystem.On("Stat", tConfPath).Return((*MockFileInfo)(nil), fs.ErrNotExist)
+}
+
+func Test_WriteFileAtomic_NewFile(t *testing.T) {
+	fileSystem, fileUtils, temp := prepareAtomicWriter()
+	directory := NewNamedMockFile("/etc/ntpsec")
+	notExisting(fileSystem)
+	fileSystem.On("Chmod", tTempPath, fs.FileMode(0644)).Return(nil)
+	fileSystem.On("Move", tTempPath, tConfPath).Return(nil)
+	fileSystem.On("Open", "/etc/ntpsec").Return(directory, nil)
+
+	err := fileUtils.WriteFileAtomic(tConfPath, []byte("server 10.0.0.1\n"), 0644)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "server 10.0.0.1\n", temp.String())
+	assert.True(t, temp.Synced, "Did not get expected result. The temporary file must be synced")
+	assert.True(t, directory.Synced, "Did not get expected result. The directory must be synced after the rename")
+	fileSystem.AssertNotCalled(t, "Chown", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_WriteFileAtomic_KeepsModeOfExistingFile(t *testing.T) {
+	fileSystem, fileUtils, _ := prepareAtomicWriter()
+	info := new(MockFileInfo)
+	info.On("Mode").Return(0600)
+	info.On("Sys").Return(nil)
+	fileSystem.On("Stat", tConfPath).Return(info, nil)
+	fileSystem.On("Chmod", tTempPath, fs.FileMode(0600)).Return(nil)
+	fileSystem.On("Move", tTempPath, tConfPath).Return(nil)
+	fileSystem.On("Open", "/etc/ntpsec").Return(NewEmptyMockFile(), nil)
+
+	assert.NoError(t, fileUtils.WriteFileAtomic(tConfPath, []byte("content"), 0644))
+	fileSystem.AssertCalled(t, "Chmod", tTempPath, fs.FileMode(0600))
+}
+
+func Test_WriteFileAtomic_FaultsKeepTheFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		inject func(fileSystem *MockFileSystem, temp *MockFileIO)
+	}{
+		{"write", func(fileSystem *MockFileSystem, temp *MockFileIO) {
+			temp.WriteErr = errors.New("no space left on device")
+		}},
+		{"sync", func(fileSystem *MockFileSystem, temp *MockFileIO) { temp.SyncErr = errors.New("input/output error") }},
+		{"close", func(fileSystem *MockFileSystem, temp *MockFileIO) { temp.CloseErr = errors.New("input/output error") }},
+		{"chmod", func(fileSystem *MockFileSystem, temp *MockFileIO) {
+			fileSystem.On("Chmod", tTempPath, fs.FileMode(0644)).Return(errors.New("operation not permitted"))
+		}},
+		{"rename", func(fileSystem *MockFileSystem, temp *MockFileIO) {
+			fileSystem.On("Move", tTempPath, tConfPath).Return(errors.New("read-only file system"))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileSystem, fileUtils, temp := prepareAtomicWriter()
+			notExisting(fileSystem)
+			tt.inject(fileSystem, temp)
+			fileSystem.On("Chmod", tTempPath, fs.FileMode(0644)).Return(nil)
+			fileSystem.On("Move", tTempPath, tConfPath).Return(nil)
+			fileSystem.On("Remove", tTempPath).Return(nil)
+
+			err := fileUtils.WriteFileAtomic(tConfPath, []byte("content"), 0644)
+
+			assert.Error(t, err)
+			fileSystem.AssertCalled(t, "Remove", tTempPath)
+			fileSystem.AssertNotCalled(t, "Open", "/etc/ntpsec")
+			if tt.name != "rename" {
+				fileSystem.AssertNotCalled(t, "Move", tTempPath, tConfPath)
+			}
+		})
+	}
+}
+
+func Test_WriteFileAtomic_StatAndCreateTempErrors(t *testing.T) {
+	fileSystem := new(MockFileSystem)
+	fileUtils := &files.OsFileUtils{FileSystemOperations: fileSystem}
+	fileSystem.On("Stat", tConfPath).Return((*MockFileInfo)(nil), fs.ErrPermission).Once()
+
+	assert.ErrorIs(t, fileUtils.WriteFileAtomic(tConfPath, []byte("content"), 0644), fs.ErrPermission)
+	fileSystem.AssertNotCalled(t, "CreateTemp", mock.Anything, mock.Anything)
+
+	notExisting(fileSystem)
+	fileSystem.On("CreateTemp", "/etc/ntpsec", ".ntp.conf.tmp-*").Return((*MockFileIO)(nil), fs.ErrPermission)
+
+	assert.ErrorIs(t, fileUtils.WriteFileAtomic(tConfPath, []byte("content"), 0644), fs.ErrPermission)
+}
+
+func Test_WriteFileAtomic_OsFileSystem(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ntp.conf")
+	assert.NoError(t, os.WriteFile(path, []byte("server 10.0.0.1\n"), 0640))
+	fileUtils := &files.OsFileUtils{FileSystemOperations: &files.OsFileSystemOperations{}}
+
+	assert.NoError(t, fileUtils.WriteFileAtomic(path, []byte("server 10.0.0.2\n"), 0644))
+	assert.NoError(t, fileUtils.AppendToFile(path, "pool 0.pool.ntp.org\n"))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "server 10.0.0.2\npool 0.pool.ntp.org\n", string(content))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0640), info.Mode().Perm())
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "Did not get expected result. No temporary file must be left")
+}
+
+func Test_WriteFileAtomic_ModeSetBeforeContent(t *testing.T) {
+	fileSystem, fileUtils, temp := prepareAtomicWriter()
+	notExisting(fileSystem)
+	var contentAtChmod string
+	fileSystem.On("Chmod", tTempPath, fs.FileMode(0600)).Run(func(mock.Arguments) { contentAtChmod = temp.String() }).Return(nil)
+	fileSystem.On("Move", tTempPath, tConfPath).Return(nil)
+	fileSystem.On("Open", "/etc/ntpsec").Return(NewEmptyMockFile(), nil)
+
+	assert.NoError(t, fileUtils.WriteFileAtomic(tConfPath, []byte("secret"), 0600))
+	assert.Equal(t, "", contentAtChmod, "Did not get expected result. The mode must be set before the content is written")
+	assert.Equal(t, "secret", temp.String())
+}
+
+func Test_WriteFileAtomicWithMode_ReplacesModeOfExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ntp.keys")
+	assert.NoError(t, os.WriteFile(path, []byte("1 MD5 old\n"), 0644))
+	assert.NoError(t, os.Chmod(path, 0644))
+	fileUtils := &files.OsFileUtils{FileSystemOperations: &files.OsFileSystemOperations{}}
+
+	assert.NoError(t, fileUtils.WriteFileAtomicWithMode(path, []byte("1 MD5 new\n"), 0600))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "1 MD5 new\n", string(content))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0600), info.Mode().Perm())
+}
@@ -1,5 +1,5 @@
 /*
- * Copyright © Siemens 2023 - 2025. ALL RIGHTS RESERVED.
+ * Copyright © Siemens 2023 - 2026. ALL RIGHTS RESERVED.
  * Licensed under the MIT license
  * See LICENSE file in the top-level directory
  */
@@ -34,6 +34,15 @@ type FileSystemOperations interface {
 
 	// Stat os.Stat file stats
 	Stat(name string) (fs.FileInfo, error)
+
+	// CreateTemp os.CreateTemp
+	CreateTemp(dir string, pattern string) (FileIO, error)
+
+	// Chmod os.Chmod
+	Chmod(name string, mode fs.FileMode) error
+
+	// Chown os.Chown
+	Chown(name string, uid int, gid int) error
 }
 
 // FileIO is an interface to bring functionality of required file IO operations
@@ -42,6 +51,12 @@ type FileIO interface {
 	io.Reader
 	io.Writer
 	io.Closer
+
+	// Sync commits the content to the storage, os.File.Sync
+	Sync() error
+
+	// Name os.File.Name
+	Name() string
 }
 
 // OsFileSystemOperations FileSystemOperations implementation, wrapper for functionalities from `os` package
@@ -91,6 +106,21 @@ func (fileSystem *OsFileSystemOperations) Stat(name string) (fs.FileInfo, error)
 	return os.Stat(name)
 }
 
+func (fileSystem *OsFileSystemOperations) CreateTemp(dir string, pattern string) (FileIO, error) {
+	if file, err := os.CreateTemp(dir, pattern); err == nil {
+		return &OsFile{file}, nil
+	} else {
+		return nil, err
+	}
+}
+
+func (fileSystem *OsFileSystemOperations) Chmod(name string, mode fs.FileMode) error {
+	return os.Chmod(name, mode)
+}
+
+func (fileSystem *OsFileSystemOperations) Chown(name string, uid int, gid int) error {
+	return os.Chown(name, uid, gid)
+}
 
 // OsFile wrapper type for os.File
 type OsFile struct {
@@ -108,3 +138,11 @@ func (osFile *OsFile) Write(p []byte) (n int, err error) {
 func (osFile *OsFile) Close() error {
 	return osFile.file.Close()
 }
+
+func (osFile *OsFile) Sync() error {
+	return osFile.file.Sync()
+}
+
+func (osFile *OsFile) Name() string {
+	return osFile.file.Name()
+}
@@ -1,5 +1,5 @@
 /*
- * Copyright © Siemens 2023 - 2025. ALL RIGHTS RESERVED.
+ * Copyright © Siemens 2023 - 2026. ALL RIGHTS RESERVED.
  * Licensed under the MIT license
  * See LICENSE file in the top-level directory
  */
@@ -7,7 +7,6 @@
 package files
 
 import (
-	"bufio"
 	"errors"
 	"io"
 	"io/fs"
@@ -15,10 +14,17 @@ import (
 	"path/filepath"
 )
 
+// newFilePermissions are the permissions of files created by CreateOrUpdateFile and AppendToFile.
+const newFilePermissions = 0644
+
 type FileUtil interface {
 	Copy(source string, target string) error
 	CreateOrUpdateFile(path string, content string) error
 	IsFileExist(path string) (bool, error)
+	ReadFile(path string) ([]byte, error)
+	WriteFileAtomic(path string, content []byte, perm fs.FileMode) error
+	WriteFileAtomicWithMode(path string, content []byte, perm fs.FileMode) error
+	AppendToFile(path string, content string) error
 }
 
 type OsFileUtils struct {
@@ -55,18 +61,7 @@ func (fileUtils *OsFileUtils) Copy(source string, target string) error {
 }
 
 func (fileUtils *OsFileUtils) CreateOrUpdateFile(path string, content string) error {
-	if targetFile, err := fileUtils.Create(path); err == nil {
-		writer := bufio.NewWriter(targetFile)
-		if _, err := writer.WriteString(content); err != nil {
-			return err
-		}
-
-		if err = writer.Flush(); err != nil {
-			return err
-		}
-		defer targetFile.Close()
-	}
-	return nil
+	return fileUtils.WriteFileAtomic(path, []byte(content), newFilePermissions)
 }
 
 func (fileUtils *OsFileUtils) IsFileExist(path string) (bool, error) {
@@ -88,3 +83,13 @@ func (fileUtils *OsFileUtils) IsFileExist(path string) (bool, error) {
 
 	return true, nil
 }
+
+// ReadFile returns the content of the file, os.ReadFile through the FileSystemOperations.
+func (fileUtils *OsFileUtils) ReadFile(path string) ([]byte, error) {
+	file, err := fileUtils.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
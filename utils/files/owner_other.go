@@ -0,0 +1,16 @@
+//go:build !unix
+
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package files
+
+import "io/fs"
+
+// fileOwner returns no owner, ownership is only kept on unix systems.
+func fileOwner(info fs.FileInfo) (int, int, bool) {
+	return -1, -1, false
+}
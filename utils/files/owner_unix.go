@@ -0,0 +1,22 @@
+//go:build unix
+
+/*
+ * Copyright © Siemens 2026 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package files
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// fileOwner returns the user and group owning the file.
+func fileOwner(info fs.FileInfo) (int, int, bool) {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return int(stat.Uid), int(stat.Gid), true
+	}
+	return -1, -1, false
+}
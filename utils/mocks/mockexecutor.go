@@ -0,0 +1,24 @@
+/*
+ * Copyright © Siemens 2023 - 2026. ALL RIGHTS RESERVED.
+ * Licensed under the MIT license
+ * See LICENSE file in the top-level directory
+ */
+
+package mocks
+
+import (
+	"context"
+
+	"ntpservice/utils/executor"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockExecutor struct {
+	mock.Mock
+}
+
+func (mock *MockExecutor) Run(ctx context.Context, command executor.Command) ([]byte, error) {
+	args := mock.Called(command)
+	return args.Get(0).([]byte), args.Error(1)
+}
@@ -1,5 +1,5 @@
 /*
- * Copyright © Siemens 2023 - 2025. ALL RIGHTS RESERVED.
+ * Copyright © Siemens 2023 - 2026. ALL RIGHTS RESERVED.
  * Licensed under the MIT license
  * See LICENSE file in the top-level directory
  */
@@ -11,16 +11,27 @@ import (
 )
 
 // MockFileIO Basic Mock File with the functionality of both io.Reader and io.Writer
+// Faults are injected by setting the errors returned by Write, Sync and Close.
 type MockFileIO struct {
-	content *bytes.Buffer
+	content  *bytes.Buffer
+	name     string
+	WriteErr error
+	SyncErr  error
+	CloseErr error
+	Synced   bool
 }
 
 func NewEmptyMockFile() *MockFileIO {
-	return &MockFileIO{bytes.NewBuffer([]byte{})}
+	return &MockFileIO{content: bytes.NewBuffer([]byte{})}
 }
 
 func NewMockFile(content string) *MockFileIO {
-	return &MockFileIO{bytes.NewBufferString(content)}
+	return &MockFileIO{content: bytes.NewBufferString(content)}
+}
+
+// NewNamedMockFile returns an empty mock file with the name returned by Name, e.g. of a temporary file.
+func NewNamedMockFile(name string) *MockFileIO {
+	return &MockFileIO{content: bytes.NewBuffer([]byte{}), name: name}
 }
 
 func (mock *MockFileIO) Read(p []byte) (n int, err error) {
@@ -28,9 +39,29 @@ func (mock *MockFileIO) Read(p []byte) (n int, err error) {
 }
 
 func (mock *MockFileIO) Write(p []byte) (n int, err error) {
+	if mock.WriteErr != nil {
+		return 0, mock.WriteErr
+	}
 	return mock.content.Write(p)
 }
 
 func (mock *MockFileIO) Close() error {
+	return mock.CloseErr
+}
+
+func (mock *MockFileIO) Sync() error {
+	if mock.SyncErr != nil {
+		return mock.SyncErr
+	}
+	mock.Synced = true
 	return nil
 }
+
+func (mock *MockFileIO) Name() string {
+	return mock.name
+}
+
+// String returns the content which was not read yet.
+func (mock *MockFileIO) String() string {
+	return mock.content.String()
+}
@@ -1,5 +1,5 @@
 /*
- * Copyright © Siemens 2023 - 2025. ALL RIGHTS RESERVED.
+ * Copyright © Siemens 2023 - 2026. ALL RIGHTS RESERVED.
  * Licensed under the MIT license
  * See LICENSE file in the top-level directory
  */
@@ -56,6 +56,20 @@ func (mock *MockFileSystem) Move(source string, target string) error {
 	return mock.Called(source, target).Error(0)
 }
 
+func (mock *MockFileSystem) CreateTemp(dir string, pattern string) (FileIO, error) {
+	args := mock.Called(dir, pattern)
+
+	return args.Get(0).(*MockFileIO), args.Error(1)
+}
+
+func (mock *MockFileSystem) Chmod(name string, mode fs.FileMode) error {
+	return mock.Called(name, mode).Error(0)
+}
+
+func (mock *MockFileSystem) Chown(name string, uid int, gid int) error {
+	return mock.Called(name, uid, gid).Error(0)
+}
+
 func (mock *MockFileInfo) Name() string {
 	return mock.Called().String(0)
 }
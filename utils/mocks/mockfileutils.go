@@ -1,12 +1,16 @@
 /*
- * Copyright © Siemens 2023 - 2025. ALL RIGHTS RESERVED.
+ * Copyright © Siemens 2023 - 2026. ALL RIGHTS RESERVED.
  * Licensed under the MIT license
  * See LICENSE file in the top-level directory
  */
 
 package mocks
 
-import "github.com/stretchr/testify/mock"
+import (
+	"io/fs"
+
+	"github.com/stretchr/testify/mock"
+)
 
 type MockFileUtil struct {
 	mock.Mock
@@ -18,6 +22,12 @@ func (mock *MockFileUtil) IsFileExist(path string) (bool, error) {
 	return args.Bool(0), args.Error(1)
 }
 
+func (mock *MockFileUtil) ReadFile(path string) ([]byte, error) {
+	args := mock.Called(path)
+	content, _ := args.Get(0).([]byte)
+	return content, args.Error(1)
+}
+
 func (mock *MockFileUtil) Copy(source string, target string) error {
 	args := mock.Called(source, target)
 	return args.Error(0)
@@ -27,3 +37,18 @@ func (mock *MockFileUtil) CreateOrUpdateFile(path string, content string) error
 	args := mock.Called(path, content)
 	return args.Error(0)
 }
+
+func (mock *MockFileUtil) WriteFileAtomic(path string, content []byte, perm fs.FileMode) error {
+	args := mock.Called(path, content, perm)
+	return args.Error(0)
+}
+
+func (mock *MockFileUtil) WriteFileAtomicWithMode(path string, content []byte, perm fs.FileMode) error {
+	args := mock.Called(path, content, perm)
+	return args.Error(0)
+}
+
+func (mock *MockFileUtil) AppendToFile(path string, content string) error {
+	args := mock.Called(path, content)
+	return args.Error(0)
+}